@@ -0,0 +1,86 @@
+// Package account_export writes the archive of everything a user has in TaskPixie, the copy of their data they may ask for.
+//
+// The archive is a ZIP of JSON documents, one per kind of data, with the files of the user under files/.
+package account_export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"io"
+	"path"
+	"time"
+)
+
+// File is a file of the user, put in the archive as is.
+type File struct {
+	Name    string
+	Content []byte
+}
+
+const readme = `This archive holds the data of your TaskPixie account, as of %s.
+
+profile.json         Your profile and preferences
+organizations.json   The organizations you're a member of
+projects.json        The projects you own or are a member of
+tasks.json           The tasks you own or are assigned to
+time_entries.json    The time you tracked
+views.json           The task views you saved
+files/               The files you uploaded, such as your avatar
+
+Times are RFC 3339 in UTC, dates are YYYY-MM-DD.
+`
+
+// Write writes the archive of the data and files to w, at the given time.
+func Write(w io.Writer, data *entity.AccountData, files []File, at time.Time) error {
+	archive := zip.NewWriter(w)
+
+	documents := []struct {
+		name  string
+		value interface{}
+	}{
+		{"profile.json", data.Profile},
+		{"organizations.json", data.Organizations},
+		{"projects.json", data.Projects},
+		{"tasks.json", data.Tasks},
+		{"time_entries.json", data.TimeEntries},
+		{"views.json", data.Views},
+	}
+
+	if err := add(archive, "README.txt", []byte(fmt.Sprintf(readme, at.UTC().Format(time.RFC3339))), at); err != nil {
+		return err
+	}
+
+	for _, document := range documents {
+		content, err := json.MarshalIndent(document.value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode %s: %v", document.name, err)
+		}
+		if err := add(archive, document.name, content, at); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range files {
+		// Names come from storage, they're kept from leaving files/
+		if err := add(archive, "files/"+path.Base("/"+file.Name), file.Content, at); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func add(archive *zip.Writer, name string, content []byte, at time.Time) error {
+	writer, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: at})
+	if err != nil {
+		return fmt.Errorf("add %s: %v", name, err)
+	}
+
+	if _, err := writer.Write(content); err != nil {
+		return fmt.Errorf("write %s: %v", name, err)
+	}
+
+	return nil
+}
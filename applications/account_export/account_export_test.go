@@ -0,0 +1,52 @@
+package account_export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/account_export"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	// Arrange
+	data := &entity.AccountData{
+		Profile:  &entity.User{Id: "user-1", Username: "pixie", Email: "pixie@gmail.com"},
+		Projects: []entity.AccountProject{{Id: "project-1", Title: "Launch", Role: "owner"}},
+		Tasks:    []entity.AccountTask{{Id: "task-1", Title: "Write docs", Owned: true}},
+	}
+	files := []account_export.File{{Name: "../avatar.webp", Content: []byte("image")}}
+
+	// Action
+	var buffer bytes.Buffer
+	err := account_export.Write(&buffer, data, files, time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC))
+
+	// Assert
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	assert.NoError(t, err)
+
+	contents := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, _ := file.Open()
+		contents[file.Name], _ = io.ReadAll(reader)
+		_ = reader.Close()
+	}
+
+	assert.Len(t, contents, 8)
+	assert.Contains(t, string(contents["README.txt"]), "2024-05-01T08:00:00Z")
+	assert.Equal(t, "image", string(contents["files/avatar.webp"]))
+
+	var profile entity.User
+	assert.NoError(t, json.Unmarshal(contents["profile.json"], &profile))
+	assert.Equal(t, "pixie", profile.Username)
+
+	var tasks []entity.AccountTask
+	assert.NoError(t, json.Unmarshal(contents["tasks.json"], &tasks))
+	assert.Equal(t, data.Tasks, tasks)
+}
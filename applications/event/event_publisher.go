@@ -0,0 +1,8 @@
+package event
+
+// EventPublisher interface defines a method for publishing events happening inside a project.
+// This interface is used when the use case wants to notify the subscribers (like webhooks) of a change
+type EventPublisher interface {
+	// Publish publishes the event with its data, do nothing if projectId is empty.
+	Publish(projectId string, event string, data interface{})
+}
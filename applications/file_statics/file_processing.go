@@ -10,4 +10,8 @@ const (
 type FileProcessing interface {
 	CompressImage(buffer []byte, to ConvertTo) ([]byte, string)
 	AddWatermark(buffer []byte) []byte
+
+	// CreateSquareVariants crops the center of the image to a square, then scales it to every size.
+	// Returning the variants by size, and their extension.
+	CreateSquareVariants(buffer []byte, sizes []int, to ConvertTo) (map[int][]byte, string)
 }
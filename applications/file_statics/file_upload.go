@@ -1,5 +1,7 @@
 ﻿package file_statics
 
+import "github.com/wisle25/task-pixie/domains/entity"
+
 // FileUpload handling file uploading, manipulating (like adding watermark), removing, etc
 type FileUpload interface {
 	// UploadFile before uploading file, it will generate a new name.
@@ -13,4 +15,7 @@ type FileUpload interface {
 	// RemoveFile deleting specified file by its link
 	// Do nothing if it's really not existed
 	RemoveFile(oldFileLink string)
+
+	// ListFiles lists every stored file, with its size and when it was last modified.
+	ListFiles() []entity.StoredFile
 }
@@ -0,0 +1,234 @@
+// Package ical serializes calendars in the iCalendar format (RFC 5545), for calendar apps to subscribe to.
+//
+// Days (all-day events, due dates) are written as DATE values, they're the same day wherever the calendar is read.
+// Instants are written in UTC, so no time zone definition (VTIMEZONE) is ever needed.
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type of an iCalendar document.
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	dateFormat     = "20060102"
+	dateTimeFormat = "20060102T150405Z"
+
+	// maxLineOctets is the longest a line may be, line break excluded
+	maxLineOctets = 75
+)
+
+// Statuses of an event.
+const (
+	EventTentative = "TENTATIVE"
+	EventConfirmed = "CONFIRMED"
+	EventCancelled = "CANCELLED"
+)
+
+// Statuses of a to-do.
+const (
+	TodoNeedsAction = "NEEDS-ACTION"
+	TodoInProcess   = "IN-PROCESS"
+	TodoCompleted   = "COMPLETED"
+	TodoCancelled   = "CANCELLED"
+)
+
+// Calendar is an iCalendar document with its events and to-dos.
+type Calendar struct {
+	ProdId      string // Product that made the calendar, e.g. -//Task Pixie//Calendar//EN
+	Name        string // Shown by calendar apps, optional
+	Description string // Optional
+	Events      []Event
+	Todos       []Todo
+}
+
+// Event is an all-day event (VEVENT).
+type Event struct {
+	UID         string // Globally unique and stable, apps use it to update the event
+	Summary     string
+	Description string
+	URL         string    // Optional
+	Day         time.Time // Only the date in the time's own location is kept
+	Status      string    // Optional, see EventConfirmed
+	Priority    int       // 1 (highest) to 9 (lowest), 0 is undefined
+	Sequence    int       // Revision of the event
+	Categories  []string
+	Stamp       time.Time // Last change of the event
+}
+
+// Todo is a to-do due on a day (VTODO).
+type Todo struct {
+	UID         string // Globally unique and stable, apps use it to update the to-do
+	Summary     string
+	Description string
+	URL         string    // Optional
+	Due         time.Time // Only the date in the time's own location is kept
+	Status      string    // Optional, see TodoNeedsAction
+	Priority    int       // 1 (highest) to 9 (lowest), 0 is undefined
+	Sequence    int       // Revision of the to-do
+	Categories  []string
+	Stamp       time.Time // Last change of the to-do
+}
+
+// Marshal returns the calendar as an iCalendar document, with CRLF line breaks and folded lines.
+func (c *Calendar) Marshal() []byte {
+	var w writer
+
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.property("PRODID", c.ProdId)
+	w.line("CALSCALE:GREGORIAN")
+	w.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		w.text("X-WR-CALNAME", c.Name)
+	}
+	if c.Description != "" {
+		w.text("X-WR-CALDESC", c.Description)
+	}
+
+	for _, event := range c.Events {
+		w.line("BEGIN:VEVENT")
+		w.text("UID", event.UID)
+		w.dateTime("DTSTAMP", event.Stamp)
+		w.date("DTSTART", event.Day)
+		w.date("DTEND", event.Day.AddDate(0, 0, 1)) // The end of an all-day event is exclusive
+		w.text("SUMMARY", event.Summary)
+		w.optionalText("DESCRIPTION", event.Description)
+		w.optionalProperty("URL", event.URL)
+		w.optionalProperty("STATUS", event.Status)
+		w.priority(event.Priority)
+		w.property("SEQUENCE", strconv.Itoa(event.Sequence))
+		w.categories(event.Categories)
+		w.line("TRANSP:TRANSPARENT")
+		w.dateTime("LAST-MODIFIED", event.Stamp)
+		w.line("END:VEVENT")
+	}
+
+	for _, todo := range c.Todos {
+		w.line("BEGIN:VTODO")
+		w.text("UID", todo.UID)
+		w.dateTime("DTSTAMP", todo.Stamp)
+		w.date("DUE", todo.Due)
+		w.text("SUMMARY", todo.Summary)
+		w.optionalText("DESCRIPTION", todo.Description)
+		w.optionalProperty("URL", todo.URL)
+		w.optionalProperty("STATUS", todo.Status)
+		w.priority(todo.Priority)
+		w.property("SEQUENCE", strconv.Itoa(todo.Sequence))
+		w.categories(todo.Categories)
+		w.dateTime("LAST-MODIFIED", todo.Stamp)
+		w.line("END:VTODO")
+	}
+
+	w.line("END:VCALENDAR")
+
+	return []byte(w.String())
+}
+
+// Escape escapes a TEXT value, line breaks become \n and other control characters are dropped.
+func Escape(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var escaped strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == ';' || r == ',':
+			escaped.WriteRune('\\')
+			escaped.WriteRune(r)
+		case r == '\n' || r == '\r':
+			escaped.WriteString(`\n`)
+		case r == '\t' || (r >= 0x20 && r != 0x7f):
+			escaped.WriteRune(r)
+		}
+	}
+
+	return escaped.String()
+}
+
+// Fold splits a content line into lines of at most 75 octets, continued lines start with a space.
+// Multi-octet UTF-8 characters are never split.
+func Fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var folded strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		// Back off to the start of a character
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		folded.WriteString(line[:cut])
+		folded.WriteString("\r\n ")
+		line = line[cut:]
+
+		// The leading space counts
+		limit = maxLineOctets - 1
+	}
+	folded.WriteString(line)
+
+	return folded.String()
+}
+
+type writer struct {
+	strings.Builder
+}
+
+func (w *writer) line(line string) {
+	w.WriteString(Fold(line))
+	w.WriteString("\r\n")
+}
+
+func (w *writer) property(name string, value string) {
+	w.line(name + ":" + value)
+}
+
+func (w *writer) optionalProperty(name string, value string) {
+	if value != "" {
+		w.property(name, value)
+	}
+}
+
+func (w *writer) text(name string, value string) {
+	w.property(name, Escape(value))
+}
+
+func (w *writer) optionalText(name string, value string) {
+	if value != "" {
+		w.text(name, value)
+	}
+}
+
+func (w *writer) date(name string, day time.Time) {
+	w.property(name+";VALUE=DATE", day.Format(dateFormat))
+}
+
+func (w *writer) dateTime(name string, instant time.Time) {
+	w.property(name, instant.UTC().Format(dateTimeFormat))
+}
+
+func (w *writer) priority(priority int) {
+	if priority > 0 && priority <= 9 {
+		w.property("PRIORITY", strconv.Itoa(priority))
+	}
+}
+
+func (w *writer) categories(categories []string) {
+	var values []string
+	for _, category := range categories {
+		if category != "" {
+			values = append(values, Escape(category))
+		}
+	}
+
+	if len(values) > 0 {
+		w.property("CATEGORIES", strings.Join(values, ","))
+	}
+}
@@ -0,0 +1,171 @@
+package ical_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/ical"
+)
+
+func TestEscape(t *testing.T) {
+	t.Run("Should escape the special characters of TEXT values", func(t *testing.T) {
+		cases := []struct{ text, expected string }{
+			{"Release", "Release"},
+			{"Release, then party; maybe", `Release\, then party\; maybe`},
+			{`C:\builds`, `C:\\builds`},
+			{"First line\nSecond line", `First line\nSecond line`},
+			{"Windows\r\nline", `Windows\nline`},
+			{"Old Mac\rline", `Old Mac\nline`},
+			{"Tab\tkept, bell\a dropped", `Tab` + "\t" + `kept\, bell dropped`},
+			{"Émoji 🚀: ok", "Émoji 🚀: ok"},
+		}
+
+		for _, c := range cases {
+			assert.Equal(t, c.expected, ical.Escape(c.text), c.text)
+		}
+	})
+}
+
+func TestFold(t *testing.T) {
+	t.Run("Should leave short lines as they are", func(t *testing.T) {
+		line := strings.Repeat("a", 75)
+
+		assert.Equal(t, line, ical.Fold(line))
+	})
+
+	t.Run("Should fold long lines at 75 octets", func(t *testing.T) {
+		// Arrange
+		line := strings.Repeat("a", 200)
+
+		// Action
+		lines := strings.Split(ical.Fold(line), "\r\n")
+
+		// Assert
+		assert.Len(t, lines, 3)
+		assert.Len(t, lines[0], 75)
+		assert.Len(t, lines[1], 75)
+		assert.True(t, strings.HasPrefix(lines[1], " "))
+		assert.Equal(t, line, unfold(lines))
+	})
+
+	t.Run("Should not split multi-octet characters", func(t *testing.T) {
+		// Arrange
+		line := "SUMMARY:" + strings.Repeat("日本語", 20)
+
+		// Action
+		lines := strings.Split(ical.Fold(line), "\r\n")
+
+		// Assert
+		for _, folded := range lines {
+			assert.LessOrEqual(t, len(folded), 75)
+			assert.True(t, strings.ToValidUTF8(folded, "") == folded, folded)
+		}
+		assert.Equal(t, line, unfold(lines))
+	})
+}
+
+func TestCalendarMarshal(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	calendar := ical.Calendar{
+		ProdId: "-//Task Pixie//Calendar//EN",
+		Name:   "Launch, phase 1",
+		Events: []ical.Event{{
+			UID:         "task-1@task-pixie",
+			Summary:     "Ship it; finally",
+			Description: "Line one\nLine two",
+			Day:         time.Date(2024, time.December, 31, 23, 30, 0, 0, jakarta),
+			Status:      ical.EventConfirmed,
+			Priority:    1,
+			Sequence:    3,
+			Categories:  []string{"Launch, phase 1", ""},
+			Stamp:       time.Date(2024, time.December, 1, 7, 0, 0, 0, jakarta),
+		}},
+		Todos: []ical.Todo{{
+			UID:     "task-2@task-pixie",
+			Summary: "Write notes",
+			Due:     time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC),
+			Status:  ical.TodoNeedsAction,
+			Stamp:   time.Date(2024, time.February, 1, 12, 0, 0, 0, time.UTC),
+		}},
+	}
+
+	document := string(calendar.Marshal())
+
+	t.Run("Should use CRLF line breaks only", func(t *testing.T) {
+		assert.True(t, strings.HasSuffix(document, "END:VCALENDAR\r\n"))
+		assert.NotContains(t, strings.ReplaceAll(document, "\r\n", ""), "\n")
+	})
+
+	t.Run("Should write the events as all-day events", func(t *testing.T) {
+		assert.Contains(t, document, "\r\nBEGIN:VEVENT\r\nUID:task-1@task-pixie\r\n")
+		assert.Contains(t, document, "\r\nDTSTART;VALUE=DATE:20241231\r\n")
+		assert.Contains(t, document, "\r\nDTEND;VALUE=DATE:20250101\r\n")
+		assert.Contains(t, document, "\r\nSUMMARY:Ship it\\; finally\r\n")
+		assert.Contains(t, document, "\r\nDESCRIPTION:Line one\\nLine two\r\n")
+		assert.Contains(t, document, "\r\nPRIORITY:1\r\nSEQUENCE:3\r\n")
+		assert.Contains(t, document, "\r\nCATEGORIES:Launch\\, phase 1\r\n")
+	})
+
+	t.Run("Should keep the day in its own time zone and write instants in UTC", func(t *testing.T) {
+		// DTSTART above is the 31st as in Jakarta, and the stamp is 07:00 in Jakarta
+		assert.Contains(t, document, "\r\nDTSTAMP:20241201T000000Z\r\n")
+		assert.Contains(t, document, "\r\nLAST-MODIFIED:20241201T000000Z\r\n")
+		assert.NotContains(t, document, "TZID")
+	})
+
+	t.Run("Should write the to-dos with their due day", func(t *testing.T) {
+		assert.Contains(t, document, "\r\nBEGIN:VTODO\r\nUID:task-2@task-pixie\r\n")
+		assert.Contains(t, document, "\r\nDUE;VALUE=DATE:20240229\r\n")
+		assert.Contains(t, document, "\r\nSTATUS:NEEDS-ACTION\r\n")
+		assert.NotContains(t, document, "PRIORITY:0")
+	})
+
+	t.Run("Should skip the optional properties left empty", func(t *testing.T) {
+		todo := document[strings.Index(document, "BEGIN:VTODO"):]
+
+		assert.NotContains(t, todo, "DESCRIPTION")
+		assert.NotContains(t, todo, "URL")
+		assert.NotContains(t, todo, "CATEGORIES")
+	})
+
+	t.Run("Should fold long properties", func(t *testing.T) {
+		// Arrange
+		long := ical.Calendar{
+			ProdId: "-//Task Pixie//Calendar//EN",
+			Todos: []ical.Todo{{
+				UID:         "task-3@task-pixie",
+				Summary:     "Long",
+				Description: strings.Repeat("Très long, ", 20),
+				Due:         time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+				Stamp:       time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			}},
+		}
+
+		// Action
+		lines := strings.Split(strings.TrimSuffix(string(long.Marshal()), "\r\n"), "\r\n")
+
+		// Assert
+		for _, line := range lines {
+			assert.LessOrEqual(t, len(line), 75, line)
+		}
+		assert.Contains(t, unfold(lines), "DESCRIPTION:"+strings.Repeat(`Très long\, `, 20))
+	})
+}
+
+// unfold joins folded lines back, as readers do.
+func unfold(lines []string) string {
+	var unfolded strings.Builder
+	for i, line := range lines {
+		if i > 0 && strings.HasPrefix(line, " ") {
+			line = line[1:]
+		} else if i > 0 {
+			unfolded.WriteString("\r\n")
+		}
+		unfolded.WriteString(line)
+	}
+
+	return unfolded.String()
+}
@@ -0,0 +1,108 @@
+// Package identicon draws the default avatar of users who haven't uploaded one.
+//
+// The picture is a symmetric 5×5 pattern of cells in one color, both taken from the SHA-256 of the seed,
+// so the same user always gets the same picture and different users rarely get alike ones.
+package identicon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+)
+
+// ContentType is the media type of the generated pictures.
+const ContentType = "image/png"
+
+// Cells per side of the pattern, only the left half and the middle column are drawn from the hash
+const gridSize = 5
+
+var background = color.RGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+// Generate draws the identicon of the seed as a square PNG of size pixels.
+func Generate(seed string, size int) []byte {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, Draw(seed, size)); err != nil {
+		panic(fmt.Errorf("identicon_err: encode png: %v", err))
+	}
+
+	return buffer.Bytes()
+}
+
+// Draw draws the identicon of the seed as a square image of size pixels.
+// About half a cell is left as margin around the pattern.
+func Draw(seed string, size int) *image.RGBA {
+	hash := sha256.Sum256([]byte(seed))
+	foreground := colorOf(hash)
+
+	picture := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(picture, picture.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	// The margins must be even for the picture to stay symmetric
+	cell := size / (gridSize + 1)
+	if (size-cell*gridSize)%2 != 0 {
+		cell--
+	}
+	margin := (size - cell*gridSize) / 2
+
+	for row := 0; row < gridSize; row++ {
+		for column := 0; column < (gridSize+1)/2; column++ {
+			// One bit of the hash per cell, from the last bytes the color doesn't use
+			bit := row*((gridSize+1)/2) + column
+			if hash[len(hash)-1-bit/8]>>(bit%8)&1 == 0 {
+				continue
+			}
+
+			fill(picture, margin+column*cell, margin+row*cell, cell, foreground)
+			fill(picture, margin+(gridSize-1-column)*cell, margin+row*cell, cell, foreground)
+		}
+	}
+
+	return picture
+}
+
+// colorOf picks a saturated color from the first bytes of the hash, never too light on the background.
+func colorOf(hash [sha256.Size]byte) color.RGBA {
+	hue := float64(uint16(hash[0])<<8|uint16(hash[1])) / 65536 * 360
+	saturation := 0.45 + float64(hash[2])/255*0.3
+	lightness := 0.4 + float64(hash[3])/255*0.2
+
+	return hslToRGB(hue, saturation, lightness)
+}
+
+func fill(picture *image.RGBA, x int, y int, side int, c color.RGBA) {
+	draw.Draw(picture, image.Rect(x, y, x+side, y+side), image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+func hslToRGB(hue float64, saturation float64, lightness float64) color.RGBA {
+	chroma := (1 - math.Abs(2*lightness-1)) * saturation
+	x := chroma * (1 - math.Abs(math.Mod(hue/60, 2)-1))
+	m := lightness - chroma/2
+
+	var r, g, b float64
+	switch {
+	case hue < 60:
+		r, g, b = chroma, x, 0
+	case hue < 120:
+		r, g, b = x, chroma, 0
+	case hue < 180:
+		r, g, b = 0, chroma, x
+	case hue < 240:
+		r, g, b = 0, x, chroma
+	case hue < 300:
+		r, g, b = x, 0, chroma
+	default:
+		r, g, b = chroma, 0, x
+	}
+
+	return color.RGBA{
+		R: uint8((r + m) * 255),
+		G: uint8((g + m) * 255),
+		B: uint8((b + m) * 255),
+		A: 0xff,
+	}
+}
@@ -0,0 +1,53 @@
+package identicon_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wisle25/task-pixie/applications/identicon"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("Should encode a square PNG of the size", func(t *testing.T) {
+		for _, size := range []int{32, 64, 128, 256} {
+			// Action
+			picture, err := png.Decode(bytes.NewReader(identicon.Generate("user-id", size)))
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, size, picture.Bounds().Dx())
+			assert.Equal(t, size, picture.Bounds().Dy())
+		}
+	})
+
+	t.Run("Should be the same for the same seed only", func(t *testing.T) {
+		// Action
+		first := identicon.Generate("8d3f7a3e-1c1e-4d6b-9d0e-5a1c2b3d4e5f", 64)
+		again := identicon.Generate("8d3f7a3e-1c1e-4d6b-9d0e-5a1c2b3d4e5f", 64)
+		other := identicon.Generate("0b6c1f2e-7a8d-4e9f-8a0b-1c2d3e4f5a6b", 64)
+
+		// Assert
+		assert.Equal(t, first, again)
+		assert.NotEqual(t, first, other)
+	})
+}
+
+func TestDraw(t *testing.T) {
+	t.Run("Should mirror the pattern horizontally", func(t *testing.T) {
+		// Arrange
+		size := 128
+
+		// Action
+		picture := identicon.Draw("mirrored", size)
+
+		// Assert
+		for y := 0; y < size; y++ {
+			for x := 0; x < size/2; x++ {
+				assert.Equal(t, picture.RGBAAt(x, y), picture.RGBAAt(size-1-x, y), "pixel %d,%d", x, y)
+			}
+		}
+	})
+}
@@ -0,0 +1,119 @@
+// Package importer defines the boards read from the exports of other tools, and the adapters reading them.
+//
+// Every source (Trello, Jira...) has its own adapter turning its export into a Board,
+// the board is then mapped to the tasks of a project the same way whatever the source.
+package importer
+
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"io"
+	"strings"
+)
+
+// Adapter reads the exports of a source into boards.
+type Adapter interface {
+	// Source is the name clients choose the adapter by, e.g. trello.
+	Source() string
+
+	// Parse reads an export file, returning an error telling what's wrong with the file.
+	Parse(r io.Reader) (*Board, error)
+}
+
+// Board is a board (or project) of a source, ready to be mapped to tasks.
+type Board struct {
+	Name    string
+	States  []State // Lists or statuses the cards go through
+	Members []Member
+	Cards   []Card
+}
+
+// State is a stage of the workflow of a source, a Trello list or a Jira status.
+type State struct {
+	Id     string
+	Name   string
+	Status string // Task status suggested by the adapter, empty when it can't tell
+}
+
+// Member is a person of the source, matched to users by email.
+type Member struct {
+	Id    string
+	Name  string
+	Email string // Empty when the source doesn't export it
+}
+
+// Card is a Trello card or a Jira issue, imported as a task.
+type Card struct {
+	Title       string
+	Description string
+	StateId     string
+	Priority    string // Task priority, empty when the source has none
+	DueDate     string // YYYY-MM-DD, empty when none
+	MemberIds   []string
+	Labels      []string
+	Checklists  []Checklist
+	Archived    bool // Archived cards aren't imported
+}
+
+// Checklist is a list of items to tick off inside a card.
+type Checklist struct {
+	Name  string
+	Items []ChecklistItem
+}
+
+// ChecklistItem is a single item of a checklist.
+type ChecklistItem struct {
+	Name string
+	Done bool
+}
+
+// Detail returns the checklists and labels of the card as Markdown, tasks keep them in their detail.
+func (c *Card) Detail() string {
+	var sections []string
+
+	for _, checklist := range c.Checklists {
+		var lines []string
+		if checklist.Name != "" {
+			lines = append(lines, checklist.Name)
+		}
+		for _, item := range checklist.Items {
+			box := "[ ]"
+			if item.Done {
+				box = "[x]"
+			}
+			lines = append(lines, "- "+box+" "+item.Name)
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+
+	if len(c.Labels) > 0 {
+		sections = append(sections, "Labels: "+strings.Join(c.Labels, ", "))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// SuggestStatus guesses the task status of a state from its name, To Do when nothing matches.
+func SuggestStatus(name string) string {
+	name = strings.ToLower(name)
+
+	switch {
+	case containsAny(name, "cancel", "won't", "wont", "reject", "abandon"):
+		return entity.TaskStatusCanceled
+	case containsAny(name, "done", "complete", "closed", "finished", "resolved", "shipped", "released"):
+		return entity.TaskStatusCompleted
+	case containsAny(name, "progress", "doing", "review", "testing", "started"):
+		return entity.TaskStatusInProgress
+	default:
+		return entity.TaskStatusToDo
+	}
+}
+
+func containsAny(s string, substrings ...string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(s, substring) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,44 @@
+package importer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+func TestCardDetail(t *testing.T) {
+	t.Run("Should write checklists as task lists and labels last", func(t *testing.T) {
+		card := importer.Card{
+			Labels: []string{"bug", "ui"},
+			Checklists: []importer.Checklist{
+				{Name: "Release", Items: []importer.ChecklistItem{{Name: "Tag", Done: true}, {Name: "Announce"}}},
+				{Items: []importer.ChecklistItem{{Name: "Celebrate"}}},
+			},
+		}
+
+		assert.Equal(t, "Release\n- [x] Tag\n- [ ] Announce\n\n- [ ] Celebrate\n\nLabels: bug, ui", card.Detail())
+	})
+
+	t.Run("Should be empty without checklists nor labels", func(t *testing.T) {
+		assert.Equal(t, "", (&importer.Card{Title: "Plain"}).Detail())
+	})
+}
+
+func TestSuggestStatus(t *testing.T) {
+	cases := map[string]string{
+		"To Do":       entity.TaskStatusToDo,
+		"Backlog":     entity.TaskStatusToDo,
+		"Doing":       entity.TaskStatusInProgress,
+		"In Review":   entity.TaskStatusInProgress,
+		"Done 🎉":      entity.TaskStatusCompleted,
+		"Won't Fix":   entity.TaskStatusCanceled,
+		"Cancelled":   entity.TaskStatusCanceled,
+		"In Progress": entity.TaskStatusInProgress,
+	}
+
+	for name, status := range cases {
+		assert.Equal(t, status, importer.SuggestStatus(name), name)
+	}
+}
@@ -0,0 +1,15 @@
+package mailer
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// MailRenderer interface defines methods for rendering email contents.
+type MailRenderer interface {
+	// RenderDigest renders the digest, returning its HTML and plain-text parts.
+	RenderDigest(digest *entity.Digest) (string, string)
+
+	// RenderInvitation renders the project invitation, returning its HTML and plain-text parts.
+	RenderInvitation(invitation *entity.InvitationMail) (string, string)
+
+	// RenderPasswordReset renders the password reset asked by an administrator, returning its HTML and plain-text parts.
+	RenderPasswordReset(reset *entity.PasswordResetMail) (string, string)
+}
@@ -0,0 +1,10 @@
+package mailer
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// Mailer interface defines a method for sending emails.
+type Mailer interface {
+	// Send delivers the message.
+	// It should raise panic if the message couldn't be delivered.
+	Send(message *entity.MailMessage)
+}
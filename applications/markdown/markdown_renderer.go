@@ -0,0 +1,10 @@
+package markdown
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// MarkdownRenderer interface defines a method for rendering the Markdown written by users.
+type MarkdownRenderer interface {
+	// Render renders the source to sanitized HTML, extracting its task links and mentions.
+	// Raw HTML of the source is escaped, and the output only holds allowed tags and attributes.
+	Render(source string) *entity.Markdown
+}
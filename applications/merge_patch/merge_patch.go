@@ -0,0 +1,113 @@
+// Package merge_patch applies JSON Merge Patches (RFC 7396) onto payloads.
+//
+// A patch is a JSON object: a member replaces the value of the document, a null member removes it,
+// and a missing member leaves it unchanged. Objects are merged recursively, arrays are replaced as a whole.
+package merge_patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Apply merges the patch into the JSON representation of document and decodes the result into target,
+// a pointer to a struct. Returns the names of the target's fields present in the patch, so only them are validated.
+func Apply(document interface{}, patch []byte, target interface{}) ([]string, error) {
+	var patchObject map[string]interface{}
+	if err := decode(patch, &patchObject); err != nil || patchObject == nil {
+		return nil, errors.New("patch must be a JSON object")
+	}
+
+	fields, err := fieldNames(target, patchObject)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("merge_patch_error: marshal document: %v", err)
+	}
+
+	var originalObject interface{}
+	if err := decode(original, &originalObject); err != nil {
+		return nil, fmt.Errorf("merge_patch_error: decode document: %v", err)
+	}
+
+	merged, err := json.Marshal(Merge(originalObject, patchObject))
+	if err != nil {
+		return nil, fmt.Errorf("merge_patch_error: marshal merged document: %v", err)
+	}
+
+	if err := json.Unmarshal(merged, target); err != nil {
+		return nil, fmt.Errorf("invalid patch: %v", err)
+	}
+
+	return fields, nil
+}
+
+// Merge implements the MergePatch function of RFC 7396 over decoded JSON values.
+func Merge(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = make(map[string]interface{})
+	}
+
+	for name, value := range patchObject {
+		if value == nil {
+			delete(targetObject, name)
+			continue
+		}
+		targetObject[name] = Merge(targetObject[name], value)
+	}
+
+	return targetObject
+}
+
+// decode keeps numbers as they are written, so they aren't rounded through float64.
+func decode(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
+
+// fieldNames maps the members of the patch to the fields of the target, rejecting unknown members.
+func fieldNames(target interface{}, patch map[string]interface{}) ([]string, error) {
+	byJsonName := make(map[string]string)
+
+	targetType := reflect.TypeOf(target)
+	for targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+	for i := 0; i < targetType.NumField(); i++ {
+		field := targetType.Field(i)
+
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		byJsonName[name] = field.Name
+	}
+
+	var fields []string
+	for name := range patch {
+		field, ok := byJsonName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
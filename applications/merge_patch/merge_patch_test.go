@@ -0,0 +1,116 @@
+package merge_patch_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/merge_patch"
+)
+
+type document struct {
+	Title     string   `json:"title"`
+	ProjectId string   `json:"projectId"`
+	Assignees []string `json:"assignedTo"`
+	Ignored   string   `json:"-"`
+}
+
+func TestMerge(t *testing.T) {
+	t.Run("Should follow the examples of RFC 7396", func(t *testing.T) {
+		cases := []struct{ target, patch, expected string }{
+			{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+			{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+			{`{"a":"b"}`, `{"a":null}`, `{}`},
+			{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+			{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+			{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+			{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+			{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+			{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+			{`{"a":"b"}`, `["c"]`, `["c"]`},
+			{`{"a":"foo"}`, `null`, `null`},
+			{`{"a":"foo"}`, `"bar"`, `"bar"`},
+			{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+			{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+			{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+		}
+
+		for _, c := range cases {
+			var target, patch interface{}
+			_ = json.Unmarshal([]byte(c.target), &target)
+			_ = json.Unmarshal([]byte(c.patch), &patch)
+
+			// Action
+			merged, _ := json.Marshal(merge_patch.Merge(target, patch))
+
+			// Assert
+			assert.JSONEq(t, c.expected, string(merged), c.patch)
+		}
+	})
+}
+
+func TestApply(t *testing.T) {
+	original := document{Title: "Release", ProjectId: "project", Assignees: []string{"pixie"}, Ignored: "kept"}
+
+	t.Run("Should only change the fields of the patch", func(t *testing.T) {
+		// Arrange
+		var patched document
+
+		// Action
+		fields, err := merge_patch.Apply(original, []byte(`{"title":"Launch"}`), &patched)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Title"}, fields)
+		assert.Equal(t, document{Title: "Launch", ProjectId: "project", Assignees: []string{"pixie"}}, patched)
+	})
+
+	t.Run("Should clear fields set to null", func(t *testing.T) {
+		// Arrange
+		var patched document
+
+		// Action
+		fields, err := merge_patch.Apply(original, []byte(`{"projectId":null,"assignedTo":null}`), &patched)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"ProjectId", "Assignees"}, fields)
+		assert.Equal(t, "", patched.ProjectId)
+		assert.Empty(t, patched.Assignees)
+		assert.Equal(t, "Release", patched.Title)
+	})
+
+	t.Run("Should replace arrays as a whole", func(t *testing.T) {
+		// Arrange
+		var patched document
+
+		// Action
+		_, err := merge_patch.Apply(original, []byte(`{"assignedTo":["bob","alice"]}`), &patched)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"bob", "alice"}, patched.Assignees)
+	})
+
+	t.Run("Should return error on invalid patch", func(t *testing.T) {
+		invalid := []string{
+			`not json`,
+			`["title"]`,
+			`null`,
+			`{"unknown":"field"}`,
+			`{"Ignored":"value"}`,
+			`{"title":42}`,
+		}
+
+		for _, patch := range invalid {
+			// Arrange
+			var patched document
+
+			// Action
+			_, err := merge_patch.Apply(original, []byte(patch), &patched)
+
+			// Assert
+			assert.Error(t, err, patch)
+		}
+	})
+}
@@ -0,0 +1,10 @@
+package scanner
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// FileQuarantine interface defines a method for keeping the files the scanner refused out of the storage.
+type FileQuarantine interface {
+	// Quarantine keeps the file aside to be reviewed, along with what was found in it and who uploaded it.
+	// It should raise panic if the file couldn't be kept.
+	Quarantine(file *entity.QuarantinedFile)
+}
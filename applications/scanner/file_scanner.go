@@ -0,0 +1,8 @@
+package scanner
+
+// FileScanner interface defines a method for scanning uploaded files for malware.
+type FileScanner interface {
+	// Scan returns the name of the threat found in the content, empty if it's clean.
+	// It should raise panic if the content couldn't be scanned, the upload is refused then.
+	Scan(content []byte) string
+}
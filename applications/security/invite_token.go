@@ -0,0 +1,13 @@
+package security
+
+import "time"
+
+// InviteToken interface defines methods for signing and verifying project invitation tokens.
+type InviteToken interface {
+	// CreateInviteToken signs the invitation ID into a token valid until expiresAt.
+	CreateInviteToken(invitationId string, expiresAt time.Time) string
+
+	// ValidateInviteToken verifies the token, returning the invitation ID it carries.
+	// It should raise panic if the token is malformed, tampered or expired.
+	ValidateInviteToken(token string) string
+}
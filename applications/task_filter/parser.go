@@ -0,0 +1,271 @@
+// Package task_filter parses task filter expressions into entity.TaskFilter.
+//
+// An expression is a list of terms separated by spaces, a task must match all of them:
+//
+//	status:"In Progress" priority:Urgent,High due:<7d assignee:@me -project:Website release
+//
+// A term is either `field:value[,value...]` or a bare text searched in the title and description.
+// Values containing spaces must be quoted, and a leading "-" negates the term.
+package task_filter
+
+import (
+	"errors"
+	"fmt"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Limits the size of the generated query
+const maxTerms = 20
+
+var (
+	statuses   = []string{"To Do", "In Progress", "Completed", "Canceled"}
+	priorities = []string{"Low", "High", "Urgent"}
+
+	relativeDays = regexp.MustCompile(`^(-?\d{1,4})([dw])$`)
+)
+
+// term is a single unparsed term of the expression.
+type term struct {
+	negated bool
+	field   string
+	values  []string
+}
+
+// Parse parses the expression, returning an error describing the first invalid term.
+func Parse(expression string) (*entity.TaskFilter, error) {
+	terms, err := scan(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(terms) > maxTerms {
+		return nil, fmt.Errorf("filter can't have more than %d terms", maxTerms)
+	}
+
+	filter := &entity.TaskFilter{}
+	for _, t := range terms {
+		condition, err := parseTerm(t)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.negated {
+			condition = entity.TaskFilterNot{Condition: condition}
+		}
+		filter.Conditions = append(filter.Conditions, condition)
+	}
+
+	return filter, nil
+}
+
+// scan splits the expression into terms, handling quotes, negation and value lists.
+func scan(expression string) ([]term, error) {
+	var terms []term
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var t term
+		if runes[i] == '-' {
+			if i+1 == len(runes) || unicode.IsSpace(runes[i+1]) {
+				return nil, errors.New("dangling \"-\" in filter")
+			}
+			t.negated = true
+			i++
+		}
+
+		// Field name is only recognized when followed by a colon
+		j := i
+		for j < len(runes) && unicode.IsLetter(runes[j]) {
+			j++
+		}
+		if j > i && j < len(runes) && runes[j] == ':' {
+			t.field = strings.ToLower(string(runes[i:j]))
+			i = j + 1
+		}
+
+		// Only field values can be listed with commas
+		for {
+			value, next, err := scanValue(runes, i, t.field != "")
+			if err != nil {
+				return nil, err
+			}
+			if value == "" && t.field != "" {
+				return nil, fmt.Errorf("%s needs a value", t.field)
+			}
+			if value == "" {
+				return nil, errors.New("empty text in filter")
+			}
+
+			t.values = append(t.values, value)
+			i = next
+
+			if t.field != "" && i < len(runes) && runes[i] == ',' {
+				i++
+				continue
+			}
+			break
+		}
+
+		terms = append(terms, t)
+	}
+
+	return terms, nil
+}
+
+// scanValue reads a value starting at i, returning it with the position right after it.
+func scanValue(runes []rune, i int, stopAtComma bool) (string, int, error) {
+	var value strings.Builder
+
+	for i < len(runes) && !unicode.IsSpace(runes[i]) && !(stopAtComma && runes[i] == ',') {
+		if runes[i] != '"' {
+			value.WriteRune(runes[i])
+			i++
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && runes[end] != '"' {
+			end++
+		}
+		if end == len(runes) {
+			return "", 0, errors.New("unterminated quote in filter")
+		}
+
+		value.WriteString(string(runes[i+1 : end]))
+		i = end + 1
+	}
+
+	return value.String(), i, nil
+}
+
+func parseTerm(t term) (entity.TaskFilterCondition, error) {
+	switch t.field {
+	case "":
+		return entity.TaskFilterText{Text: t.values[0]}, nil
+	case "status":
+		values, err := canonical(t.field, t.values, statuses)
+		return entity.TaskFilterStatus{Statuses: values}, err
+	case "priority":
+		values, err := canonical(t.field, t.values, priorities)
+		return entity.TaskFilterPriority{Priorities: values}, err
+	case "project":
+		return entity.TaskFilterProject{Projects: t.values}, nil
+	case entity.TaskFilterAssignee, entity.TaskFilterOwner:
+		return parseUser(t.field, t.values), nil
+	case "due":
+		if len(t.values) > 1 {
+			return nil, errors.New("due only accepts a single value")
+		}
+		return parseDue(t.values[0])
+	}
+
+	return nil, fmt.Errorf("unknown filter field %q", t.field)
+}
+
+// canonical matches the values against the allowed ones, ignoring case, spaces and dashes.
+func canonical(field string, values []string, allowed []string) ([]string, error) {
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		found := false
+		for _, candidate := range allowed {
+			if normalize(value) == normalize(candidate) {
+				result = append(result, candidate)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("invalid %s %q, expected one of: %s", field, value, strings.Join(allowed, ", "))
+		}
+	}
+
+	return result, nil
+}
+
+func normalize(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '_' {
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, value)
+}
+
+func parseUser(role string, values []string) entity.TaskFilterUser {
+	user := entity.TaskFilterUser{Role: role}
+
+	for _, value := range values {
+		if strings.EqualFold(value, "@me") {
+			user.Me = true
+			continue
+		}
+		user.Usernames = append(user.Usernames, strings.TrimPrefix(value, "@"))
+	}
+
+	return user
+}
+
+// parseDue parses values like "<7d", ">=2024-06-01", "today", "overdue" or "none".
+func parseDue(value string) (entity.TaskFilterCondition, error) {
+	due := entity.TaskFilterDue{Operator: entity.TaskFilterEqual}
+
+	for _, operator := range []string{
+		entity.TaskFilterLessEqual,
+		entity.TaskFilterGreaterEqual,
+		entity.TaskFilterLess,
+		entity.TaskFilterGreater,
+		entity.TaskFilterEqual,
+	} {
+		if strings.HasPrefix(value, operator) {
+			due.Operator = operator
+			value = value[len(operator):]
+			break
+		}
+	}
+
+	lowered := strings.ToLower(value)
+	switch lowered {
+	case "none":
+		if due.Operator != entity.TaskFilterEqual {
+			return nil, errors.New("due:none can't be compared")
+		}
+		due.None = true
+	case "overdue":
+		if due.Operator != entity.TaskFilterEqual {
+			return nil, errors.New("due:overdue can't be compared")
+		}
+		due.Operator = entity.TaskFilterLess
+	case "today":
+	case "tomorrow":
+		due.Days = 1
+	case "yesterday":
+		due.Days = -1
+	default:
+		if match := relativeDays.FindStringSubmatch(lowered); match != nil {
+			due.Days, _ = strconv.Atoi(match[1])
+			if match[2] == "w" {
+				due.Days *= 7
+			}
+			break
+		}
+
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return nil, fmt.Errorf("invalid due %q, expected a date (YYYY-MM-DD), days (7d), weeks (2w), today, tomorrow, yesterday, overdue or none", value)
+		}
+		due.Date = value
+	}
+
+	return due, nil
+}
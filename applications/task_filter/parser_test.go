@@ -0,0 +1,93 @@
+package task_filter_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/task_filter"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("Should parse every field", func(t *testing.T) {
+		// Action
+		filter, err := task_filter.Parse(`status:"In Progress" priority:urgent,HIGH due:<7d assignee:@me,@pixie owner:bob project:Website`)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TaskFilterCondition{
+			entity.TaskFilterStatus{Statuses: []string{"In Progress"}},
+			entity.TaskFilterPriority{Priorities: []string{"Urgent", "High"}},
+			entity.TaskFilterDue{Operator: entity.TaskFilterLess, Days: 7},
+			entity.TaskFilterUser{Role: entity.TaskFilterAssignee, Me: true, Usernames: []string{"pixie"}},
+			entity.TaskFilterUser{Role: entity.TaskFilterOwner, Usernames: []string{"bob"}},
+			entity.TaskFilterProject{Projects: []string{"Website"}},
+		}, filter.Conditions)
+	})
+
+	t.Run("Should parse negation and free text", func(t *testing.T) {
+		// Action
+		filter, err := task_filter.Parse(`-status:completed,canceled release "release notes" -draft`)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TaskFilterCondition{
+			entity.TaskFilterNot{Condition: entity.TaskFilterStatus{Statuses: []string{"Completed", "Canceled"}}},
+			entity.TaskFilterText{Text: "release"},
+			entity.TaskFilterText{Text: "release notes"},
+			entity.TaskFilterNot{Condition: entity.TaskFilterText{Text: "draft"}},
+		}, filter.Conditions)
+	})
+
+	t.Run("Should parse due values", func(t *testing.T) {
+		cases := map[string]entity.TaskFilterDue{
+			"due:today":          {Operator: entity.TaskFilterEqual},
+			"due:>=tomorrow":     {Operator: entity.TaskFilterGreaterEqual, Days: 1},
+			"due:<=2w":           {Operator: entity.TaskFilterLessEqual, Days: 14},
+			"due:>-3d":           {Operator: entity.TaskFilterGreater, Days: -3},
+			"due:overdue":        {Operator: entity.TaskFilterLess},
+			"due:none":           {Operator: entity.TaskFilterEqual, None: true},
+			"due:<2024-06-01":    {Operator: entity.TaskFilterLess, Date: "2024-06-01"},
+			"due:\"2024-06-01\"": {Operator: entity.TaskFilterEqual, Date: "2024-06-01"},
+		}
+
+		for expression, expected := range cases {
+			// Action
+			filter, err := task_filter.Parse(expression)
+
+			// Assert
+			assert.NoError(t, err, expression)
+			assert.Equal(t, []entity.TaskFilterCondition{expected}, filter.Conditions, expression)
+		}
+	})
+
+	t.Run("Should parse empty expression", func(t *testing.T) {
+		// Action
+		filter, err := task_filter.Parse("   ")
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Empty(t, filter.Conditions)
+	})
+
+	t.Run("Should return error on invalid expression", func(t *testing.T) {
+		invalid := []string{
+			`label:bug`,
+			`status:Done`,
+			`priority:`,
+			`status:"In Progress`,
+			`due:<none`,
+			`due:2024-13-01`,
+			`due:today,tomorrow`,
+			`- status:Completed`,
+		}
+
+		for _, expression := range invalid {
+			// Action
+			_, err := task_filter.Parse(expression)
+
+			// Assert
+			assert.Error(t, err, expression)
+		}
+	})
+}
@@ -0,0 +1,294 @@
+// Package task_transfer reads and writes the CSV and JSON files tasks are exported to and imported from.
+//
+// Both formats carry the fields of entity.ExportedTask. CSV files start with a header row, and the assignees of a task
+// are separated by commas inside their cell. Imports map the task fields to the columns of the file,
+// so the files of other tools can be read as well as our own exports.
+package task_transfer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MaxRows is the most tasks a single file may import
+const MaxRows = 5000
+
+// Columns of CSV exports, in order
+var Columns = []string{
+	"id",
+	"title",
+	"description",
+	"detail",
+	"priority",
+	"status",
+	"dueDate",
+	"estimate",
+	"milestone",
+	"assignees",
+	"createdAt",
+	"updatedAt",
+}
+
+// Fields of a task that can be imported, title is required
+var Fields = []string{"title", "description", "detail", "priority", "status", "dueDate", "estimate", "assignees"}
+
+// Row is a task read from an import file, its values are validated afterwards.
+type Row struct {
+	Title       string
+	Description string
+	Detail      string
+	Priority    string
+	Status      string
+	DueDate     string
+	Estimate    string
+	Assignees   []string // Usernames or emails
+}
+
+// Exporter writes exported tasks to a file.
+type Exporter interface {
+	Write(task *entity.ExportedTask) error
+
+	// Close finishes the file, the underlying writer is left open.
+	Close() error
+}
+
+// NewExporter returns the exporter of the format (csv or json) writing to w.
+func NewExporter(format string, w io.Writer) Exporter {
+	if format == entity.TransferFormatJSON {
+		return &jsonExporter{writer: w}
+	}
+
+	exporter := &csvExporter{writer: csv.NewWriter(w)}
+	_ = exporter.writer.Write(Columns) // Errors are kept by the writer, and returned by the next call
+
+	return exporter
+}
+
+type csvExporter struct {
+	writer *csv.Writer
+}
+
+func (e *csvExporter) Write(task *entity.ExportedTask) error {
+	estimate := ""
+	if task.Estimate > 0 {
+		estimate = strconv.Itoa(task.Estimate)
+	}
+
+	return e.writer.Write([]string{
+		task.Id,
+		task.Title,
+		task.Description,
+		task.Detail,
+		task.Priority,
+		task.Status,
+		task.DueDate,
+		estimate,
+		task.Milestone,
+		strings.Join(task.Assignees, ", "),
+		task.CreatedAt,
+		task.UpdatedAt,
+	})
+}
+
+func (e *csvExporter) Close() error {
+	e.writer.Flush()
+
+	return e.writer.Error()
+}
+
+type jsonExporter struct {
+	writer io.Writer
+	count  int
+}
+
+func (e *jsonExporter) Write(task *entity.ExportedTask) error {
+	if task.Assignees == nil {
+		task.Assignees = []string{}
+	}
+
+	encoded, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "[\n"
+	}
+	e.count++
+
+	_, err = e.writer.Write(append([]byte(separator), encoded...))
+	return err
+}
+
+func (e *jsonExporter) Close() error {
+	end := "\n]\n"
+	if e.count == 0 {
+		end = "[]\n"
+	}
+
+	_, err := io.WriteString(e.writer, end)
+	return err
+}
+
+// ReadCSV reads the rows of a CSV file with a header row. The mapping maps task fields to the columns of the header,
+// when nil the columns named after the fields (as exported) are read.
+func ReadCSV(r io.Reader, mapping map[string]string) ([]Row, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %v", err)
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		// Spreadsheets like to start the file with a byte order mark
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.TrimSpace(name)
+
+		if _, exists := columns[name]; !exists {
+			columns[name] = i
+		}
+	}
+
+	indexes, err := mapColumns(mapping, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []Row
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+		if len(rows) == MaxRows {
+			return nil, fmt.Errorf("too many rows, at most %d tasks are imported at once", MaxRows)
+		}
+
+		value := func(field string) string {
+			index, ok := indexes[field]
+			if !ok {
+				return ""
+			}
+
+			return strings.TrimSpace(record[index])
+		}
+
+		rows = append(rows, Row{
+			Title:       value("title"),
+			Description: value("description"),
+			Detail:      value("detail"),
+			Priority:    value("priority"),
+			Status:      value("status"),
+			DueDate:     value("dueDate"),
+			Estimate:    value("estimate"),
+			Assignees:   splitAssignees(value("assignees")),
+		})
+	}
+
+	return rows, nil
+}
+
+// ReadJSON reads the rows of a JSON array of tasks, as exported.
+func ReadJSON(r io.Reader) ([]Row, error) {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	var tasks []entity.ExportedTask
+	if err := decoder.Decode(&tasks); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if len(tasks) > MaxRows {
+		return nil, fmt.Errorf("too many rows, at most %d tasks are imported at once", MaxRows)
+	}
+
+	rows := make([]Row, 0, len(tasks))
+	for _, task := range tasks {
+		estimate := ""
+		if task.Estimate != 0 {
+			estimate = strconv.Itoa(task.Estimate)
+		}
+
+		rows = append(rows, Row{
+			Title:       strings.TrimSpace(task.Title),
+			Description: task.Description,
+			Detail:      task.Detail,
+			Priority:    task.Priority,
+			Status:      task.Status,
+			DueDate:     task.DueDate,
+			Estimate:    estimate,
+			Assignees:   task.Assignees,
+		})
+	}
+
+	return rows, nil
+}
+
+// mapColumns returns the index of the column of every mapped field.
+func mapColumns(mapping map[string]string, columns map[string]int) (map[string]int, error) {
+	indexes := make(map[string]int)
+
+	if mapping == nil {
+		for _, field := range Fields {
+			if index, ok := columns[field]; ok {
+				indexes[field] = index
+			}
+		}
+	}
+
+	for field, column := range mapping {
+		if !isField(field) {
+			return nil, fmt.Errorf("unknown field %q in the mapping, expected one of %s", field, strings.Join(Fields, ", "))
+		}
+
+		index, ok := columns[strings.TrimSpace(column)]
+		if !ok {
+			return nil, fmt.Errorf("column %q of the mapping is not in the file", column)
+		}
+		indexes[field] = index
+	}
+
+	if _, ok := indexes["title"]; !ok {
+		return nil, errors.New("title must be mapped to a column")
+	}
+
+	return indexes, nil
+}
+
+func isField(field string) bool {
+	for _, known := range Fields {
+		if field == known {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitAssignees splits a cell of assignees, separated by commas or semicolons.
+func splitAssignees(cell string) []string {
+	var assignees []string
+	for _, assignee := range strings.FieldsFunc(cell, func(r rune) bool { return r == ',' || r == ';' }) {
+		if assignee = strings.TrimSpace(assignee); assignee != "" {
+			assignees = append(assignees, assignee)
+		}
+	}
+
+	return assignees
+}
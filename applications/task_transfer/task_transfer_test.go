@@ -0,0 +1,165 @@
+package task_transfer_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/task_transfer"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+var exported = []entity.ExportedTask{
+	{
+		Id:          "task-1",
+		Title:       "Launch, finally",
+		Description: "Line one\nLine \"two\"",
+		Priority:    "Urgent",
+		Status:      "To Do",
+		DueDate:     "2024-09-30",
+		Estimate:    90,
+		Milestone:   "Sprint 1",
+		Assignees:   []string{"pixie", "dixie"},
+		CreatedAt:   "2024-09-01T08:00:00Z",
+		UpdatedAt:   "2024-09-02T08:00:00Z",
+	},
+	{
+		Id:        "task-2",
+		Title:     "Write notes",
+		Priority:  "Low",
+		Status:    "Completed",
+		CreatedAt: "2024-09-01T09:00:00Z",
+		UpdatedAt: "2024-09-01T09:00:00Z",
+	},
+}
+
+func export(t *testing.T, format string) *bytes.Buffer {
+	var buffer bytes.Buffer
+	exporter := task_transfer.NewExporter(format, &buffer)
+	for i := range exported {
+		task := exported[i]
+		assert.NoError(t, exporter.Write(&task))
+	}
+	assert.NoError(t, exporter.Close())
+
+	return &buffer
+}
+
+func TestExporter(t *testing.T) {
+	t.Run("Should write CSV with a header", func(t *testing.T) {
+		lines := strings.Split(export(t, entity.TransferFormatCSV).String(), "\n")
+
+		assert.Equal(t, strings.Join(task_transfer.Columns, ","), lines[0])
+		assert.Equal(t, `task-1,"Launch, finally","Line one`, lines[1])
+		assert.Equal(t, `Line ""two""",,Urgent,To Do,2024-09-30,90,Sprint 1,"pixie, dixie",2024-09-01T08:00:00Z,2024-09-02T08:00:00Z`, lines[2])
+		assert.Equal(t, `task-2,Write notes,,,Low,Completed,,,,,2024-09-01T09:00:00Z,2024-09-01T09:00:00Z`, lines[3])
+	})
+
+	t.Run("Should write a JSON array", func(t *testing.T) {
+		document := export(t, entity.TransferFormatJSON).String()
+
+		assert.True(t, strings.HasPrefix(document, "[\n{"))
+		assert.Contains(t, document, `"assignees":[]`)
+		assert.Contains(t, document, `"title":"Launch, finally"`)
+	})
+
+	t.Run("Should write an empty JSON array without tasks", func(t *testing.T) {
+		var buffer bytes.Buffer
+		exporter := task_transfer.NewExporter(entity.TransferFormatJSON, &buffer)
+
+		assert.NoError(t, exporter.Close())
+		assert.JSONEq(t, `[]`, buffer.String())
+	})
+}
+
+func TestReadCSV(t *testing.T) {
+	t.Run("Should read its own exports back", func(t *testing.T) {
+		// Action
+		rows, err := task_transfer.ReadCSV(export(t, entity.TransferFormatCSV), nil)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, task_transfer.Row{
+			Title:       "Launch, finally",
+			Description: "Line one\nLine \"two\"",
+			Priority:    "Urgent",
+			Status:      "To Do",
+			DueDate:     "2024-09-30",
+			Estimate:    "90",
+			Assignees:   []string{"pixie", "dixie"},
+		}, rows[0])
+		assert.Nil(t, rows[1].Assignees)
+	})
+
+	t.Run("Should read the columns of the mapping", func(t *testing.T) {
+		// Arrange
+		file := "\ufeffName,State,Owners,Notes\n" +
+			"Fix login,In Progress,pixie@mail.com; dixie,Ignored\n"
+		mapping := map[string]string{"title": "Name", "status": "State", "assignees": " Owners"}
+
+		// Action
+		rows, err := task_transfer.ReadCSV(strings.NewReader(file), mapping)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, []task_transfer.Row{{
+			Title:     "Fix login",
+			Status:    "In Progress",
+			Assignees: []string{"pixie@mail.com", "dixie"},
+		}}, rows)
+	})
+
+	t.Run("Should reject invalid mappings and files", func(t *testing.T) {
+		cases := []struct {
+			file    string
+			mapping map[string]string
+			message string
+		}{
+			{"", nil, "file is empty"},
+			{"Name\nRelease\n", nil, "title must be mapped to a column"},
+			{"Name\nRelease\n", map[string]string{"status": "Name"}, "title must be mapped to a column"},
+			{"Name\nRelease\n", map[string]string{"title": "Title"}, `column "Title" of the mapping is not in the file`},
+			{"Name\nRelease\n", map[string]string{"title": "Name", "owner": "Name"}, `unknown field "owner"`},
+			{"title,status\nRelease\n", nil, "invalid CSV"},
+		}
+
+		for _, c := range cases {
+			_, err := task_transfer.ReadCSV(strings.NewReader(c.file), c.mapping)
+
+			if assert.Error(t, err, c.file) {
+				assert.Contains(t, err.Error(), c.message)
+			}
+		}
+	})
+
+	t.Run("Should limit the number of rows", func(t *testing.T) {
+		file := "title\n" + strings.Repeat("Task\n", task_transfer.MaxRows+1)
+
+		_, err := task_transfer.ReadCSV(strings.NewReader(file), nil)
+
+		assert.ErrorContains(t, err, "too many rows")
+	})
+}
+
+func TestReadJSON(t *testing.T) {
+	t.Run("Should read its own exports back", func(t *testing.T) {
+		// Action
+		rows, err := task_transfer.ReadJSON(export(t, entity.TransferFormatJSON))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Len(t, rows, 2)
+		assert.Equal(t, "Launch, finally", rows[0].Title)
+		assert.Equal(t, "90", rows[0].Estimate)
+		assert.Equal(t, []string{"pixie", "dixie"}, rows[0].Assignees)
+		assert.Equal(t, "", rows[1].Estimate)
+	})
+
+	t.Run("Should reject unknown fields", func(t *testing.T) {
+		_, err := task_transfer.ReadJSON(strings.NewReader(`[{"title":"Release","labels":["bug"]}]`))
+
+		assert.ErrorContains(t, err, "invalid JSON")
+	})
+}
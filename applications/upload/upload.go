@@ -0,0 +1,195 @@
+// Package upload inspects uploaded files before anything else reads them.
+//
+// The content type is sniffed from the first bytes of the content, never taken from the name or the request,
+// and images are measured from their header only, so a small file can't decompress into a huge picture.
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	_ "golang.org/x/image/webp"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Reasons a file is refused, wrapped with the details by Read and Inspect.
+var (
+	ErrTooLarge    = errors.New("file is too large")
+	ErrContentType = errors.New("file type is not allowed")
+	ErrDimensions  = errors.New("image dimensions are not allowed")
+	ErrUnreadable  = errors.New("file couldn't be read")
+)
+
+// Longest a sanitized file name may be, in bytes
+const maxFileNameLength = 100
+
+// Extensions of the content types that can be sniffed and allowed
+var extensions = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"application/zip": ".zip",
+	"text/plain":      ".txt",
+}
+
+// Policy tells what's accepted for a kind of upload.
+type Policy struct {
+	MaxBytes     int64
+	ContentTypes []string // Sniffed content types allowed, without parameters
+
+	// Images only, the dimensions are read from the header and are left unchecked when zero
+	MinDimension int
+	MaxDimension int
+	MaxPixels    int // Width times height, bounds the memory decoding takes
+}
+
+// File is an upload that satisfied its policy.
+type File struct {
+	Name        string // Sanitized, only meant to be shown or logged
+	ContentType string // Sniffed from the content
+	Extension   string // Of the content type, with its dot
+	Content     []byte
+	Width       int // Zero unless it's an image
+	Height      int
+}
+
+// Read reads the uploaded file, refusing it once it's larger than the policy allows.
+func Read(header *multipart.FileHeader, policy Policy) (*File, error) {
+	if policy.MaxBytes > 0 && header.Size > policy.MaxBytes {
+		return nil, fmt.Errorf("%w: at most %d bytes", ErrTooLarge, policy.MaxBytes)
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadable, err)
+	}
+	defer file.Close()
+
+	// The header could lie about the size
+	reader := io.Reader(file)
+	if policy.MaxBytes > 0 {
+		reader = io.LimitReader(file, policy.MaxBytes+1)
+	}
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnreadable, err)
+	}
+
+	return Inspect(header.Filename, content, policy)
+}
+
+// Inspect checks the content against the policy.
+func Inspect(name string, content []byte, policy Policy) (*File, error) {
+	if policy.MaxBytes > 0 && int64(len(content)) > policy.MaxBytes {
+		return nil, fmt.Errorf("%w: at most %d bytes", ErrTooLarge, policy.MaxBytes)
+	}
+
+	contentType := Sniff(content)
+	if !slices.Contains(policy.ContentTypes, contentType) {
+		return nil, fmt.Errorf("%w: %s, only %s", ErrContentType, contentType, strings.Join(policy.ContentTypes, ", "))
+	}
+
+	file := &File{
+		Name:        SanitizeFileName(name),
+		ContentType: contentType,
+		Extension:   extensions[contentType],
+		Content:     content,
+	}
+
+	if strings.HasPrefix(contentType, "image/") && (policy.MinDimension > 0 || policy.MaxDimension > 0 || policy.MaxPixels > 0) {
+		config, _, err := image.DecodeConfig(bytes.NewReader(content))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnreadable, err)
+		}
+		file.Width, file.Height = config.Width, config.Height
+
+		if err := checkDimensions(file.Width, file.Height, policy); err != nil {
+			return nil, err
+		}
+	}
+
+	return file, nil
+}
+
+func checkDimensions(width int, height int, policy Policy) error {
+	if policy.MinDimension > 0 && (width < policy.MinDimension || height < policy.MinDimension) {
+		return fmt.Errorf("%w: at least %d pixels wide and high", ErrDimensions, policy.MinDimension)
+	}
+	if policy.MaxDimension > 0 && (width > policy.MaxDimension || height > policy.MaxDimension) {
+		return fmt.Errorf("%w: at most %d pixels wide and high", ErrDimensions, policy.MaxDimension)
+	}
+	if policy.MaxPixels > 0 && width*height > policy.MaxPixels {
+		return fmt.Errorf("%w: at most %d pixels in total", ErrDimensions, policy.MaxPixels)
+	}
+
+	return nil
+}
+
+// Sniff returns the content type of the content, without its parameters.
+func Sniff(content []byte) string {
+	contentType := http.DetectContentType(content)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	return contentType
+}
+
+// SanitizeFileName keeps the base name of the uploaded name, without control characters,
+// path separators or characters shells and file systems treat specially. It's never empty.
+func SanitizeFileName(name string) string {
+	// Browsers on Windows may send the full path
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+
+	var sanitized strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			continue
+		case strings.ContainsRune(`/<>:"|?*`, r), unicode.IsSpace(r):
+			sanitized.WriteRune('_')
+		default:
+			sanitized.WriteRune(r)
+		}
+	}
+
+	// Hidden files and relative names like ".." aren't kept as they are
+	result := strings.TrimLeft(sanitized.String(), ".")
+	if len(result) > maxFileNameLength {
+		extension := path.Ext(result)
+		if len(extension) > 10 {
+			extension = ""
+		}
+		result = truncate(result[:len(result)-len(extension)], maxFileNameLength-len(extension)) + extension
+	}
+	if result == "" {
+		return "file"
+	}
+
+	return result
+}
+
+// truncate cuts the text to at most size bytes, without splitting a character.
+func truncate(text string, size int) string {
+	if len(text) <= size {
+		return text
+	}
+	for size > 0 && !utf8.RuneStart(text[size]) {
+		size--
+	}
+
+	return text[:size]
+}
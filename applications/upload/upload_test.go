@@ -0,0 +1,113 @@
+package upload_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wisle25/task-pixie/applications/upload"
+)
+
+func encodePNG(t *testing.T, width int, height int) []byte {
+	var buffer bytes.Buffer
+	require.NoError(t, png.Encode(&buffer, image.NewGray(image.Rect(0, 0, width, height))))
+
+	return buffer.Bytes()
+}
+
+func TestInspect(t *testing.T) {
+	policy := upload.Policy{
+		MaxBytes:     1 << 20,
+		ContentTypes: []string{"image/png", "image/jpeg"},
+		MinDimension: 32,
+		MaxDimension: 1024,
+		MaxPixels:    512 * 512,
+	}
+
+	t.Run("Should accept an allowed image and measure it", func(t *testing.T) {
+		// Action
+		file, err := upload.Inspect("../../avatar.png", encodePNG(t, 64, 48), policy)
+
+		// Assert
+		require.NoError(t, err)
+		assert.Equal(t, "avatar.png", file.Name)
+		assert.Equal(t, "image/png", file.ContentType)
+		assert.Equal(t, ".png", file.Extension)
+		assert.Equal(t, 64, file.Width)
+		assert.Equal(t, 48, file.Height)
+	})
+
+	t.Run("Should sniff the type instead of trusting the name", func(t *testing.T) {
+		// Action
+		_, err := upload.Inspect("avatar.png", []byte("<html><script>alert(1)</script></html>"), policy)
+
+		// Assert
+		assert.ErrorIs(t, err, upload.ErrContentType)
+	})
+
+	t.Run("Should refuse content larger than the limit", func(t *testing.T) {
+		// Action
+		_, err := upload.Inspect("avatar.png", encodePNG(t, 64, 64), upload.Policy{
+			MaxBytes:     16,
+			ContentTypes: policy.ContentTypes,
+		})
+
+		// Assert
+		assert.ErrorIs(t, err, upload.ErrTooLarge)
+	})
+
+	t.Run("Should refuse images out of the dimensions", func(t *testing.T) {
+		for _, size := range [][2]int{{16, 64}, {2048, 64}, {1000, 1000}} {
+			// Action
+			_, err := upload.Inspect("avatar.png", encodePNG(t, size[0], size[1]), policy)
+
+			// Assert
+			assert.ErrorIs(t, err, upload.ErrDimensions, size)
+		}
+	})
+
+	t.Run("Should refuse an image whose header can't be read", func(t *testing.T) {
+		// Arrange
+		content := encodePNG(t, 64, 64)[:20]
+
+		// Action
+		_, err := upload.Inspect("avatar.png", content, policy)
+
+		// Assert
+		assert.ErrorIs(t, err, upload.ErrUnreadable)
+	})
+}
+
+func TestSanitizeFileName(t *testing.T) {
+	t.Run("Should keep the base name without special characters", func(t *testing.T) {
+		tests := map[string]string{
+			"report.pdf":                "report.pdf",
+			"../../etc/passwd":          "passwd",
+			`C:\Users\pixie\avatar.png`: "avatar.png",
+			"my photo?.png":             "my_photo_.png",
+			"in\x00valid\r\nname.png":   "invalidname.png",
+			".htaccess":                 "htaccess",
+			"..":                        "file",
+			"":                          "file",
+			"evil\u202egnp.exe":         "evilgnp.exe",
+			"résumé.pdf":                "résumé.pdf",
+		}
+
+		for name, expected := range tests {
+			assert.Equal(t, expected, upload.SanitizeFileName(name), name)
+		}
+	})
+
+	t.Run("Should shorten a long name and keep its extension", func(t *testing.T) {
+		// Action
+		name := upload.SanitizeFileName(strings.Repeat("é", 200) + ".png")
+
+		// Assert
+		assert.LessOrEqual(t, len(name), 100)
+		assert.True(t, strings.HasSuffix(name, "é.png"))
+	})
+}
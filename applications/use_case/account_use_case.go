@@ -0,0 +1,208 @@
+package use_case
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/account_export"
+	"github.com/wisle25/task-pixie/applications/file_statics"
+	"github.com/wisle25/task-pixie/applications/security"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"log"
+	"path"
+	"strings"
+	"time"
+)
+
+// Exports claimed by a single run of the worker
+const exportBatchSize = 5
+
+// AccountUseCase handles the business logic for exporting the data of users and deleting their accounts.
+type AccountUseCase struct {
+	accountRepository repository.AccountRepository
+	userRepository    repository.UserRepository
+	fileUpload        file_statics.FileUpload
+	passwordHash      security.PasswordHash
+	validator         validation.ValidateUser
+	config            *commons.Config
+}
+
+func NewAccountUseCase(
+	accountRepository repository.AccountRepository,
+	userRepository repository.UserRepository,
+	fileUpload file_statics.FileUpload,
+	passwordHash security.PasswordHash,
+	validator validation.ValidateUser,
+	config *commons.Config,
+) *AccountUseCase {
+	return &AccountUseCase{
+		accountRepository: accountRepository,
+		userRepository:    userRepository,
+		fileUpload:        fileUpload,
+		passwordHash:      passwordHash,
+		validator:         validator,
+		config:            config,
+	}
+}
+
+// ExecuteRequestExport returns the latest export of the user's data, a new one is queued
+// when there's none yet or the latest failed or expired.
+// Returning the export and whether it was just queued.
+func (uc *AccountUseCase) ExecuteRequestExport(userId string) (*entity.AccountExport, bool) {
+	export := uc.accountRepository.GetLatestExport(userId)
+	if export != nil && export.Status != entity.AccountExportFailed && !export.Expired {
+		return export, false
+	}
+
+	uc.accountRepository.AddExport(userId)
+
+	return uc.accountRepository.GetLatestExport(userId), true
+}
+
+// ExecuteDownloadExport returns the archive of the latest export of the user's data.
+// Should raise panic if it's not ready.
+func (uc *AccountUseCase) ExecuteDownloadExport(userId string) []byte {
+	export := uc.accountRepository.GetLatestExport(userId)
+	if export == nil || export.Expired {
+		panic(fiber.NewError(fiber.StatusNotFound, "Export not found, request a new one!"))
+	}
+	if export.Status != entity.AccountExportReady {
+		panic(fiber.NewError(fiber.StatusConflict, "Export is not ready yet!"))
+	}
+
+	return uc.fileUpload.GetFile(export.FileName)
+}
+
+// ExecuteBuildPendingExports builds the archives of the pending exports, a failure is recorded on its export.
+// Returning the number of handled exports.
+func (uc *AccountUseCase) ExecuteBuildPendingExports() int {
+	exports := uc.accountRepository.ClaimPendingExports(exportBatchSize)
+
+	for _, export := range exports {
+		uc.buildExport(&export)
+	}
+
+	return len(exports)
+}
+
+func (uc *AccountUseCase) buildExport(export *entity.AccountExport) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("build_export_err: export %s: %v", export.Id, r)
+			uc.accountRepository.FailExport(export.Id, "The export couldn't be built, request a new one!")
+		}
+	}()
+
+	data := uc.accountRepository.GetAccountData(export.UserId)
+	data.Profile = uc.userRepository.GetUserById(export.UserId)
+
+	var files []account_export.File
+	if data.Profile.AvatarLink != "" {
+		files = append(files, account_export.File{
+			Name:    "avatar" + path.Ext(data.Profile.AvatarLink),
+			Content: uc.fileUpload.GetFile(data.Profile.AvatarLink),
+		})
+	}
+
+	var archive bytes.Buffer
+	if err := account_export.Write(&archive, data, files, time.Now()); err != nil {
+		panic(fmt.Errorf("write archive: %v", err))
+	}
+
+	fileName := uc.fileUpload.UploadFile(archive.Bytes(), ".zip")
+	uc.accountRepository.CompleteExport(export.Id, fileName, uc.config.AccountExportTTL)
+}
+
+// ExecuteRemoveExpiredExports removes the expired exports with their archives.
+// Returning the number of removed exports.
+func (uc *AccountUseCase) ExecuteRemoveExpiredExports() int {
+	fileNames := uc.accountRepository.DeleteExpiredExports()
+	for _, fileName := range fileNames {
+		uc.removeFile(fileName)
+	}
+
+	return len(fileNames)
+}
+
+// ExecuteScheduleDeletion schedules the deletion of the user's account after the grace period, the password confirms it.
+// Should raise panic if the user still owns projects shared with others, they must be transferred or deleted first.
+func (uc *AccountUseCase) ExecuteScheduleDeletion(userId string, payload *entity.DeleteUserPayload) *entity.AccountDeletion {
+	uc.validator.ValidateDeletePayload(payload)
+
+	user := uc.userRepository.GetUserById(userId)
+	_, encryptedPassword := uc.userRepository.GetUserForLogin(user.Username)
+	uc.passwordHash.Compare(payload.Password, encryptedPassword)
+
+	if projects := uc.accountRepository.GetSharedOwnedProjects(userId); len(projects) > 0 {
+		titles := make([]string, 0, len(projects))
+		for _, project := range projects {
+			titles = append(titles, project.Title)
+		}
+
+		panic(fiber.NewError(
+			fiber.StatusConflict,
+			"Transfer or delete the projects you share with others first: "+strings.Join(titles, ", ")+"!",
+		))
+	}
+
+	return &entity.AccountDeletion{
+		ScheduledAt: uc.accountRepository.ScheduleDeletion(userId, uc.config.AccountDeletionGracePeriod),
+	}
+}
+
+// ExecuteCancelDeletion keeps the user's account, while its grace period isn't over.
+func (uc *AccountUseCase) ExecuteCancelDeletion(userId string) {
+	uc.accountRepository.CancelDeletion(userId)
+}
+
+// ExecuteDeleteDueAccounts anonymizes the accounts whose grace period is over, along with their files.
+// A failure is logged so other accounts are still deleted.
+// Returning the number of deleted accounts.
+func (uc *AccountUseCase) ExecuteDeleteDueAccounts() int {
+	deleted := 0
+
+	for _, userId := range uc.accountRepository.GetAccountsDueForDeletion() {
+		if uc.deleteAccount(userId) {
+			deleted++
+		}
+	}
+
+	return deleted
+}
+
+func (uc *AccountUseCase) deleteAccount(userId string) (deleted bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("delete_account_err: user %s: %v", userId, r)
+			deleted = false
+		}
+	}()
+
+	// The rows of the exports go with the account
+	fileNames := uc.accountRepository.GetExportFileNames(userId)
+	fileNames = append(fileNames, uc.accountRepository.AnonymizeAccount(userId)...)
+
+	for _, fileName := range fileNames {
+		uc.removeFile(fileName)
+	}
+
+	return true
+}
+
+// removeFile removes a file, a failure is only logged since nothing references it anymore.
+func (uc *AccountUseCase) removeFile(fileName string) {
+	if fileName == "" {
+		return
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("remove_file_err: %s: %v", fileName, r)
+		}
+	}()
+
+	uc.fileUpload.RemoveFile(fileName)
+}
@@ -0,0 +1,213 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/file_statics"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"log"
+	"time"
+)
+
+// Users and audit logs per page when the limit isn't specified
+const defaultAdminLimit = 20
+
+// AdminUseCase handles the business logic of the admin console, every change is written to the audit log.
+type AdminUseCase struct {
+	adminRepository repository.AdminRepository
+	userRepository  repository.UserRepository
+	fileUpload      file_statics.FileUpload
+	mailer          mailer.Mailer
+	mailRenderer    mailer.MailRenderer
+	cache           cache.Cache
+	validator       validation.ValidateAdmin
+	config          *commons.Config
+}
+
+func NewAdminUseCase(
+	adminRepository repository.AdminRepository,
+	userRepository repository.UserRepository,
+	fileUpload file_statics.FileUpload,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	cache cache.Cache,
+	validator validation.ValidateAdmin,
+	config *commons.Config,
+) *AdminUseCase {
+	return &AdminUseCase{
+		adminRepository: adminRepository,
+		userRepository:  userRepository,
+		fileUpload:      fileUpload,
+		mailer:          mailer,
+		mailRenderer:    mailRenderer,
+		cache:           cache,
+		validator:       validator,
+		config:          config,
+	}
+}
+
+// ExecuteGetUsers searches the users by username, email or display name, filtered by the status of their account.
+func (uc *AdminUseCase) ExecuteGetUsers(filter *entity.AdminUserFilter, adminId string) *entity.AdminUserList {
+	uc.requireAdmin(adminId)
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAdminLimit
+	}
+	uc.validator.ValidateUserFilter(filter)
+
+	return uc.adminRepository.GetUsers(filter)
+}
+
+// ExecuteDisableUser disables the account and signs the user out everywhere, they can't log in until it's enabled.
+// Their calendar feeds stop working meanwhile.
+// Should raise panic if the admin disables themselves or the account is already disabled.
+func (uc *AdminUseCase) ExecuteDisableUser(id string, adminId string) {
+	uc.requireAdmin(adminId)
+
+	if id == adminId {
+		panic(fiber.NewError(fiber.StatusBadRequest, "You can't disable your own account!"))
+	}
+	if user := uc.adminRepository.GetUser(id); user.Status == entity.AccountStatusDisabled {
+		panic(fiber.NewError(fiber.StatusConflict, "User is already disabled!"))
+	}
+
+	uc.adminRepository.SetUserDisabled(id, true)
+	revokeSessions(uc.cache, uc.config, id)
+
+	uc.adminRepository.AddAuditLog(adminId, entity.AuditDisableUser, entity.AuditTargetUser, id, nil)
+}
+
+// ExecuteEnableUser lets the disabled user log in again.
+// Should raise panic if the account isn't disabled.
+func (uc *AdminUseCase) ExecuteEnableUser(id string, adminId string) {
+	uc.requireAdmin(adminId)
+
+	if user := uc.adminRepository.GetUser(id); user.Status != entity.AccountStatusDisabled {
+		panic(fiber.NewError(fiber.StatusConflict, "User is not disabled!"))
+	}
+
+	uc.adminRepository.SetUserDisabled(id, false)
+
+	uc.adminRepository.AddAuditLog(adminId, entity.AuditEnableUser, entity.AuditTargetUser, id, nil)
+}
+
+// ExecuteForcePasswordReset signs the user out everywhere and emails them a link to choose a new password.
+// They can't log in until it's done, forcing it again replaces the previous link.
+// Once the link expires, logging in emails a new one.
+func (uc *AdminUseCase) ExecuteForcePasswordReset(id string, adminId string) {
+	uc.requireAdmin(adminId)
+
+	user := uc.userRepository.GetUserById(id)
+	token := generateSecretToken()
+
+	uc.userRepository.RequirePasswordReset(id, hashSecretToken(token), uc.config.PasswordResetTTL)
+	revokeSessions(uc.cache, uc.config, id)
+
+	uc.adminRepository.AddAuditLog(adminId, entity.AuditForcePasswordReset, entity.AuditTargetUser, id, nil)
+
+	mailPasswordReset(uc.mailer, uc.mailRenderer, uc.config, user, token)
+}
+
+// ExecuteGetStats counts the users and what they've made across the whole system.
+func (uc *AdminUseCase) ExecuteGetStats(adminId string) *entity.SystemStats {
+	uc.requireAdmin(adminId)
+
+	return uc.adminRepository.GetStats()
+}
+
+// ExecuteGetOrphanedFiles lists the stored files nothing references anymore.
+func (uc *AdminUseCase) ExecuteGetOrphanedFiles(adminId string) *entity.OrphanedFiles {
+	uc.requireAdmin(adminId)
+
+	return uc.findOrphanedFiles()
+}
+
+// ExecutePurgeOrphanedFiles removes the stored files nothing references anymore.
+// A file that couldn't be removed is only logged, it's listed again next time.
+// Returning the removed files.
+func (uc *AdminUseCase) ExecutePurgeOrphanedFiles(adminId string) *entity.OrphanedFiles {
+	uc.requireAdmin(adminId)
+
+	orphaned := uc.findOrphanedFiles()
+	purged := &entity.OrphanedFiles{Files: make([]entity.StoredFile, 0)}
+	fileNames := make([]string, 0, len(orphaned.Files))
+
+	for _, file := range orphaned.Files {
+		if !uc.removeFile(file.Name) {
+			continue
+		}
+
+		purged.Files = append(purged.Files, file)
+		purged.Size += file.Size
+		fileNames = append(fileNames, file.Name)
+	}
+
+	uc.adminRepository.AddAuditLog(adminId, entity.AuditPurgeFiles, entity.AuditTargetFile, "", map[string]interface{}{
+		"files": fileNames,
+		"size":  purged.Size,
+	})
+
+	return purged
+}
+
+// ExecuteGetAuditLogs retrieves the audit log, the latest first.
+func (uc *AdminUseCase) ExecuteGetAuditLogs(filter *entity.AuditLogFilter, adminId string) []entity.AuditLog {
+	uc.requireAdmin(adminId)
+
+	if filter.Limit == 0 {
+		filter.Limit = defaultAdminLimit
+	}
+	uc.validator.ValidateAuditLogFilter(filter)
+
+	return uc.adminRepository.GetAuditLogs(filter)
+}
+
+// requireAdmin makes sure the user is an administrator.
+// Should raise panic (403) if they're not.
+func (uc *AdminUseCase) requireAdmin(userId string) {
+	if !uc.adminRepository.IsAdmin(userId) {
+		panic(fiber.NewError(fiber.StatusForbidden, "Only administrators are allowed to do this!"))
+	}
+}
+
+// findOrphanedFiles compares the stored files with the referenced ones.
+// Recent files are left out, they may be uploaded right before being referenced.
+func (uc *AdminUseCase) findOrphanedFiles() *entity.OrphanedFiles {
+	referenced := make(map[string]bool)
+	for _, fileName := range uc.adminRepository.GetReferencedFileNames() {
+		referenced[fileName] = true
+	}
+
+	cutoff := time.Now().Add(-uc.config.OrphanedFileAge)
+	orphaned := &entity.OrphanedFiles{Files: make([]entity.StoredFile, 0)}
+
+	for _, file := range uc.fileUpload.ListFiles() {
+		lastModified, err := time.Parse(time.RFC3339, file.LastModified)
+		if referenced[file.Name] || err != nil || lastModified.After(cutoff) {
+			continue
+		}
+
+		orphaned.Files = append(orphaned.Files, file)
+		orphaned.Size += file.Size
+	}
+
+	return orphaned
+}
+
+// removeFile removes a file, returning whether it could.
+func (uc *AdminUseCase) removeFile(fileName string) (removed bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("remove_file_err: %s: %v", fileName, r)
+			removed = false
+		}
+	}()
+
+	uc.fileUpload.RemoveFile(fileName)
+
+	return true
+}
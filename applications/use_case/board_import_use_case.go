@@ -0,0 +1,302 @@
+package use_case
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/event"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/applications/task_transfer"
+	"github.com/wisle25/task-pixie/applications/upload"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"slices"
+	"strings"
+)
+
+var taskStatuses = []string{
+	entity.TaskStatusToDo,
+	entity.TaskStatusInProgress,
+	entity.TaskStatusCompleted,
+	entity.TaskStatusCanceled,
+}
+
+// What's accepted as a board export, Trello and Jira both export JSON which sniffs as plain text
+var boardImportPolicy = upload.Policy{
+	MaxBytes:     20 << 20,
+	ContentTypes: []string{"text/plain"},
+}
+
+// BoardImportUseCase handles the business logic for importing boards exported from other tools, like Trello or Jira.
+type BoardImportUseCase struct {
+	taskRepository    repository.TaskRepository
+	projectRepository repository.ProjectRepository
+	userRepository    repository.UserRepository
+	validator         validation.ValidateTask
+	adapters          []importer.Adapter
+	uploadGuard       *UploadGuard
+	eventPublisher    event.EventPublisher
+	cache             cache.Cache
+}
+
+func NewBoardImportUseCase(
+	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+	userRepository repository.UserRepository,
+	validator validation.ValidateTask,
+	adapters []importer.Adapter,
+	uploadGuard *UploadGuard,
+	eventPublisher event.EventPublisher,
+	cache cache.Cache,
+) *BoardImportUseCase {
+	return &BoardImportUseCase{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		validator:         validator,
+		adapters:          adapters,
+		uploadGuard:       uploadGuard,
+		eventPublisher:    eventPublisher,
+		cache:             cache,
+	}
+}
+
+// ExecutePreviewBoardImport reads the board without importing anything, telling how its states and members
+// would be mapped, and which cards couldn't be imported. The mapping is then adjusted and committed.
+func (uc *BoardImportUseCase) ExecutePreviewBoardImport(projectId string, payload *entity.BoardImportPayload, userId string) *entity.BoardImportReport {
+	return uc.importBoard(projectId, payload, userId, false)
+}
+
+// ExecuteCommitBoardImport imports the cards of the board into the project, mapped like the preview.
+// Cards are only imported, all at once, when every one of them is valid.
+func (uc *BoardImportUseCase) ExecuteCommitBoardImport(projectId string, payload *entity.BoardImportPayload, userId string) *entity.BoardImportReport {
+	return uc.importBoard(projectId, payload, userId, true)
+}
+
+func (uc *BoardImportUseCase) importBoard(projectId string, payload *entity.BoardImportPayload, userId string, commit bool) *entity.BoardImportReport {
+	uc.validator.ValidateBoardImportPayload(payload)
+	requireProjectAccess(uc.projectRepository, projectId, userId)
+	requireProjectWritable(uc.projectRepository, projectId)
+
+	mapping := uc.readMapping(payload)
+	board := uc.readBoard(payload, userId)
+
+	report := &entity.BoardImportReport{
+		Source:   strings.ToLower(payload.Source),
+		Board:    board.Name,
+		Labels:   []string{},
+		Warnings: []string{},
+	}
+
+	statuses := uc.mapStates(board, mapping, report)
+	members := uc.mapMembers(projectId, board, mapping, report)
+
+	var payloads []entity.TaskPayload
+	for i := range board.Cards {
+		card := &board.Cards[i]
+		if card.Archived {
+			report.Skipped++
+			continue
+		}
+
+		for _, label := range card.Labels {
+			if !slices.Contains(report.Labels, label) {
+				report.Labels = append(report.Labels, label)
+			}
+		}
+
+		taskPayload := uc.cardPayload(projectId, card, statuses, members, mapping)
+		errs := importedTaskErrors(uc.validator, taskPayload)
+		if len(errs) > 0 {
+			report.Failed++
+		}
+
+		payloads = append(payloads, *taskPayload)
+		report.Rows = append(report.Rows, entity.TaskImportRowResult{Row: len(payloads), Title: card.Title, Errors: errs})
+	}
+
+	report.Total = len(payloads)
+	if report.Total == 0 {
+		panic(fiber.NewError(fiber.StatusBadRequest, "The board has no cards to import!"))
+	}
+	if report.Total > task_transfer.MaxRows {
+		panic(fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("The board has too many cards, at most %d are imported at once!", task_transfer.MaxRows)))
+	}
+	if len(report.Labels) > 0 {
+		report.Warnings = append(report.Warnings, "Projects have no labels, they're kept in the detail of the tasks.")
+	}
+
+	if !commit || report.Failed > 0 {
+		return report
+	}
+
+	ids := uc.taskRepository.ImportTasks(payloads, userId)
+	for i, id := range ids {
+		report.Rows[i].TaskId = id
+	}
+	report.Imported = true
+	invalidateProjectStats(uc.cache, projectId)
+
+	// Notify subscribers of the project, the same way single creations do
+	for _, id := range ids {
+		uc.eventPublisher.Publish(projectId, entity.WebhookEventTaskCreated, uc.taskRepository.GetTaskById(id))
+	}
+
+	return report
+}
+
+// readMapping reads the overrides of the suggestions, should raise panic (400) if they're invalid.
+func (uc *BoardImportUseCase) readMapping(payload *entity.BoardImportPayload) *entity.BoardImportMapping {
+	var mapping entity.BoardImportMapping
+	if payload.Mapping != "" {
+		if err := json.Unmarshal([]byte(payload.Mapping), &mapping); err != nil {
+			panic(fiber.NewError(fiber.StatusBadRequest, "Invalid mapping: it must map statuses and members!"))
+		}
+	}
+
+	for stateId, status := range mapping.Statuses {
+		if !slices.Contains(taskStatuses, status) {
+			panic(fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid mapping: %s is not a task status for %s!", status, stateId)))
+		}
+	}
+
+	return &mapping
+}
+
+// readBoard checks the uploaded export and reads it with the adapter of its source, should raise panic (400) if it can't.
+func (uc *BoardImportUseCase) readBoard(payload *entity.BoardImportPayload, userId string) *importer.Board {
+	var adapter importer.Adapter
+	var sources []string
+	for _, a := range uc.adapters {
+		if strings.EqualFold(a.Source(), payload.Source) {
+			adapter = a
+		}
+		sources = append(sources, a.Source())
+	}
+	if adapter == nil {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Unknown source, it must be one of "+strings.Join(sources, ", ")+"!"))
+	}
+
+	file := uc.uploadGuard.Check(payload.File, boardImportPolicy, userId)
+
+	board, err := adapter.Parse(bytes.NewReader(file.Content))
+	if err != nil {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Invalid file: "+err.Error()+"!"))
+	}
+
+	return board
+}
+
+// mapStates maps every state of the board to a task status, the suggestion of the adapter unless overridden.
+func (uc *BoardImportUseCase) mapStates(board *importer.Board, mapping *entity.BoardImportMapping, report *entity.BoardImportReport) map[string]string {
+	statuses := make(map[string]string)
+
+	for _, state := range board.States {
+		status, ok := mapping.Statuses[state.Id]
+		if !ok {
+			status = state.Status
+		}
+		if status == "" {
+			status = entity.TaskStatusToDo
+		}
+		statuses[state.Id] = status
+
+		tasks := 0
+		for _, card := range board.Cards {
+			if card.StateId == state.Id && !card.Archived {
+				tasks++
+			}
+		}
+
+		report.States = append(report.States, entity.BoardImportState{
+			Id:     state.Id,
+			Name:   state.Name,
+			Tasks:  tasks,
+			Status: status,
+		})
+	}
+
+	return statuses
+}
+
+// mapMembers maps the members of the board to users of the project, by the identity of the mapping or by email.
+// Should raise panic (400) if the mapping names someone outside the project, unmatched members are only warned about.
+func (uc *BoardImportUseCase) mapMembers(
+	projectId string,
+	board *importer.Board,
+	mapping *entity.BoardImportMapping,
+	report *entity.BoardImportReport,
+) map[string]string {
+	users := make(map[string]string)
+
+	for _, member := range board.Members {
+		result := entity.BoardImportMember{Id: member.Id, Name: member.Name, Email: member.Email}
+
+		identity, overridden := mapping.Members[member.Id]
+		if !overridden {
+			identity = member.Email
+		}
+
+		if identity != "" {
+			user := uc.userRepository.FindUserByIdentity(identity)
+			if user != nil && uc.projectRepository.GetMemberRole(projectId, user.Id) != "" {
+				result.UserId = user.Id
+				result.Username = user.Username
+			} else if overridden {
+				panic(fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid mapping: %s is not part of the project!", identity)))
+			}
+		}
+
+		if result.UserId == "" && !overridden {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("Member %s has no user in the project, their cards are left unassigned.", member.Name))
+		}
+
+		users[member.Id] = result.UserId
+		report.Members = append(report.Members, result)
+	}
+
+	return users
+}
+
+// cardPayload turns the card into the payload of a new task of the project.
+// Missing descriptions default to the title, and missing priorities to Low.
+func (uc *BoardImportUseCase) cardPayload(
+	projectId string,
+	card *importer.Card,
+	statuses map[string]string,
+	members map[string]string,
+	mapping *entity.BoardImportMapping,
+) *entity.TaskPayload {
+	payload := &entity.TaskPayload{
+		Title:       strings.TrimSpace(card.Title),
+		Description: strings.TrimSpace(card.Description),
+		Detail:      card.Detail(),
+		Priority:    card.Priority,
+		Status:      statuses[card.StateId],
+		ProjectId:   projectId,
+		DueDate:     card.DueDate,
+	}
+	if payload.Description == "" {
+		payload.Description = payload.Title
+	}
+	if payload.Priority == "" {
+		payload.Priority = "Low"
+	}
+	if payload.Status == "" {
+		payload.Status = entity.TaskStatusToDo
+	}
+	if payload.DueDate == "" {
+		payload.DueDate = mapping.DueDate
+	}
+
+	for _, memberId := range card.MemberIds {
+		if userId := members[memberId]; userId != "" && !slices.Contains(payload.AssignedToId, userId) {
+			payload.AssignedToId = append(payload.AssignedToId, userId)
+		}
+	}
+
+	return payload
+}
@@ -0,0 +1,142 @@
+package use_case
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/ical"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"time"
+)
+
+const calendarProdId = "-//Task Pixie//Calendar//EN"
+
+// Priorities of tasks in calendars, 1 is the highest and 9 the lowest
+var calendarPriorities = map[string]int{
+	"Urgent": 1,
+	"High":   3,
+	"Low":    9,
+}
+
+var calendarTodoStatuses = map[string]string{
+	entity.TaskStatusToDo:       ical.TodoNeedsAction,
+	entity.TaskStatusInProgress: ical.TodoInProcess,
+	entity.TaskStatusCompleted:  ical.TodoCompleted,
+	entity.TaskStatusCanceled:   ical.TodoCancelled,
+}
+
+// CalendarFeedUseCase handles the business logic for the calendar feeds calendar apps subscribe to.
+type CalendarFeedUseCase struct {
+	calendarFeedRepository repository.CalendarFeedRepository
+	projectRepository      repository.ProjectRepository
+	validator              validation.ValidateCalendarFeed
+	config                 *commons.Config
+}
+
+func NewCalendarFeedUseCase(
+	calendarFeedRepository repository.CalendarFeedRepository,
+	projectRepository repository.ProjectRepository,
+	validator validation.ValidateCalendarFeed,
+	config *commons.Config,
+) *CalendarFeedUseCase {
+	return &CalendarFeedUseCase{
+		calendarFeedRepository: calendarFeedRepository,
+		projectRepository:      projectRepository,
+		validator:              validator,
+		config:                 config,
+	}
+}
+
+// ExecuteAddCalendarFeed creates the calendar feed of the user for a project, or for their own tasks.
+// A previous feed of the same kind is revoked. Returns the feed ID and its secret token, not shown anymore after this.
+func (uc *CalendarFeedUseCase) ExecuteAddCalendarFeed(payload *entity.CalendarFeedPayload, userId string) (string, string) {
+	uc.validator.ValidatePayload(payload)
+	requireProjectAccess(uc.projectRepository, payload.ProjectId, userId)
+
+	token := generateSecretToken()
+	feedId := uc.calendarFeedRepository.AddCalendarFeed(userId, payload.ProjectId, hashSecretToken(token))
+
+	return feedId, token
+}
+
+// ExecuteGetCalendarFeeds retrieves the calendar feeds of the user.
+func (uc *CalendarFeedUseCase) ExecuteGetCalendarFeeds(userId string) []entity.CalendarFeed {
+	return uc.calendarFeedRepository.GetCalendarFeedsByUser(userId)
+}
+
+// ExecuteDeleteCalendarFeed revokes a calendar feed of the user, its URL stops working at once.
+func (uc *CalendarFeedUseCase) ExecuteDeleteCalendarFeed(id string, userId string) {
+	uc.calendarFeedRepository.DeleteCalendarFeed(id, userId)
+}
+
+// ExecuteGetCalendar returns the iCalendar document of the feed with the token, publishing the tasks with a due date.
+// A project feed stops working once its user leaves the project.
+func (uc *CalendarFeedUseCase) ExecuteGetCalendar(token string, filter *entity.CalendarFeedFilter) []byte {
+	uc.validator.ValidateFilter(filter)
+	feed := uc.calendarFeedRepository.GetCalendarFeedByToken(hashSecretToken(token))
+
+	calendar := ical.Calendar{ProdId: calendarProdId}
+	var tasks []entity.CalendarTask
+	if feed.ProjectId == "" {
+		calendar.Name = "Task Pixie: My tasks"
+		tasks = uc.calendarFeedRepository.GetUserCalendarTasks(feed.UserId)
+	} else {
+		if uc.projectRepository.GetMemberRole(feed.ProjectId, feed.UserId) == "" {
+			panic(fiber.NewError(fiber.StatusNotFound, "Calendar feed not found!"))
+		}
+
+		calendar.Name = "Task Pixie: " + feed.Project
+		tasks = uc.calendarFeedRepository.GetProjectCalendarTasks(feed.ProjectId)
+	}
+
+	for _, task := range tasks {
+		due, err := time.Parse(time.DateOnly, task.DueDate)
+		if err != nil {
+			panic(fmt.Errorf("calendar_feed_use_case_error: parse due date: %v", err))
+		}
+		updatedAt, err := time.Parse(time.RFC3339, task.UpdatedAt)
+		if err != nil {
+			panic(fmt.Errorf("calendar_feed_use_case_error: parse updated at: %v", err))
+		}
+
+		uid := task.Id + "@task-pixie"
+		url := uc.config.ClientOrigin + "/tasks/" + task.Id
+		if filter.Kind == entity.CalendarFeedTodos {
+			calendar.Todos = append(calendar.Todos, ical.Todo{
+				UID:         uid,
+				Summary:     task.Title,
+				Description: task.Description,
+				URL:         url,
+				Due:         due,
+				Status:      calendarTodoStatuses[task.Status],
+				Priority:    calendarPriorities[task.Priority],
+				Sequence:    task.Version,
+				Categories:  []string{task.Project},
+				Stamp:       updatedAt,
+			})
+			continue
+		}
+
+		// Calendars have no done events, canceled tasks are canceled events
+		status := ical.EventConfirmed
+		if task.Status == entity.TaskStatusCanceled {
+			status = ical.EventCancelled
+		}
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         uid,
+			Summary:     task.Title,
+			Description: task.Description,
+			URL:         url,
+			Day:         due,
+			Status:      status,
+			Priority:    calendarPriorities[task.Priority],
+			Sequence:    task.Version,
+			Categories:  []string{task.Project},
+			Stamp:       updatedAt,
+		})
+	}
+
+	return calendar.Marshal()
+}
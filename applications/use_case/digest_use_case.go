@@ -0,0 +1,142 @@
+package use_case
+
+import (
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"log"
+	"time"
+)
+
+// DigestUseCase handles the business logic for the email digests.
+type DigestUseCase struct {
+	digestRepository repository.DigestRepository
+	mailer           mailer.Mailer
+	mailRenderer     mailer.MailRenderer
+	validator        validation.ValidateDigest
+	config           *commons.Config
+}
+
+func NewDigestUseCase(
+	digestRepository repository.DigestRepository,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	validator validation.ValidateDigest,
+	config *commons.Config,
+) *DigestUseCase {
+	return &DigestUseCase{
+		digestRepository: digestRepository,
+		mailer:           mailer,
+		mailRenderer:     mailRenderer,
+		validator:        validator,
+		config:           config,
+	}
+}
+
+// ExecuteSubscribe opts the user in to the digest, or changes the existing subscription.
+func (uc *DigestUseCase) ExecuteSubscribe(userId string, payload *entity.DigestSubscriptionPayload) {
+	uc.validator.ValidateSubscriptionPayload(payload)
+	uc.digestRepository.UpsertSubscription(userId, payload)
+}
+
+// ExecuteGetSubscription retrieves the digest subscription of the user.
+func (uc *DigestUseCase) ExecuteGetSubscription(userId string) *entity.DigestSubscription {
+	return uc.digestRepository.GetSubscriptionByUser(userId)
+}
+
+// ExecuteUnsubscribe opts the user out of the digest.
+func (uc *DigestUseCase) ExecuteUnsubscribe(userId string) {
+	uc.digestRepository.DeleteSubscription(userId)
+}
+
+// ExecuteSendDueDigests sends the digest of every subscriber whose local send hour has passed in the current period.
+// Every period is claimed before sending, so calling this repeatedly never sends the same digest twice.
+// Returning the number of sent digests.
+func (uc *DigestUseCase) ExecuteSendDueDigests(now time.Time) int {
+	sent := 0
+
+	for _, subscription := range uc.digestRepository.GetSubscriptions() {
+		if uc.sendDigest(&subscription, now) {
+			sent++
+		}
+	}
+
+	return sent
+}
+
+// sendDigest sends a single digest, a failure is logged so other subscribers still get theirs.
+func (uc *DigestUseCase) sendDigest(subscription *entity.DigestSubscription, now time.Time) (sent bool) {
+	location, err := time.LoadLocation(subscription.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+
+	local := now.In(location)
+	if local.Hour() < subscription.SendHour {
+		return false
+	}
+
+	periodKey, periodLabel := digestPeriod(subscription.Frequency, local)
+	if !uc.digestRepository.ClaimDigestPeriod(subscription.UserId, periodKey) {
+		return false
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			// Release the period, so it's retried on the next run
+			uc.digestRepository.ReleaseDigestPeriod(subscription.UserId, periodKey)
+			log.Printf("send_digest_err: user %s: %v", subscription.UserId, r)
+			sent = false
+		}
+	}()
+
+	digest := &entity.Digest{
+		Username:    subscription.Username,
+		Frequency:   subscription.Frequency,
+		PeriodLabel: periodLabel,
+	}
+
+	// Classify the tasks based on the user's local date
+	today := local.Format(time.DateOnly)
+	dueSoonUntil := local.AddDate(0, 0, uc.config.DigestDueSoonDays).Format(time.DateOnly)
+
+	for _, task := range uc.digestRepository.GetOpenAssignedTasks(subscription.UserId) {
+		switch {
+		case task.DueDate != "" && task.DueDate < today:
+			digest.Overdue = append(digest.Overdue, task)
+		case task.DueDate != "" && task.DueDate <= dueSoonUntil:
+			digest.DueSoon = append(digest.DueSoon, task)
+		default:
+			digest.Assigned = append(digest.Assigned, task)
+		}
+	}
+
+	// Nothing to tell, the period is still considered done
+	if len(digest.Overdue)+len(digest.DueSoon)+len(digest.Assigned) == 0 {
+		return false
+	}
+
+	html, text := uc.mailRenderer.RenderDigest(digest)
+	uc.mailer.Send(&entity.MailMessage{
+		To:      subscription.Email,
+		Subject: fmt.Sprintf("Your TaskPixie digest: %d overdue, %d due soon", len(digest.Overdue), len(digest.DueSoon)),
+		HTML:    html,
+		Text:    text,
+	})
+
+	return true
+}
+
+// digestPeriod returns the key identifying the period of the local time, and its human friendly label.
+func digestPeriod(frequency string, local time.Time) (string, string) {
+	if frequency == entity.DigestWeekly {
+		year, week := local.ISOWeek()
+
+		return fmt.Sprintf("weekly:%d-W%02d", year, week), fmt.Sprintf("week %d, %d", week, year)
+	}
+
+	return "daily:" + local.Format(time.DateOnly), local.Format("Monday, 2 January 2006")
+}
@@ -0,0 +1,198 @@
+package use_case
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/applications/security"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// InvitationUseCase handles the business logic for inviting people to projects.
+type InvitationUseCase struct {
+	invitationRepository repository.InvitationRepository
+	projectRepository    repository.ProjectRepository
+	userRepository       repository.UserRepository
+	inviteToken          security.InviteToken
+	mailer               mailer.Mailer
+	mailRenderer         mailer.MailRenderer
+	validator            validation.ValidateInvitation
+	config               *commons.Config
+}
+
+func NewInvitationUseCase(
+	invitationRepository repository.InvitationRepository,
+	projectRepository repository.ProjectRepository,
+	userRepository repository.UserRepository,
+	inviteToken security.InviteToken,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	validator validation.ValidateInvitation,
+	config *commons.Config,
+) *InvitationUseCase {
+	return &InvitationUseCase{
+		invitationRepository: invitationRepository,
+		projectRepository:    projectRepository,
+		userRepository:       userRepository,
+		inviteToken:          inviteToken,
+		mailer:               mailer,
+		mailRenderer:         mailRenderer,
+		validator:            validator,
+		config:               config,
+	}
+}
+
+// ExecuteInvite invites a user by username or email, owner and admins are allowed but only the owner can invite admins.
+// People who aren't registered yet can be invited by email, they answer with the emailed link after signing up.
+// Returning the invitation ID, the signed link is emailed to the invitee.
+func (uc *InvitationUseCase) ExecuteInvite(projectId string, payload *entity.InvitationPayload, userId string) string {
+	role := requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, projectId)
+
+	if payload.Role == "" {
+		payload.Role = entity.ProjectRoleMember
+	}
+	uc.validator.ValidatePayload(payload)
+
+	// Same rule as adding members directly
+	if payload.Role == entity.ProjectRoleAdmin && role != entity.ProjectRoleOwner {
+		panic(fiber.NewError(fiber.StatusForbidden, "Only the project owner can invite admins!"))
+	}
+
+	email, inviteeId := strings.ToLower(payload.Invitee), ""
+	invitee := uc.userRepository.FindUserByIdentity(payload.Invitee)
+	switch {
+	case invitee != nil:
+		if uc.projectRepository.GetMemberRole(projectId, invitee.Id) != "" {
+			panic(fiber.NewError(fiber.StatusConflict, "User is already part of the project!"))
+		}
+		email, inviteeId = strings.ToLower(invitee.Email), invitee.Id
+	case !strings.Contains(payload.Invitee, "@"):
+		panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+	}
+
+	expiresAt := time.Now().Add(uc.config.InvitationTTL)
+	invitationId := uc.invitationRepository.AddInvitation(
+		projectId,
+		email,
+		inviteeId,
+		payload.Role,
+		userId,
+		uc.config.InvitationTTL,
+	)
+
+	// Registered users who turned the emails off still find it among their invitations
+	if invitee == nil || invitee.Notifications.EmailInvitations {
+		uc.sendInvitation(invitationId, email, invitee != nil, payload.Role, projectId, userId, expiresAt)
+	}
+
+	return invitationId
+}
+
+// ExecuteGetProjectInvitations retrieves the pending invitations of a project.
+func (uc *InvitationUseCase) ExecuteGetProjectInvitations(projectId string, userId string) []entity.Invitation {
+	requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+
+	return uc.invitationRepository.GetPendingInvitationsByProject(projectId)
+}
+
+// ExecuteRevokeInvitation cancels a pending invitation, only project owner and admins are allowed.
+func (uc *InvitationUseCase) ExecuteRevokeInvitation(id string, userId string) {
+	invitation := uc.invitationRepository.GetInvitationById(id)
+	requireProjectRole(uc.projectRepository, invitation.ProjectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+
+	uc.invitationRepository.UpdateInvitationStatus(id, entity.InvitationRevoked)
+}
+
+// ExecuteGetMyInvitations retrieves the pending invitations sent to the user's account.
+func (uc *InvitationUseCase) ExecuteGetMyInvitations(userId string) []entity.Invitation {
+	return uc.invitationRepository.GetPendingInvitationsByInvitee(userId)
+}
+
+// ExecuteRespondInvitation accepts or declines an invitation sent to the user's account.
+// Invitations sent to an email before registering are only answered with their token,
+// the email of an account isn't verified so it doesn't prove the invitation is theirs.
+func (uc *InvitationUseCase) ExecuteRespondInvitation(id string, userId string, accept bool) {
+	invitation := uc.invitationRepository.GetInvitationById(id)
+
+	// Only the invitee may see it exists
+	if invitation.UserId != userId {
+		panic(fiber.NewError(fiber.StatusNotFound, "Invitation not found!"))
+	}
+
+	uc.respondInvitation(invitation, userId, accept)
+}
+
+// ExecuteRespondInvitationByToken answers the invitation carried by the emailed token.
+// Invitations sent to an email before registering are answered by whoever holds the token.
+func (uc *InvitationUseCase) ExecuteRespondInvitationByToken(token string, userId string, accept bool) {
+	invitation := uc.invitationRepository.GetInvitationById(uc.inviteToken.ValidateInviteToken(token))
+
+	if invitation.UserId != "" && invitation.UserId != userId {
+		panic(fiber.NewError(fiber.StatusNotFound, "Invitation not found!"))
+	}
+
+	uc.respondInvitation(invitation, userId, accept)
+}
+
+// respondInvitation accepts or declines the pending invitation,
+// accepting adds the user to the project with the invited role.
+func (uc *InvitationUseCase) respondInvitation(invitation *entity.Invitation, userId string, accept bool) {
+	if invitation.Status != entity.InvitationPending {
+		panic(fiber.NewError(fiber.StatusConflict, "Invitation is no longer pending!"))
+	}
+	if invitation.Expired {
+		panic(fiber.NewError(fiber.StatusGone, "Invitation has expired!"))
+	}
+
+	if accept {
+		uc.invitationRepository.AcceptInvitation(invitation.Id, userId)
+		return
+	}
+
+	uc.invitationRepository.UpdateInvitationStatus(invitation.Id, entity.InvitationDeclined)
+}
+
+// sendInvitation emails the signed link to the invitee.
+// The invitation is revoked if it couldn't be sent, so it can be sent again.
+func (uc *InvitationUseCase) sendInvitation(
+	invitationId string,
+	email string,
+	registered bool,
+	role string,
+	projectId string,
+	inviterId string,
+	expiresAt time.Time,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			uc.invitationRepository.UpdateInvitationStatus(invitationId, entity.InvitationRevoked)
+			panic(r)
+		}
+	}()
+
+	project := uc.projectRepository.GetProjectById(projectId)
+	inviter := uc.userRepository.GetUserById(inviterId)
+	token := uc.inviteToken.CreateInviteToken(invitationId, expiresAt)
+
+	html, text := uc.mailRenderer.RenderInvitation(&entity.InvitationMail{
+		Project:    project.Title,
+		InvitedBy:  inviter.Username,
+		Role:       role,
+		Link:       uc.config.ClientOrigin + "/invitations?token=" + url.QueryEscape(token),
+		Registered: registered,
+		ExpiresAt:  expiresAt.UTC().Format("Monday, 2 January 2006 15:04 MST"),
+	})
+	uc.mailer.Send(&entity.MailMessage{
+		To:      email,
+		Subject: fmt.Sprintf("%s invited you to %s on TaskPixie", inviter.Username, project.Title),
+		HTML:    html,
+		Text:    text,
+	})
+}
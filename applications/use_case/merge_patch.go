@@ -0,0 +1,28 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/merge_patch"
+)
+
+// applyMergePatch merges the patch into the document and decodes it into target.
+// Should raise panic (400) if the patch is invalid, returning the fields supplied by the patch otherwise.
+func applyMergePatch(document interface{}, patch []byte, target interface{}) []string {
+	fields, err := merge_patch.Apply(document, patch, target)
+	if err != nil {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Invalid patch: "+err.Error()))
+	}
+
+	return fields
+}
+
+// patchContains tells whether the field was supplied by the patch.
+func patchContains(fields []string, field string) bool {
+	for _, supplied := range fields {
+		if supplied == field {
+			return true
+		}
+	}
+
+	return false
+}
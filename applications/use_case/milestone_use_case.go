@@ -0,0 +1,180 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"time"
+)
+
+// MilestoneUseCase handles the business logic for the milestones (sprints) of projects.
+type MilestoneUseCase struct {
+	milestoneRepository repository.MilestoneRepository
+	projectRepository   repository.ProjectRepository
+	validator           validation.ValidateMilestone
+	cache               cache.Cache
+}
+
+func NewMilestoneUseCase(
+	milestoneRepository repository.MilestoneRepository,
+	projectRepository repository.ProjectRepository,
+	validator validation.ValidateMilestone,
+	cache cache.Cache,
+) *MilestoneUseCase {
+	return &MilestoneUseCase{
+		milestoneRepository: milestoneRepository,
+		projectRepository:   projectRepository,
+		validator:           validator,
+		cache:               cache,
+	}
+}
+
+// ExecuteAddMilestone plans a new milestone in the project, only the owner and admins are allowed.
+func (uc *MilestoneUseCase) ExecuteAddMilestone(projectId string, payload *entity.MilestonePayload, userId string) string {
+	uc.validator.ValidatePayload(payload)
+	requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, projectId)
+
+	return uc.milestoneRepository.AddMilestone(projectId, payload)
+}
+
+// ExecuteGetMilestonesByProject retrieves the milestones of a project, only people of the project are allowed.
+func (uc *MilestoneUseCase) ExecuteGetMilestonesByProject(projectId string, userId string) []entity.Milestone {
+	requireProjectAccess(uc.projectRepository, projectId, userId)
+
+	return uc.milestoneRepository.GetMilestonesByProject(projectId)
+}
+
+// ExecuteGetMilestoneById retrieves a milestone by its ID, only people of its project are allowed.
+func (uc *MilestoneUseCase) ExecuteGetMilestoneById(id string, userId string) *entity.Milestone {
+	milestone := uc.milestoneRepository.GetMilestoneById(id)
+	requireProjectAccess(uc.projectRepository, milestone.ProjectId, userId)
+
+	return milestone
+}
+
+// ExecuteUpdateMilestoneById updates a milestone that is not closed yet, only the owner and admins are allowed.
+func (uc *MilestoneUseCase) ExecuteUpdateMilestoneById(id string, payload *entity.MilestonePayload, userId string) {
+	uc.validator.ValidatePayload(payload)
+
+	milestone := uc.requireMilestoneManager(id, userId)
+	if milestone.State == entity.MilestoneStateClosed {
+		panic(fiber.NewError(fiber.StatusConflict, "Closed milestones can't be changed!"))
+	}
+
+	uc.milestoneRepository.UpdateMilestoneById(id, payload)
+}
+
+// ExecuteDeleteMilestoneById deletes a milestone, its tasks are left without milestone.
+// Only the owner and admins are allowed.
+func (uc *MilestoneUseCase) ExecuteDeleteMilestoneById(id string, userId string) {
+	milestone := uc.requireMilestoneManager(id, userId)
+
+	uc.milestoneRepository.DeleteMilestoneById(id)
+	invalidateProjectStats(uc.cache, milestone.ProjectId)
+}
+
+// ExecuteStartMilestone starts a planned milestone, its tasks at that moment are the committed work.
+// Only the owner and admins are allowed.
+func (uc *MilestoneUseCase) ExecuteStartMilestone(id string, userId string) {
+	uc.requireMilestoneManager(id, userId)
+
+	uc.milestoneRepository.StartMilestone(id)
+}
+
+// ExecuteCloseMilestone closes the active milestone, carrying its unfinished tasks to the next milestone.
+// The next milestone is the earliest planned one of the project unless one is given, none leaves them without milestone.
+// Only the owner and admins are allowed, returns the next milestone and the number of carried tasks.
+func (uc *MilestoneUseCase) ExecuteCloseMilestone(id string, payload *entity.CloseMilestonePayload, userId string) (string, int) {
+	uc.validator.ValidateClosePayload(payload)
+	milestone := uc.requireMilestoneManager(id, userId)
+
+	nextId := payload.NextMilestoneId
+	if nextId == "" {
+		nextId = uc.milestoneRepository.GetNextMilestone(id)
+	} else {
+		if nextId == id {
+			panic(fiber.NewError(fiber.StatusBadRequest, "Tasks can't be carried to the milestone being closed!"))
+		}
+		requireOpenMilestone(uc.milestoneRepository, milestone.ProjectId, nextId)
+	}
+
+	carried := uc.milestoneRepository.CloseMilestone(id, nextId)
+	invalidateProjectStats(uc.cache, milestone.ProjectId)
+
+	return nextId, carried
+}
+
+// ExecuteGetSprintReport compares the work committed when the milestone started with the work completed by its end,
+// or by now while it's still active. Only people of the project are allowed.
+func (uc *MilestoneUseCase) ExecuteGetSprintReport(id string, userId string) *entity.SprintReport {
+	milestone := uc.ExecuteGetMilestoneById(id, userId)
+	if milestone.State == entity.MilestoneStatePlanned {
+		panic(fiber.NewError(fiber.StatusConflict, "Milestone hasn't started yet!"))
+	}
+
+	end := milestone.ClosedAt
+	if end == "" {
+		end = time.Now().UTC().Format(time.RFC3339Nano)
+	}
+
+	report := &entity.SprintReport{
+		Milestone:    *milestone,
+		ScopeChanges: uc.milestoneRepository.GetScopeChanges(id, milestone.ActivatedAt, end),
+		Tasks:        uc.milestoneRepository.GetMilestoneTasksAt(id, end),
+	}
+
+	for _, task := range uc.milestoneRepository.GetMilestoneTasksAt(id, milestone.ActivatedAt) {
+		report.Committed.Tasks++
+		report.Committed.Estimate += task.Estimate
+	}
+
+	for _, task := range report.Tasks {
+		switch task.Status {
+		case entity.TaskStatusCompleted:
+			report.Completed.Tasks++
+			report.Completed.Estimate += task.Estimate
+		case entity.TaskStatusCanceled:
+		default:
+			report.Remaining.Tasks++
+			report.Remaining.Estimate += task.Estimate
+		}
+	}
+
+	for _, change := range report.ScopeChanges {
+		if change.Change == entity.ScopeChangeAdded {
+			report.Added++
+		} else {
+			report.Removed++
+		}
+	}
+
+	return report
+}
+
+// requireMilestoneManager makes sure the user is the owner or an admin of the milestone's writable project.
+func (uc *MilestoneUseCase) requireMilestoneManager(id string, userId string) *entity.Milestone {
+	milestone := uc.milestoneRepository.GetMilestoneById(id)
+	requireProjectRole(uc.projectRepository, milestone.ProjectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, milestone.ProjectId)
+
+	return milestone
+}
+
+// requireOpenMilestone makes sure tasks of the project can be put in the milestone, an empty milestoneId is always fine.
+// Should raise panic (400) if the milestone belongs to another project, or (409) if it's closed.
+func requireOpenMilestone(milestoneRepository repository.MilestoneRepository, projectId string, milestoneId string) {
+	if milestoneId == "" {
+		return
+	}
+
+	milestone := milestoneRepository.GetMilestoneById(milestoneId)
+	if milestone.ProjectId != projectId {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Milestone is not part of the task's project!"))
+	}
+	if milestone.State == entity.MilestoneStateClosed {
+		panic(fiber.NewError(fiber.StatusConflict, "Milestone is closed!"))
+	}
+}
@@ -0,0 +1,29 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// requireOrganizationRole makes sure the user holds one of the roles inside the organization.
+// Should raise panic (404) if the user is not a member, so outsiders can't tell the organization exists,
+// or (403) if the role is not allowed, returning the user's role otherwise.
+func requireOrganizationRole(
+	organizationRepository repository.OrganizationRepository,
+	organizationId string,
+	userId string,
+	roles ...string,
+) string {
+	role := organizationRepository.GetOrganizationRole(organizationId, userId)
+	if role == "" {
+		panic(fiber.NewError(fiber.StatusNotFound, "Organization not found!"))
+	}
+
+	for _, allowed := range roles {
+		if role == allowed {
+			return role
+		}
+	}
+
+	panic(fiber.NewError(fiber.StatusForbidden, "You don't have permission to do this in the organization!"))
+}
@@ -0,0 +1,160 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// OrganizationUseCase handles the business logic for organizations, the tenants owning projects.
+type OrganizationUseCase struct {
+	organizationRepository repository.OrganizationRepository
+	projectRepository      repository.ProjectRepository
+	validator              validation.ValidateOrganization
+}
+
+func NewOrganizationUseCase(
+	organizationRepository repository.OrganizationRepository,
+	projectRepository repository.ProjectRepository,
+	validator validation.ValidateOrganization,
+) *OrganizationUseCase {
+	return &OrganizationUseCase{
+		organizationRepository: organizationRepository,
+		projectRepository:      projectRepository,
+		validator:              validator,
+	}
+}
+
+// ExecuteAddOrganization creates an organization owned by the user.
+func (uc *OrganizationUseCase) ExecuteAddOrganization(payload *entity.OrganizationPayload, userId string) string {
+	uc.validator.ValidatePayload(payload)
+
+	return uc.organizationRepository.AddOrganization(payload, userId)
+}
+
+// ExecuteGetOrganizations retrieves the organizations of the user, starting with the personal workspace.
+func (uc *OrganizationUseCase) ExecuteGetOrganizations(userId string) []entity.Organization {
+	// Users registered after the migration get their workspace on first use
+	uc.organizationRepository.EnsurePersonalOrganization(userId)
+
+	return uc.organizationRepository.GetOrganizationsByUser(userId)
+}
+
+// ExecuteGetOrganizationById retrieves an organization the user is a member of.
+func (uc *OrganizationUseCase) ExecuteGetOrganizationById(id string, userId string) *entity.Organization {
+	return uc.organizationRepository.GetOrganizationById(id, userId)
+}
+
+// ExecuteUpdateOrganizationById renames an organization, owner and admins are allowed.
+func (uc *OrganizationUseCase) ExecuteUpdateOrganizationById(id string, payload *entity.OrganizationPayload, userId string) {
+	uc.validator.ValidatePayload(payload)
+	requireOrganizationRole(uc.organizationRepository, id, userId, entity.OrganizationRoleOwner, entity.OrganizationRoleAdmin)
+
+	uc.organizationRepository.UpdateOrganizationById(id, payload)
+}
+
+// ExecuteDeleteOrganizationById deletes an organization, only the owner is allowed.
+// Personal workspaces can't be deleted, and the projects of the organization must be purged first.
+func (uc *OrganizationUseCase) ExecuteDeleteOrganizationById(id string, userId string) {
+	requireOrganizationRole(uc.organizationRepository, id, userId, entity.OrganizationRoleOwner)
+
+	if uc.organizationRepository.GetOrganizationById(id, userId).IsPersonal {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Personal workspace can't be deleted!"))
+	}
+	if uc.organizationRepository.CountProjects(id) > 0 {
+		panic(fiber.NewError(fiber.StatusConflict, "Organization still has projects, delete them first!"))
+	}
+
+	uc.organizationRepository.DeleteOrganizationById(id)
+}
+
+// ExecuteGetOrganizationMembers retrieves the member directory, only members are allowed.
+func (uc *OrganizationUseCase) ExecuteGetOrganizationMembers(id string, userId string) []entity.OrganizationMember {
+	requireOrganizationRole(
+		uc.organizationRepository,
+		id,
+		userId,
+		entity.OrganizationRoleOwner,
+		entity.OrganizationRoleAdmin,
+		entity.OrganizationRoleMember,
+	)
+
+	return uc.organizationRepository.GetOrganizationMembers(id)
+}
+
+// ExecuteGetOrganizationProjects retrieves the projects of the organization the user can access.
+// Owners and admins see every project of the organization.
+func (uc *OrganizationUseCase) ExecuteGetOrganizationProjects(id string, userId string) []entity.PreviewProject {
+	requireOrganizationRole(
+		uc.organizationRepository,
+		id,
+		userId,
+		entity.OrganizationRoleOwner,
+		entity.OrganizationRoleAdmin,
+		entity.OrganizationRoleMember,
+	)
+
+	return uc.projectRepository.GetAccessibleProjects(userId, id)
+}
+
+// ExecuteAddOrganizationMember adds a user to the organization, owner and admins are allowed but only the owner can add admins.
+func (uc *OrganizationUseCase) ExecuteAddOrganizationMember(id string, payload *entity.OrganizationMemberPayload, userId string) {
+	if payload.Role == "" {
+		payload.Role = entity.OrganizationRoleMember
+	}
+	uc.validator.ValidateMemberPayload(payload)
+
+	role := requireOrganizationRole(uc.organizationRepository, id, userId, entity.OrganizationRoleOwner, entity.OrganizationRoleAdmin)
+	if payload.Role == entity.OrganizationRoleAdmin && role != entity.OrganizationRoleOwner {
+		panic(fiber.NewError(fiber.StatusForbidden, "Only the organization owner can add admins!"))
+	}
+
+	uc.organizationRepository.AddOrganizationMember(id, payload.UserId, payload.Role)
+}
+
+// ExecuteUpdateOrganizationMemberRole changes the role of a member, only the owner is allowed.
+func (uc *OrganizationUseCase) ExecuteUpdateOrganizationMemberRole(id string, payload *entity.OrganizationMemberPayload, userId string) {
+	uc.validator.ValidateMemberPayload(payload)
+	requireOrganizationRole(uc.organizationRepository, id, userId, entity.OrganizationRoleOwner)
+
+	if uc.organizationRepository.GetOrganizationRole(id, payload.UserId) == entity.OrganizationRoleOwner {
+		panic(fiber.NewError(fiber.StatusBadRequest, "The owner's role can't be changed!"))
+	}
+
+	uc.organizationRepository.UpdateOrganizationMemberRole(id, payload.UserId, payload.Role)
+}
+
+// ExecuteRemoveOrganizationMember removes a member from the organization, along with the member's access to its projects.
+// The owner can remove anyone, admins can only remove members.
+func (uc *OrganizationUseCase) ExecuteRemoveOrganizationMember(id string, memberId string, userId string) {
+	role := requireOrganizationRole(uc.organizationRepository, id, userId, entity.OrganizationRoleOwner, entity.OrganizationRoleAdmin)
+
+	switch uc.organizationRepository.GetOrganizationRole(id, memberId) {
+	case entity.OrganizationRoleOwner:
+		panic(fiber.NewError(fiber.StatusBadRequest, "The owner can't be removed from the organization!"))
+	case entity.OrganizationRoleAdmin:
+		if role != entity.OrganizationRoleOwner && memberId != userId {
+			panic(fiber.NewError(fiber.StatusForbidden, "Only the organization owner can remove admins!"))
+		}
+	}
+
+	uc.organizationRepository.RemoveOrganizationMember(id, memberId)
+}
+
+// ExecuteLeaveOrganization removes the user from the organization, the owner can't leave.
+func (uc *OrganizationUseCase) ExecuteLeaveOrganization(id string, userId string) {
+	role := requireOrganizationRole(
+		uc.organizationRepository,
+		id,
+		userId,
+		entity.OrganizationRoleOwner,
+		entity.OrganizationRoleAdmin,
+		entity.OrganizationRoleMember,
+	)
+	if role == entity.OrganizationRoleOwner {
+		panic(fiber.NewError(fiber.StatusBadRequest, "The owner can't leave the organization!"))
+	}
+
+	uc.organizationRepository.RemoveOrganizationMember(id, userId)
+}
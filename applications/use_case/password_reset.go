@@ -0,0 +1,32 @@
+package use_case
+
+import (
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"net/url"
+	"time"
+)
+
+// mailPasswordReset emails the user the link to choose a new password with the token, valid for PasswordResetTTL.
+func mailPasswordReset(
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	config *commons.Config,
+	user *entity.User,
+	token string,
+) {
+	expiresAt := time.Now().Add(config.PasswordResetTTL)
+
+	html, text := mailRenderer.RenderPasswordReset(&entity.PasswordResetMail{
+		Username:  user.Username,
+		Link:      config.ClientOrigin + "/password-reset?token=" + url.QueryEscape(token),
+		ExpiresAt: expiresAt.UTC().Format("Monday, 2 January 2006 15:04 MST"),
+	})
+	mailer.Send(&entity.MailMessage{
+		To:      user.Email,
+		Subject: "Reset your TaskPixie password",
+		HTML:    html,
+		Text:    text,
+	})
+}
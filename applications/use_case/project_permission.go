@@ -0,0 +1,70 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"slices"
+)
+
+// requireProjectRole makes sure the user holds one of the roles inside the project.
+// Should raise panic (403) if it's not, returning the user's role otherwise.
+func requireProjectRole(
+	projectRepository repository.ProjectRepository,
+	projectId string,
+	userId string,
+	roles ...string,
+) string {
+	role := projectRepository.GetMemberRole(projectId, userId)
+
+	for _, allowed := range roles {
+		if role == allowed {
+			return role
+		}
+	}
+
+	panic(fiber.NewError(fiber.StatusForbidden, "You don't have permission to do this in the project!"))
+}
+
+// requireProjectWritable makes sure the project is not archived, archived projects are read-only.
+// Should raise panic (403) if it is, an empty projectId is always writable.
+func requireProjectWritable(projectRepository repository.ProjectRepository, projectId string) {
+	if projectId == "" {
+		return
+	}
+
+	if projectRepository.GetProjectById(projectId).ArchivedAt != "" {
+		panic(fiber.NewError(fiber.StatusForbidden, "Project is archived, unarchive it to make changes!"))
+	}
+}
+
+// requireProjectAccess makes sure the user takes part in the project, whatever the role.
+// Should raise panic (403) if it's not, an empty projectId isn't part of any project so it's always accessible.
+func requireProjectAccess(projectRepository repository.ProjectRepository, projectId string, userId string) {
+	if projectId == "" {
+		return
+	}
+
+	requireProjectRole(
+		projectRepository,
+		projectId,
+		userId,
+		entity.ProjectRoleOwner,
+		entity.ProjectRoleAdmin,
+		entity.ProjectRoleMember,
+	)
+}
+
+// requireTaskAccess makes sure the user may see and change the task.
+// Tasks of a project are accessible to the people of the project, the others only to their owner and assignees.
+// Should raise panic (403) if it's not.
+func requireTaskAccess(projectRepository repository.ProjectRepository, task *entity.Task, userId string) {
+	if task.ProjectId != "" {
+		requireProjectAccess(projectRepository, task.ProjectId, userId)
+		return
+	}
+
+	if task.OwnerId != userId && !slices.Contains(task.AssignedToIds, userId) {
+		panic(fiber.NewError(fiber.StatusForbidden, "You don't have permission to access this task!"))
+	}
+}
@@ -0,0 +1,160 @@
+package use_case
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
+
+const (
+	// projectStatsTTL bounds how stale the statistics get through writes that don't invalidate them
+	projectStatsTTL = 10 * time.Minute
+
+	// projectStatsDays is the default range of the series, projectStatsMaxDays the longest allowed
+	projectStatsDays    = 30
+	projectStatsMaxDays = 366
+)
+
+// ExecuteGetProjectStats retrieves the dashboard of a project, only people of the project are allowed.
+// Statistics are cached until a task of the project changes.
+func (uc *ProjectUseCase) ExecuteGetProjectStats(id string, filter *entity.ProjectStatsFilter, userId string) *entity.ProjectStats {
+	uc.validator.ValidateStatsFilter(filter)
+	requireProjectAccess(uc.projectRepository, id, userId)
+
+	today := time.Now().UTC()
+	from, to := statsRange(filter, today)
+
+	key := projectStatsKey(uc.cache, id, fmt.Sprintf("%s:%s:%s", today.Format(time.DateOnly), from, to))
+	if cached, ok := uc.cache.GetCache(key).(string); ok {
+		var stats entity.ProjectStats
+		if err := json.Unmarshal([]byte(cached), &stats); err == nil {
+			return &stats
+		}
+	}
+
+	stats := uc.computeProjectStats(id, today.Format(time.DateOnly), from, to)
+
+	statsJSON, err := json.Marshal(stats)
+	if err != nil {
+		panic(fmt.Errorf("project_use_case_error: marshal stats: %v", err))
+	}
+	uc.cache.SetCache(key, string(statsJSON), projectStatsTTL)
+
+	return stats
+}
+
+func (uc *ProjectUseCase) computeProjectStats(id string, today string, from string, to string) *entity.ProjectStats {
+	stats := &entity.ProjectStats{
+		ByStatus:   make(map[string]int),
+		ByPriority: make(map[string]int),
+		Workload:   uc.projectRepository.GetAssigneeWorkload(id, today),
+		From:       from,
+		To:         to,
+	}
+
+	for _, count := range uc.projectRepository.GetTaskCounts(id, today) {
+		stats.Total += count.Count
+		stats.Overdue += count.Overdue
+		stats.ByStatus[count.Status] += count.Count
+		stats.ByPriority[count.Priority] += count.Count
+	}
+
+	if notCanceled := stats.Total - stats.ByStatus[entity.TaskStatusCanceled]; notCanceled > 0 {
+		stats.CompletionRate = float64(stats.ByStatus[entity.TaskStatusCompleted]) / float64(notCanceled)
+	}
+
+	stats.CumulativeFlow, stats.Burndown = buildSeries(uc.projectRepository.GetDailyStatusCounts(id, from, to), from, to)
+
+	return stats
+}
+
+// statsRange returns the range of the series, defaulting to the last days up to today.
+// Should raise panic (400) if the range is reversed or too long.
+func statsRange(filter *entity.ProjectStatsFilter, today time.Time) (string, string) {
+	to := today
+	if filter.To != "" {
+		to, _ = time.Parse(time.DateOnly, filter.To)
+	}
+
+	from := to.AddDate(0, 0, -(projectStatsDays - 1))
+	if filter.From != "" {
+		from, _ = time.Parse(time.DateOnly, filter.From)
+	}
+
+	if from.After(to) {
+		panic(fiber.NewError(fiber.StatusBadRequest, "From must not be after to!"))
+	}
+	if to.Sub(from) >= projectStatsMaxDays*24*time.Hour {
+		panic(fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Range must not exceed %d days!", projectStatsMaxDays)))
+	}
+
+	return from.Format(time.DateOnly), to.Format(time.DateOnly)
+}
+
+// buildSeries turns the daily counts into the cumulative flow and the burndown, every day of the range included.
+// The ideal burndown goes from the tasks left on the first day down to none on the last one.
+func buildSeries(counts []entity.DailyStatusCount, from string, to string) ([]entity.CumulativeFlowPoint, []entity.BurndownPoint) {
+	byDate := make(map[string]map[string]int)
+	for _, count := range counts {
+		if byDate[count.Date] == nil {
+			byDate[count.Date] = make(map[string]int)
+		}
+		byDate[count.Date][count.Status] = count.Count
+	}
+
+	start, _ := time.Parse(time.DateOnly, from)
+	end, _ := time.Parse(time.DateOnly, to)
+	days := int(end.Sub(start).Hours()/24) + 1
+
+	flow := make([]entity.CumulativeFlowPoint, 0, days)
+	burndown := make([]entity.BurndownPoint, 0, days)
+	for i := 0; i < days; i++ {
+		date := start.AddDate(0, 0, i).Format(time.DateOnly)
+		byStatus := map[string]int{
+			entity.TaskStatusToDo:       byDate[date][entity.TaskStatusToDo],
+			entity.TaskStatusInProgress: byDate[date][entity.TaskStatusInProgress],
+			entity.TaskStatusCompleted:  byDate[date][entity.TaskStatusCompleted],
+			entity.TaskStatusCanceled:   byDate[date][entity.TaskStatusCanceled],
+		}
+
+		flow = append(flow, entity.CumulativeFlowPoint{Date: date, ByStatus: byStatus})
+		burndown = append(burndown, entity.BurndownPoint{
+			Date:      date,
+			Remaining: byStatus[entity.TaskStatusToDo] + byStatus[entity.TaskStatusInProgress],
+		})
+	}
+
+	if days > 1 {
+		for i := range burndown {
+			burndown[i].Ideal = float64(burndown[0].Remaining) * float64(days-1-i) / float64(days-1)
+		}
+	}
+
+	return flow, burndown
+}
+
+// projectStatsKey returns the cache key of the statistics of the project for the variant.
+// Keys include a generation of the project, dropped by invalidateProjectStats, so every variant is invalidated at once.
+func projectStatsKey(c cache.Cache, projectId string, variant string) string {
+	generationKey := "project_stats_generation:" + projectId
+
+	generation, ok := c.GetCache(generationKey).(string)
+	if !ok {
+		generation = fmt.Sprint(time.Now().UnixNano())
+		c.SetCache(generationKey, generation, projectStatsTTL)
+	}
+
+	return fmt.Sprintf("project_stats:%s:%s:%s", projectId, generation, variant)
+}
+
+// invalidateProjectStats drops the cached statistics of the projects, tasks outside of any project are ignored.
+func invalidateProjectStats(c cache.Cache, projectIds ...string) {
+	for _, projectId := range projectIds {
+		if projectId != "" {
+			c.DeleteCache("project_stats_generation:" + projectId)
+		}
+	}
+}
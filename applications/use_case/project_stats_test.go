@@ -0,0 +1,124 @@
+package use_case
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+func TestStatsRange(t *testing.T) {
+	today := time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Should resolve the range of the series", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			filter   entity.ProjectStatsFilter
+			from, to string
+		}{
+			{"default", entity.ProjectStatsFilter{}, "2024-02-15", "2024-03-15"},
+			{"only from", entity.ProjectStatsFilter{From: "2024-03-01"}, "2024-03-01", "2024-03-15"},
+			{"only to", entity.ProjectStatsFilter{To: "2024-01-31"}, "2024-01-02", "2024-01-31"},
+			{"one day", entity.ProjectStatsFilter{From: "2024-03-10", To: "2024-03-10"}, "2024-03-10", "2024-03-10"},
+			{"longest", entity.ProjectStatsFilter{From: "2023-01-01", To: "2024-01-01"}, "2023-01-01", "2024-01-01"},
+			{"leap year", entity.ProjectStatsFilter{From: "2024-01-01", To: "2024-12-31"}, "2024-01-01", "2024-12-31"},
+		}
+
+		for _, c := range cases {
+			from, to := statsRange(&c.filter, today)
+
+			assert.Equal(t, c.from, from, c.name)
+			assert.Equal(t, c.to, to, c.name)
+		}
+	})
+
+	t.Run("Should refuse reversed and too long ranges", func(t *testing.T) {
+		cases := []struct {
+			name    string
+			filter  entity.ProjectStatsFilter
+			message string
+		}{
+			{"reversed", entity.ProjectStatsFilter{From: "2024-03-11", To: "2024-03-10"}, "From must not be after to!"},
+			{"from after today", entity.ProjectStatsFilter{From: "2024-03-16"}, "From must not be after to!"},
+			{"367 days", entity.ProjectStatsFilter{From: "2023-01-01", To: "2024-01-02"}, "Range must not exceed 366 days!"},
+		}
+
+		for _, c := range cases {
+			assert.PanicsWithError(t, fiber.NewError(fiber.StatusBadRequest, c.message).Error(), func() {
+				statsRange(&c.filter, today)
+			}, c.name)
+		}
+	})
+}
+
+func TestBuildSeries(t *testing.T) {
+	t.Run("Should fill every day of the range and draw the ideal line", func(t *testing.T) {
+		// Arrange
+		counts := []entity.DailyStatusCount{
+			{Date: "2024-03-01", Status: entity.TaskStatusToDo, Count: 3},
+			{Date: "2024-03-01", Status: entity.TaskStatusInProgress, Count: 1},
+			{Date: "2024-03-03", Status: entity.TaskStatusInProgress, Count: 1},
+			{Date: "2024-03-03", Status: entity.TaskStatusCompleted, Count: 2},
+			{Date: "2024-03-03", Status: entity.TaskStatusCanceled, Count: 1},
+		}
+
+		// Action
+		flow, burndown := buildSeries(counts, "2024-03-01", "2024-03-03")
+
+		// Assert
+		assert.Equal(t, []entity.CumulativeFlowPoint{
+			{Date: "2024-03-01", ByStatus: map[string]int{
+				entity.TaskStatusToDo: 3, entity.TaskStatusInProgress: 1, entity.TaskStatusCompleted: 0, entity.TaskStatusCanceled: 0,
+			}},
+			{Date: "2024-03-02", ByStatus: map[string]int{
+				entity.TaskStatusToDo: 0, entity.TaskStatusInProgress: 0, entity.TaskStatusCompleted: 0, entity.TaskStatusCanceled: 0,
+			}},
+			{Date: "2024-03-03", ByStatus: map[string]int{
+				entity.TaskStatusToDo: 0, entity.TaskStatusInProgress: 1, entity.TaskStatusCompleted: 2, entity.TaskStatusCanceled: 1,
+			}},
+		}, flow)
+		assert.Equal(t, []entity.BurndownPoint{
+			{Date: "2024-03-01", Remaining: 4, Ideal: 4},
+			{Date: "2024-03-02", Remaining: 0, Ideal: 2},
+			{Date: "2024-03-03", Remaining: 1, Ideal: 0},
+		}, burndown)
+	})
+
+	t.Run("Should not draw the ideal line of a single day", func(t *testing.T) {
+		// Arrange
+		counts := []entity.DailyStatusCount{
+			{Date: "2024-03-10", Status: entity.TaskStatusToDo, Count: 5},
+		}
+
+		// Action
+		flow, burndown := buildSeries(counts, "2024-03-10", "2024-03-10")
+
+		// Assert
+		assert.Len(t, flow, 1)
+		assert.Equal(t, []entity.BurndownPoint{{Date: "2024-03-10", Remaining: 5, Ideal: 0}}, burndown)
+	})
+
+	t.Run("Should count the days across months and leap days", func(t *testing.T) {
+		cases := []struct {
+			from, to string
+			days     int
+			last     string
+		}{
+			{"2024-02-27", "2024-03-02", 5, "2024-03-02"},
+			{"2023-02-27", "2023-03-02", 4, "2023-03-02"},
+			{"2023-12-30", "2024-01-02", 4, "2024-01-02"},
+			{"2024-01-01", "2024-12-31", 366, "2024-12-31"},
+		}
+
+		for _, c := range cases {
+			flow, burndown := buildSeries(nil, c.from, c.to)
+
+			assert.Len(t, flow, c.days, c.from)
+			assert.Len(t, burndown, c.days, c.from)
+			assert.Equal(t, c.from, flow[0].Date, c.from)
+			assert.Equal(t, c.last, flow[c.days-1].Date, c.from)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 ﻿package use_case
 
 import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/event"
+	"github.com/wisle25/task-pixie/applications/markdown"
 	"github.com/wisle25/task-pixie/applications/validation"
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/domains/repository"
@@ -8,75 +12,301 @@ import (
 
 // ProjectUseCase handles the business logic for project operations.
 type ProjectUseCase struct {
-	projectRepository repository.ProjectRepository
-	validator         validation.ValidateProject
+	projectRepository      repository.ProjectRepository
+	organizationRepository repository.OrganizationRepository
+	validator              validation.ValidateProject
+	eventPublisher         event.EventPublisher
+	cache                  cache.Cache
+	markdownRenderer       markdown.MarkdownRenderer
 }
 
 func NewProjectUseCase(
 	projectRepository repository.ProjectRepository,
+	organizationRepository repository.OrganizationRepository,
 	validator validation.ValidateProject,
+	eventPublisher event.EventPublisher,
+	cache cache.Cache,
+	markdownRenderer markdown.MarkdownRenderer,
 ) *ProjectUseCase {
 	return &ProjectUseCase{
-		projectRepository: projectRepository,
-		validator:         validator,
+		projectRepository:      projectRepository,
+		organizationRepository: organizationRepository,
+		validator:              validator,
+		eventPublisher:         eventPublisher,
+		cache:                  cache,
+		markdownRenderer:       markdownRenderer,
 	}
 }
 
-// ExecuteAddProject handles the creation of a new project.
+// ExecuteAddProject handles the creation of a new project inside an organization of the owner.
+// Without an organization, the project goes to the owner's personal workspace.
 func (uc *ProjectUseCase) ExecuteAddProject(payload *entity.ProjectPayload, ownerId string) string {
 	uc.validator.ValidatePayload(payload)
+
+	if payload.OrganizationId == "" {
+		payload.OrganizationId = uc.organizationRepository.EnsurePersonalOrganization(ownerId)
+	} else {
+		requireOrganizationRole(
+			uc.organizationRepository,
+			payload.OrganizationId,
+			ownerId,
+			entity.OrganizationRoleOwner,
+			entity.OrganizationRoleAdmin,
+			entity.OrganizationRoleMember,
+		)
+	}
+
 	return uc.projectRepository.AddProject(payload, ownerId)
 }
 
-// ExecuteGetProjectById retrieves a project by its ID.
-func (uc *ProjectUseCase) ExecuteGetProjectById(id string) *entity.Project {
-	return uc.projectRepository.GetProjectById(id)
+// ExecuteGetProjectById retrieves a project by its ID, only people of the project are allowed.
+// The detail is rendered from Markdown, along with its task links and mentions.
+func (uc *ProjectUseCase) ExecuteGetProjectById(id string, userId string) *entity.Project {
+	requireProjectAccess(uc.projectRepository, id, userId)
+
+	project := uc.projectRepository.GetProjectById(id)
+	detail := uc.markdownRenderer.Render(project.Detail)
+	project.DetailHTML, project.TaskLinks, project.Mentions = detail.HTML, detail.TaskLinks, detail.Mentions
+
+	return project
 }
 
-func (uc *ProjectUseCase) ExecuteGetProjectMembers(id string) []entity.User {
+// ExecuteGetProjectMembers retrieves the owner and members of a project, only people of the project are allowed.
+func (uc *ProjectUseCase) ExecuteGetProjectMembers(id string, userId string) []entity.ProjectMember {
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin, entity.ProjectRoleMember)
+
 	return uc.projectRepository.GetProjectMembers(id)
 }
 
-// ExecuteUpdateProjectById updates a project by its ID.
-func (uc *ProjectUseCase) ExecuteUpdateProjectById(id string, payload *entity.ProjectPayload) {
+// ExecuteAddMember adds a user to the project, owner and admins are allowed but only the owner can add admins.
+func (uc *ProjectUseCase) ExecuteAddMember(id string, payload *entity.MemberPayload, userId string) {
+	if payload.Role == "" {
+		payload.Role = entity.ProjectRoleMember
+	}
+	uc.validator.ValidateMemberPayload(payload)
+
+	role := requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, id)
+	if payload.Role == entity.ProjectRoleAdmin && role != entity.ProjectRoleOwner {
+		panic(fiber.NewError(fiber.StatusForbidden, "Only the project owner can add admins!"))
+	}
+	if uc.projectRepository.GetMemberRole(id, payload.UserId) == entity.ProjectRoleOwner {
+		panic(fiber.NewError(fiber.StatusConflict, "User is already the owner of the project!"))
+	}
+
+	uc.projectRepository.AddMember(id, payload.UserId, payload.Role)
+}
+
+// ExecuteUpdateMemberRole changes the role of a member, only the owner is allowed.
+func (uc *ProjectUseCase) ExecuteUpdateMemberRole(id string, payload *entity.MemberPayload, userId string) {
+	uc.validator.ValidateMemberPayload(payload)
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner)
+	requireProjectWritable(uc.projectRepository, id)
+
+	uc.projectRepository.UpdateMemberRole(id, payload.UserId, payload.Role)
+}
+
+// ExecuteRemoveMember removes a member from the project.
+// The owner can remove anyone, admins can only remove members.
+// The removed user is unassigned from the project's tasks, and tasks owned by the user go to the project owner.
+func (uc *ProjectUseCase) ExecuteRemoveMember(id string, memberId string, userId string) {
+	role := requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, id)
+
+	switch uc.projectRepository.GetMemberRole(id, memberId) {
+	case entity.ProjectRoleOwner:
+		panic(fiber.NewError(fiber.StatusBadRequest, "The owner can't be removed, transfer the ownership first!"))
+	case entity.ProjectRoleAdmin:
+		if role != entity.ProjectRoleOwner && memberId != userId {
+			panic(fiber.NewError(fiber.StatusForbidden, "Only the project owner can remove admins!"))
+		}
+	}
+
+	uc.projectRepository.RemoveMember(id, memberId)
+}
+
+// ExecuteLeaveProject removes the user from the project, the same rules as ExecuteRemoveMember apply.
+// The owner has to transfer the ownership before leaving, archived projects can still be left.
+func (uc *ProjectUseCase) ExecuteLeaveProject(id string, userId string) {
+	role := requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin, entity.ProjectRoleMember)
+	if role == entity.ProjectRoleOwner {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Transfer the ownership before leaving the project!"))
+	}
+
+	uc.projectRepository.RemoveMember(id, userId)
+}
+
+// ExecuteTransferOwnership hands the project over to one of its members, only the owner is allowed.
+// The previous owner stays in the project as admin.
+func (uc *ProjectUseCase) ExecuteTransferOwnership(id string, payload *entity.TransferOwnershipPayload, userId string) {
+	uc.validator.ValidateTransferPayload(payload)
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner)
+	requireProjectWritable(uc.projectRepository, id)
+	if payload.UserId == userId {
+		panic(fiber.NewError(fiber.StatusBadRequest, "You already own the project!"))
+	}
+
+	uc.projectRepository.TransferOwnership(id, payload.UserId)
+}
+
+// ExecuteUpdateProjectById updates a project by its ID, only project owner and admins are allowed.
+// The project is only updated if it's still one of the versions (If-Match), if any. Returns its new version.
+func (uc *ProjectUseCase) ExecuteUpdateProjectById(id string, payload *entity.ProjectPayload, userId string, versions []int) int {
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, id)
 	uc.validator.ValidatePayload(payload)
-	uc.projectRepository.UpdateProjectById(id, payload)
+	uc.projectRepository.UpdateProjectById(id, payload, versions)
+
+	project := uc.projectRepository.GetProjectById(id)
+	uc.eventPublisher.Publish(id, entity.WebhookEventProjectUpdated, project)
+
+	return project.Version
+}
+
+// ExecutePatchProjectById applies a JSON Merge Patch (RFC 7396) to a project, only the supplied fields are changed and validated.
+// Only project owner and admins are allowed, the organization can't be changed this way.
+// The patch is only applied on the versions (If-Match) if any, returns the new version of the project.
+func (uc *ProjectUseCase) ExecutePatchProjectById(id string, patch []byte, userId string, versions []int) int {
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, id)
+
+	project := uc.projectRepository.GetProjectById(id)
+	current := entity.ProjectPayload{
+		Title:          project.Title,
+		Detail:         project.Detail,
+		Priority:       project.Priority,
+		Status:         project.Status,
+		OrganizationId: project.OrganizationId,
+	}
+
+	var payload entity.ProjectPayload
+	fields := applyMergePatch(&current, patch, &payload)
+	if payload.OrganizationId != project.OrganizationId {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Organization of a project can't be changed!"))
+	}
+	uc.validator.ValidatePatch(&payload, fields)
+
+	// The patch was applied on the version read above, so it mustn't be written over a newer one
+	if len(versions) == 0 {
+		versions = []int{project.Version}
+	}
+	uc.projectRepository.UpdateProjectById(id, &payload, versions)
+
+	project = uc.projectRepository.GetProjectById(id)
+	uc.eventPublisher.Publish(id, entity.WebhookEventProjectUpdated, project)
+
+	return project.Version
 }
 
-// ExecuteDeleteProjectById deletes a project by its ID.
-func (uc *ProjectUseCase) ExecuteDeleteProjectById(id string) {
-	uc.projectRepository.DeleteProjectById(id)
+// ExecuteDeleteProjectById moves a project to the trash by its ID, only the project owner is allowed.
+// The project is only deleted if it's still one of the versions (If-Match), if any.
+func (uc *ProjectUseCase) ExecuteDeleteProjectById(id string, userId string, versions []int) {
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner)
+	uc.projectRepository.DeleteProjectById(id, versions)
 }
 
-// ExecuteGetProjects retrieves projects by owner or members.
+// ExecuteArchiveProjectById makes a project read-only, only project owner and admins are allowed.
+func (uc *ProjectUseCase) ExecuteArchiveProjectById(id string, userId string) {
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	uc.projectRepository.SetProjectArchived(id, true)
+}
+
+// ExecuteUnarchiveProjectById makes an archived project writable again.
+func (uc *ProjectUseCase) ExecuteUnarchiveProjectById(id string, userId string) {
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	uc.projectRepository.SetProjectArchived(id, false)
+}
+
+// ExecuteGetProjects retrieves the projects the user can access across every organization of the user.
 func (uc *ProjectUseCase) ExecuteGetProjects(userId string) []entity.PreviewProject {
-	ownerProjects := uc.projectRepository.GetProjectsByOwner(userId)
-	memberProjects := uc.projectRepository.GetProjectsByMember(userId)
+	return uc.projectRepository.GetAccessibleProjects(userId, "")
+}
 
-	projectMap := make(map[string]entity.PreviewProject)
+// ExecuteCloneProject copies a project the user takes part in, along with the chosen parts.
+// The copy goes to the same organization unless another organization of the user is given.
+func (uc *ProjectUseCase) ExecuteCloneProject(id string, payload *entity.CloneProjectPayload, userId string) string {
+	uc.validator.ValidateClonePayload(payload)
+	requireProjectAccess(uc.projectRepository, id, userId)
 
-	// Add owner projects to map
-	for _, project := range ownerProjects {
-		projectMap[project.Id] = entity.PreviewProject{
-			Id:    project.Id,
-			Title: project.Title,
-		}
+	if payload.OrganizationId == "" {
+		payload.OrganizationId = uc.projectRepository.GetProjectById(id).OrganizationId
 	}
+	uc.requireOrganizationMember(payload.OrganizationId, userId)
 
-	// Add member projects to map if not already added
-	for _, project := range memberProjects {
-		if _, exists := projectMap[project.Id]; !exists {
-			projectMap[project.Id] = entity.PreviewProject{
-				Id:    project.Id,
-				Title: project.Title,
-			}
-		}
+	return uc.projectRepository.CloneProject(id, payload, userId, false)
+}
+
+// ExecuteSaveAsTemplate saves a project and its tasks as a template of its organization.
+// Only project owner and admins are allowed, members are never part of a template.
+func (uc *ProjectUseCase) ExecuteSaveAsTemplate(id string, payload *entity.ProjectTemplatePayload, userId string) string {
+	uc.validator.ValidateTemplatePayload(payload)
+	requireProjectRole(uc.projectRepository, id, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+
+	return uc.projectRepository.CloneProject(id, &entity.CloneProjectPayload{
+		Title:          payload.Title,
+		OrganizationId: uc.projectRepository.GetProjectById(id).OrganizationId,
+		IncludeTasks:   true,
+		ResetStatus:    true,
+	}, userId, true)
+}
+
+// ExecuteGetTemplates retrieves the templates of the organization, only its members are allowed.
+func (uc *ProjectUseCase) ExecuteGetTemplates(organizationId string, userId string) []entity.ProjectTemplate {
+	uc.requireOrganizationMember(organizationId, userId)
+
+	return uc.projectRepository.GetTemplatesByOrganization(organizationId)
+}
+
+// ExecuteGetTemplateById retrieves a template, only members of its organization are allowed.
+func (uc *ProjectUseCase) ExecuteGetTemplateById(id string, userId string) *entity.ProjectTemplate {
+	template := uc.projectRepository.GetTemplateById(id)
+	uc.requireOrganizationMember(template.OrganizationId, userId)
+
+	return template
+}
+
+// ExecuteInstantiateTemplate creates a project from a template, its tasks are always included.
+func (uc *ProjectUseCase) ExecuteInstantiateTemplate(id string, payload *entity.CloneProjectPayload, userId string) string {
+	uc.validator.ValidateClonePayload(payload)
+
+	template := uc.projectRepository.GetTemplateById(id)
+	uc.requireOrganizationMember(template.OrganizationId, userId)
+
+	if payload.OrganizationId == "" {
+		payload.OrganizationId = template.OrganizationId
 	}
+	uc.requireOrganizationMember(payload.OrganizationId, userId)
+
+	payload.IncludeTasks = true
+	payload.IncludeMembers = false
+
+	return uc.projectRepository.CloneProject(id, payload, userId, false)
+}
+
+// ExecuteDeleteTemplateById deletes a template, its creator and the organization owner and admins are allowed.
+func (uc *ProjectUseCase) ExecuteDeleteTemplateById(id string, userId string) {
+	template := uc.projectRepository.GetTemplateById(id)
 
-	var previewProjects []entity.PreviewProject
-	for _, project := range projectMap {
-		previewProjects = append(previewProjects, project)
+	if template.OwnerId != userId {
+		requireOrganizationRole(
+			uc.organizationRepository,
+			template.OrganizationId,
+			userId,
+			entity.OrganizationRoleOwner,
+			entity.OrganizationRoleAdmin,
+		)
 	}
 
-	return previewProjects
+	uc.projectRepository.DeleteTemplateById(id)
+}
+
+func (uc *ProjectUseCase) requireOrganizationMember(organizationId string, userId string) {
+	requireOrganizationRole(
+		uc.organizationRepository,
+		organizationId,
+		userId,
+		entity.OrganizationRoleOwner,
+		entity.OrganizationRoleAdmin,
+		entity.OrganizationRoleMember,
+	)
 }
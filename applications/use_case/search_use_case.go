@@ -0,0 +1,39 @@
+package use_case
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+)
+
+// Results per page when the limit isn't specified
+const defaultSearchLimit = 20
+
+// SearchUseCase handles the business logic for searching tasks and projects.
+type SearchUseCase struct {
+	searchRepository repository.SearchRepository
+	validator        validation.ValidateSearch
+}
+
+func NewSearchUseCase(
+	searchRepository repository.SearchRepository,
+	validator validation.ValidateSearch,
+) *SearchUseCase {
+	return &SearchUseCase{
+		searchRepository: searchRepository,
+		validator:        validator,
+	}
+}
+
+// ExecuteSearch runs a full-text search over the resources accessible by the user.
+func (uc *SearchUseCase) ExecuteSearch(userId string, payload *entity.SearchPayload) []entity.SearchResult {
+	payload.Query = strings.TrimSpace(payload.Query)
+	if payload.Limit == 0 {
+		payload.Limit = defaultSearchLimit
+	}
+
+	uc.validator.ValidatePayload(payload)
+
+	return uc.searchRepository.Search(userId, payload)
+}
@@ -0,0 +1,25 @@
+package use_case
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// generateSecretToken returns a random token, for secrets handed out once like links, feed URLs and signing keys.
+func generateSecretToken() string {
+	buffer := make([]byte, 32)
+	if _, err := rand.Read(buffer); err != nil {
+		panic(fmt.Errorf("generate_secret_token_err: %v", err))
+	}
+
+	return hex.EncodeToString(buffer)
+}
+
+// hashSecretToken returns the hash the token is stored as, a leaked database doesn't leak working tokens.
+func hashSecretToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
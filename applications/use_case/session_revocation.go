@@ -0,0 +1,39 @@
+package use_case
+
+import (
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/commons"
+	"strconv"
+	"time"
+)
+
+func sessionsRevokedAtKey(userId string) string {
+	return "sessions_revoked_at:" + userId
+}
+
+// revokeSessions signs the user out everywhere, the tokens issued until now are refused from then on.
+// The mark outlives the longest token, so it's never needed after it expires.
+func revokeSessions(cache cache.Cache, config *commons.Config, userId string) {
+	ttl := config.RefreshTokenExpiresIn
+	if config.AccessTokenExpiresIn > ttl {
+		ttl = config.AccessTokenExpiresIn
+	}
+
+	cache.SetCache(sessionsRevokedAtKey(userId), time.Now().Unix(), ttl)
+}
+
+// sessionRevoked tells whether the token issued at that Unix time was revoked along with the user's sessions.
+func sessionRevoked(cache cache.Cache, userId string, issuedAt int64) bool {
+	value := cache.GetCache(sessionsRevokedAtKey(userId))
+	if value == nil {
+		return false
+	}
+
+	revokedAt, err := strconv.ParseInt(fmt.Sprint(value), 10, 64)
+	if err != nil {
+		panic(fmt.Errorf("session_revoked_err: parse revocation time: %v", err))
+	}
+
+	return issuedAt <= revokedAt
+}
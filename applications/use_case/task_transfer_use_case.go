@@ -0,0 +1,231 @@
+package use_case
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/event"
+	"github.com/wisle25/task-pixie/applications/task_transfer"
+	"github.com/wisle25/task-pixie/applications/upload"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// What's accepted as a file to import, CSV and JSON both sniff as plain text
+var taskImportPolicy = upload.Policy{
+	MaxBytes:     10 << 20,
+	ContentTypes: []string{"text/plain"},
+}
+
+// TaskTransferUseCase handles the business logic for exporting the tasks of projects, and importing them back.
+type TaskTransferUseCase struct {
+	taskRepository    repository.TaskRepository
+	projectRepository repository.ProjectRepository
+	userRepository    repository.UserRepository
+	validator         validation.ValidateTask
+	uploadGuard       *UploadGuard
+	eventPublisher    event.EventPublisher
+	cache             cache.Cache
+}
+
+func NewTaskTransferUseCase(
+	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+	userRepository repository.UserRepository,
+	validator validation.ValidateTask,
+	uploadGuard *UploadGuard,
+	eventPublisher event.EventPublisher,
+	cache cache.Cache,
+) *TaskTransferUseCase {
+	return &TaskTransferUseCase{
+		taskRepository:    taskRepository,
+		projectRepository: projectRepository,
+		userRepository:    userRepository,
+		validator:         validator,
+		uploadGuard:       uploadGuard,
+		eventPublisher:    eventPublisher,
+		cache:             cache,
+	}
+}
+
+// ExecuteExportTasks makes sure the user takes part in the project, and returns the function writing its tasks to w.
+// Tasks are written as they're read from the database, so exports are streamed whatever their size.
+func (uc *TaskTransferUseCase) ExecuteExportTasks(projectId string, filter *entity.TaskExportFilter, userId string) func(w io.Writer) {
+	uc.validator.ValidateExportFilter(filter)
+	requireProjectAccess(uc.projectRepository, projectId, userId)
+
+	return func(w io.Writer) {
+		exporter := task_transfer.NewExporter(filter.Format, w)
+
+		uc.taskRepository.ExportTasksByProject(projectId, func(task *entity.ExportedTask) {
+			if err := exporter.Write(task); err != nil {
+				panic(fmt.Errorf("task_transfer_use_case_error: write task: %v", err))
+			}
+		})
+
+		if err := exporter.Close(); err != nil {
+			panic(fmt.Errorf("task_transfer_use_case_error: close export: %v", err))
+		}
+	}
+}
+
+// ExecuteImportTasks imports the tasks of a CSV or JSON file into the project, every row is validated like a new task.
+// Rows are only imported, all at once, when every one of them is valid and it's not a dry run.
+// The report tells which rows failed and why, or the IDs of the imported tasks.
+func (uc *TaskTransferUseCase) ExecuteImportTasks(projectId string, payload *entity.TaskImportPayload, userId string) *entity.TaskImportReport {
+	uc.validator.ValidateImportPayload(payload)
+	requireProjectAccess(uc.projectRepository, projectId, userId)
+	requireProjectWritable(uc.projectRepository, projectId)
+
+	rows := uc.readImportRows(payload, userId)
+	if len(rows) == 0 {
+		panic(fiber.NewError(fiber.StatusBadRequest, "The file has no tasks!"))
+	}
+
+	report := &entity.TaskImportReport{DryRun: payload.DryRun, Total: len(rows)}
+	payloads := make([]entity.TaskPayload, 0, len(rows))
+
+	// Assignees are looked up once per file
+	assignees := make(map[string]string)
+
+	for i, row := range rows {
+		taskPayload, errs := uc.checkImportRow(projectId, &row, assignees)
+		if len(errs) > 0 {
+			report.Failed++
+		}
+
+		payloads = append(payloads, *taskPayload)
+		report.Rows = append(report.Rows, entity.TaskImportRowResult{Row: i + 1, Title: row.Title, Errors: errs})
+	}
+
+	if report.Failed > 0 || payload.DryRun {
+		return report
+	}
+
+	ids := uc.taskRepository.ImportTasks(payloads, userId)
+	for i, id := range ids {
+		report.Rows[i].TaskId = id
+	}
+	report.Imported = true
+	invalidateProjectStats(uc.cache, projectId)
+
+	// Notify subscribers of the project, the same way single creations do
+	for _, id := range ids {
+		uc.eventPublisher.Publish(projectId, entity.WebhookEventTaskCreated, uc.taskRepository.GetTaskById(id))
+	}
+
+	return report
+}
+
+// readImportRows checks the uploaded file and reads its rows, should raise panic (400) if the file or the mapping is invalid.
+func (uc *TaskTransferUseCase) readImportRows(payload *entity.TaskImportPayload, userId string) []task_transfer.Row {
+	var mapping map[string]string
+	if payload.Mapping != "" {
+		if err := json.Unmarshal([]byte(payload.Mapping), &mapping); err != nil {
+			panic(fiber.NewError(fiber.StatusBadRequest, "Invalid mapping: it must map task fields to columns!"))
+		}
+	}
+
+	file := uc.uploadGuard.Check(payload.File, taskImportPolicy, userId)
+
+	var rows []task_transfer.Row
+	var err error
+	if payload.Format == entity.TransferFormatJSON {
+		rows, err = task_transfer.ReadJSON(bytes.NewReader(file.Content))
+	} else {
+		rows, err = task_transfer.ReadCSV(bytes.NewReader(file.Content), mapping)
+	}
+	if err != nil {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Invalid file: "+err.Error()+"!"))
+	}
+
+	return rows
+}
+
+// checkImportRow turns the row into the payload of a new task of the project, returning why it can't be imported.
+// Missing status and priority default to To Do and Low.
+func (uc *TaskTransferUseCase) checkImportRow(
+	projectId string,
+	row *task_transfer.Row,
+	assignees map[string]string,
+) (*entity.TaskPayload, []string) {
+	var errs []string
+
+	payload := &entity.TaskPayload{
+		Title:       row.Title,
+		Description: row.Description,
+		Detail:      row.Detail,
+		Priority:    row.Priority,
+		Status:      row.Status,
+		ProjectId:   projectId,
+		DueDate:     row.DueDate,
+	}
+	if payload.Priority == "" {
+		payload.Priority = "Low"
+	}
+	if payload.Status == "" {
+		payload.Status = entity.TaskStatusToDo
+	}
+
+	if row.Estimate != "" {
+		estimate, err := strconv.Atoi(row.Estimate)
+		if err != nil {
+			errs = append(errs, "Estimate must be a whole number of minutes!")
+		}
+		payload.Estimate = estimate
+	}
+
+	for _, identity := range row.Assignees {
+		assigneeId, ok := assignees[identity]
+		if !ok {
+			// People outside of the project can't be assigned
+			user := uc.userRepository.FindUserByIdentity(identity)
+			if user != nil && uc.projectRepository.GetMemberRole(projectId, user.Id) != "" {
+				assigneeId = user.Id
+			}
+			assignees[identity] = assigneeId
+		}
+
+		if assigneeId == "" {
+			errs = append(errs, fmt.Sprintf("Assignee %s is not part of the project!", identity))
+			continue
+		}
+		if !slices.Contains(payload.AssignedToId, assigneeId) {
+			payload.AssignedToId = append(payload.AssignedToId, assigneeId)
+		}
+	}
+
+	errs = append(errs, importedTaskErrors(uc.validator, payload)...)
+
+	return payload, errs
+}
+
+// importedTaskErrors validates the payload like a new task, returning the invalid fields instead of raising them.
+func importedTaskErrors(validator validation.ValidateTask, payload *entity.TaskPayload) (errs []string) {
+	defer func() {
+		if r := recover(); r != nil {
+			fiberErr, ok := r.(*fiber.Error)
+			if !ok {
+				panic(r)
+			}
+
+			// Validation lists the invalid fields after its message
+			message := fiberErr.Message
+			if _, fields, found := strings.Cut(message, "\n"); found {
+				message = fields
+			}
+			errs = strings.Split(message, ";")
+		}
+	}()
+
+	validator.ValidatePayload(payload)
+
+	return nil
+}
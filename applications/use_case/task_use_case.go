@@ -1,6 +1,10 @@
 ﻿package use_case
 
 import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/event"
+	"github.com/wisle25/task-pixie/applications/markdown"
 	"github.com/wisle25/task-pixie/applications/validation"
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/domains/repository"
@@ -9,44 +13,257 @@ import (
 
 // TaskUseCase handles the business logic for task operations.
 type TaskUseCase struct {
-	taskRepository repository.TaskRepository
-	validator      validation.ValidateTask
+	taskRepository      repository.TaskRepository
+	projectRepository   repository.ProjectRepository
+	milestoneRepository repository.MilestoneRepository
+	timeEntryRepository repository.TimeEntryRepository
+	validator           validation.ValidateTask
+	eventPublisher      event.EventPublisher
+	cache               cache.Cache
+	markdownRenderer    markdown.MarkdownRenderer
 }
 
 func NewTaskUseCase(
 	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+	milestoneRepository repository.MilestoneRepository,
+	timeEntryRepository repository.TimeEntryRepository,
 	validator validation.ValidateTask,
+	eventPublisher event.EventPublisher,
+	cache cache.Cache,
+	markdownRenderer markdown.MarkdownRenderer,
 ) *TaskUseCase {
 	return &TaskUseCase{
-		taskRepository: taskRepository,
-		validator:      validator,
+		taskRepository:      taskRepository,
+		projectRepository:   projectRepository,
+		milestoneRepository: milestoneRepository,
+		timeEntryRepository: timeEntryRepository,
+		validator:           validator,
+		eventPublisher:      eventPublisher,
+		cache:               cache,
+		markdownRenderer:    markdownRenderer,
 	}
 }
 
 // ExecuteAddTask handles the creation of a new task.
 func (uc *TaskUseCase) ExecuteAddTask(payload *entity.TaskPayload, ownerId string) string {
 	uc.validator.ValidatePayload(payload)
-	return uc.taskRepository.AddTask(payload, ownerId)
+	requireProjectAccess(uc.projectRepository, payload.ProjectId, ownerId)
+	requireProjectWritable(uc.projectRepository, payload.ProjectId)
+	requireOpenMilestone(uc.milestoneRepository, payload.ProjectId, payload.MilestoneId)
+	taskId := uc.taskRepository.AddTask(payload, ownerId)
+	invalidateProjectStats(uc.cache, payload.ProjectId)
+
+	// Notify subscribers of the project
+	if payload.ProjectId != "" {
+		uc.eventPublisher.Publish(payload.ProjectId, entity.WebhookEventTaskCreated, uc.taskRepository.GetTaskById(taskId))
+	}
+
+	return taskId
 }
 
-// ExecuteGetTaskById retrieves a task by its ID.
-func (uc *TaskUseCase) ExecuteGetTaskById(id string) *entity.Task {
-	return uc.taskRepository.GetTaskById(id)
+// ExecuteGetTaskById retrieves a task by its ID, tasks of a project are only visible to the people of the project,
+// the others to their owner and assignees. The detail is rendered from Markdown, along with its task links and mentions,
+// and the time spent on it is summed up to now.
+func (uc *TaskUseCase) ExecuteGetTaskById(id string, userId string) *entity.Task {
+	task := uc.taskRepository.GetTaskById(id)
+	requireTaskAccess(uc.projectRepository, task, userId)
+	task.TimeSpent = uc.timeEntryRepository.GetTimeSpentByTask(id)
+
+	detail := uc.markdownRenderer.Render(task.Detail)
+	task.DetailHTML, task.TaskLinks, task.Mentions = detail.HTML, detail.TaskLinks, detail.Mentions
+
+	return task
 }
 
-func (uc *TaskUseCase) ExecuteGetTasksByProjects(projectId string) []entity.PreviewTask {
+// ExecuteGetTasksByProjects retrieves the tasks of a project, only people of the project are allowed.
+func (uc *TaskUseCase) ExecuteGetTasksByProjects(projectId string, userId string) []entity.PreviewTask {
+	requireProjectAccess(uc.projectRepository, projectId, userId)
+
 	return uc.taskRepository.GetTasksByProjects(projectId)
 }
 
-// ExecuteUpdateTaskById updates a task by its ID.
-func (uc *TaskUseCase) ExecuteUpdateTaskById(id string, payload *entity.TaskPayload) {
+// ExecuteUpdateTaskById updates a task by its ID, as long as it's still one of the versions (If-Match), if any.
+// Returns the new version of the task.
+func (uc *TaskUseCase) ExecuteUpdateTaskById(id string, payload *entity.TaskPayload, userId string, versions []int) int {
 	uc.validator.ValidatePayload(payload)
-	uc.taskRepository.UpdateTaskById(id, payload)
+
+	// Both the current and the new project must be accessible, and neither may be archived
+	current := uc.taskRepository.GetTaskById(id)
+	requireTaskAccess(uc.projectRepository, current, userId)
+	requireProjectWritable(uc.projectRepository, current.ProjectId)
+	if payload.ProjectId != "" {
+		requireProjectAccess(uc.projectRepository, payload.ProjectId, userId)
+		requireProjectWritable(uc.projectRepository, payload.ProjectId)
+	}
+	if payload.ProjectId != current.ProjectId || payload.MilestoneId != current.MilestoneId {
+		requireOpenMilestone(uc.milestoneRepository, payload.ProjectId, payload.MilestoneId)
+	}
+
+	uc.taskRepository.UpdateTaskById(id, payload, versions)
+	invalidateProjectStats(uc.cache, current.ProjectId, payload.ProjectId)
+
+	task := uc.taskRepository.GetTaskById(id)
+	uc.eventPublisher.Publish(task.ProjectId, entity.WebhookEventTaskUpdated, task)
+
+	return task.Version
+}
+
+// ExecutePatchTaskById applies a JSON Merge Patch (RFC 7396) to a task, only the supplied fields are changed and validated.
+// Assignments are only replaced when the patch supplies "assignedTo", null unassigns everyone.
+// The patch is only applied on the versions (If-Match) if any, returns the new version of the task.
+func (uc *TaskUseCase) ExecutePatchTaskById(id string, patch []byte, userId string, versions []int) int {
+	task := uc.taskRepository.GetTaskById(id)
+	requireTaskAccess(uc.projectRepository, task, userId)
+	requireProjectWritable(uc.projectRepository, task.ProjectId)
+
+	current := entity.TaskPayload{
+		Title:        task.Title,
+		Description:  task.Description,
+		Detail:       task.Detail,
+		Priority:     task.Priority,
+		Status:       task.Status,
+		ProjectId:    task.ProjectId,
+		DueDate:      task.DueDate,
+		AssignedToId: task.AssignedToIds,
+		Estimate:     task.Estimate,
+		MilestoneId:  task.MilestoneId,
+	}
+
+	var payload entity.TaskPayload
+	fields := applyMergePatch(&current, patch, &payload)
+	uc.validator.ValidatePatch(&payload, fields)
+
+	// Moving the task requires the new project to be accessible as well
+	if payload.ProjectId != "" && payload.ProjectId != task.ProjectId {
+		requireProjectAccess(uc.projectRepository, payload.ProjectId, userId)
+		requireProjectWritable(uc.projectRepository, payload.ProjectId)
+	}
+	if payload.ProjectId != task.ProjectId || payload.MilestoneId != task.MilestoneId {
+		requireOpenMilestone(uc.milestoneRepository, payload.ProjectId, payload.MilestoneId)
+	}
+
+	// The patch was applied on the version read above, so it mustn't be written over a newer one
+	if len(versions) == 0 {
+		versions = []int{task.Version}
+	}
+
+	if patchContains(fields, "AssignedToId") {
+		uc.taskRepository.UpdateTaskById(id, &payload, versions)
+	} else {
+		uc.taskRepository.UpdateTaskFields(id, &payload, versions)
+	}
+	invalidateProjectStats(uc.cache, task.ProjectId, payload.ProjectId)
+
+	task = uc.taskRepository.GetTaskById(id)
+	uc.eventPublisher.Publish(task.ProjectId, entity.WebhookEventTaskUpdated, task)
+
+	return task.Version
 }
 
-// ExecuteDeleteTaskById deletes a task by its ID.
-func (uc *TaskUseCase) ExecuteDeleteTaskById(id string) {
-	uc.taskRepository.DeleteTaskById(id)
+// ExecuteDeleteTaskById moves a task to the trash by its ID, as long as it's still one of the versions (If-Match), if any.
+func (uc *TaskUseCase) ExecuteDeleteTaskById(id string, userId string, versions []int) {
+	// Keep the deleted task for the subscribers
+	task := uc.taskRepository.GetTaskById(id)
+	requireTaskAccess(uc.projectRepository, task, userId)
+	requireProjectWritable(uc.projectRepository, task.ProjectId)
+	uc.taskRepository.DeleteTaskById(id, versions)
+	invalidateProjectStats(uc.cache, task.ProjectId)
+
+	uc.eventPublisher.Publish(task.ProjectId, entity.WebhookEventTaskDeleted, task)
+}
+
+// ExecuteBulkTasks applies a single operation to many tasks, every task is checked on its own first.
+// If any task fails its check, nothing is applied and the report tells which tasks failed and why.
+// The repository checks them again while they're locked, so nothing changes between the checks and the operation.
+func (uc *TaskUseCase) ExecuteBulkTasks(payload *entity.TaskBulkPayload, userId string) *entity.TaskBulkReport {
+	uc.validator.ValidateBulkPayload(payload)
+
+	// The target project is the same for every task
+	if payload.Operation == entity.TaskBulkMoveToProject {
+		requireProjectAccess(uc.projectRepository, payload.Value, userId)
+		requireProjectWritable(uc.projectRepository, payload.Value)
+	}
+
+	report := &entity.TaskBulkReport{Applied: true}
+	var ids []string
+	tasks := make(map[string]*entity.Task)
+
+	for _, id := range payload.TaskIds {
+		if _, exists := tasks[id]; exists {
+			continue
+		}
+
+		task, err := uc.checkBulkTask(id, payload, userId)
+		result := entity.TaskBulkResult{TaskId: id, Result: entity.TaskBulkResultApplied}
+		if err != nil {
+			result.Result = entity.TaskBulkResultFailed
+			result.Message = err.Message
+			report.Applied = false
+		}
+
+		ids = append(ids, id)
+		tasks[id] = task
+		report.Results = append(report.Results, result)
+	}
+
+	if !report.Applied {
+		for i := range report.Results {
+			if report.Results[i].Result == entity.TaskBulkResultApplied {
+				report.Results[i].Result = entity.TaskBulkResultSkipped
+			}
+		}
+
+		return report
+	}
+
+	uc.taskRepository.ApplyBulkOperation(ids, payload.Operation, payload.Value, userId)
+	for _, task := range tasks {
+		invalidateProjectStats(uc.cache, task.ProjectId)
+	}
+	if payload.Operation == entity.TaskBulkMoveToProject {
+		invalidateProjectStats(uc.cache, payload.Value)
+	}
+
+	// Notify subscribers of the projects, the same way single changes do
+	for _, id := range ids {
+		if payload.Operation == entity.TaskBulkDelete {
+			uc.eventPublisher.Publish(tasks[id].ProjectId, entity.WebhookEventTaskDeleted, tasks[id])
+			continue
+		}
+
+		task := uc.taskRepository.GetTaskById(id)
+		uc.eventPublisher.Publish(task.ProjectId, entity.WebhookEventTaskUpdated, task)
+	}
+
+	return report
+}
+
+// checkBulkTask makes sure the user may apply the operation to the task.
+// Client errors are returned to be reported, anything else still panics.
+func (uc *TaskUseCase) checkBulkTask(id string, payload *entity.TaskBulkPayload, userId string) (task *entity.Task, err *fiber.Error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fiberErr, ok := r.(*fiber.Error)
+			if !ok {
+				panic(r)
+			}
+			err = fiberErr
+		}
+	}()
+
+	task = uc.taskRepository.GetTaskById(id)
+	requireTaskAccess(uc.projectRepository, task, userId)
+	requireProjectWritable(uc.projectRepository, task.ProjectId)
+
+	// Assignees must be able to see the task
+	if payload.Operation == entity.TaskBulkAddAssignee &&
+		task.ProjectId != "" &&
+		uc.projectRepository.GetMemberRole(task.ProjectId, payload.Value) == "" {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Assignee is not part of the task's project!"))
+	}
+
+	return task, nil
 }
 
 // ExecuteGetTasks retrieves tasks by owner or assignees.
@@ -0,0 +1,134 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/task_filter"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"time"
+)
+
+// TaskViewUseCase handles the business logic for filtering tasks and the saved views.
+type TaskViewUseCase struct {
+	taskViewRepository repository.TaskViewRepository
+	taskRepository     repository.TaskRepository
+	projectRepository  repository.ProjectRepository
+	validator          validation.ValidateTaskView
+}
+
+func NewTaskViewUseCase(
+	taskViewRepository repository.TaskViewRepository,
+	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+	validator validation.ValidateTaskView,
+) *TaskViewUseCase {
+	return &TaskViewUseCase{
+		taskViewRepository: taskViewRepository,
+		taskRepository:     taskRepository,
+		projectRepository:  projectRepository,
+		validator:          validator,
+	}
+}
+
+// ExecuteAddView saves a new view, sharing it with a project requires being part of the project.
+// Returning the view ID.
+func (uc *TaskViewUseCase) ExecuteAddView(payload *entity.TaskViewPayload, userId string) string {
+	uc.validatePayload(payload, userId)
+
+	return uc.taskViewRepository.AddView(payload, userId)
+}
+
+// ExecuteGetViews retrieves the views of the user, including the ones shared with the user's projects.
+func (uc *TaskViewUseCase) ExecuteGetViews(userId string) []entity.TaskView {
+	return uc.taskViewRepository.GetViewsByUser(userId)
+}
+
+// ExecuteGetViewById retrieves a view owned by, or shared with, the user.
+func (uc *TaskViewUseCase) ExecuteGetViewById(id string, userId string) *entity.TaskView {
+	view := uc.taskViewRepository.GetViewById(id)
+	uc.guardReadable(view, userId)
+
+	return view
+}
+
+// ExecuteUpdateViewById updates a view, only its owner is allowed.
+func (uc *TaskViewUseCase) ExecuteUpdateViewById(id string, payload *entity.TaskViewPayload, userId string) {
+	uc.guardOwner(uc.taskViewRepository.GetViewById(id), userId)
+	uc.validatePayload(payload, userId)
+
+	uc.taskViewRepository.UpdateViewById(id, payload)
+}
+
+// ExecuteDeleteViewById deletes a view, only its owner is allowed.
+func (uc *TaskViewUseCase) ExecuteDeleteViewById(id string, userId string) {
+	uc.guardOwner(uc.taskViewRepository.GetViewById(id), userId)
+
+	uc.taskViewRepository.DeleteViewById(id)
+}
+
+// ExecuteRunView retrieves the tasks matching the view.
+// A view shared with a project only returns the tasks of that project.
+func (uc *TaskViewUseCase) ExecuteRunView(id string, userId string) []entity.PreviewTask {
+	view := uc.taskViewRepository.GetViewById(id)
+	uc.guardReadable(view, userId)
+
+	filter := parseTaskFilter(view.Query)
+	if view.ProjectId != "" {
+		filter.Conditions = append(filter.Conditions, entity.TaskFilterProject{Projects: []string{view.ProjectId}})
+	}
+
+	return uc.taskRepository.GetTasksByFilter(filter, userId, time.Now().Format(time.DateOnly))
+}
+
+// ExecuteFilterTasks retrieves the tasks matching the filter expression without saving it.
+func (uc *TaskViewUseCase) ExecuteFilterTasks(query string, userId string) []entity.PreviewTask {
+	filter := parseTaskFilter(query)
+
+	return uc.taskRepository.GetTasksByFilter(filter, userId, time.Now().Format(time.DateOnly))
+}
+
+func (uc *TaskViewUseCase) validatePayload(payload *entity.TaskViewPayload, userId string) {
+	uc.validator.ValidatePayload(payload)
+	parseTaskFilter(payload.Query)
+
+	if payload.ProjectId != "" {
+		requireProjectRole(
+			uc.projectRepository,
+			payload.ProjectId,
+			userId,
+			entity.ProjectRoleOwner,
+			entity.ProjectRoleAdmin,
+			entity.ProjectRoleMember,
+		)
+	}
+}
+
+// guardReadable makes sure the view is owned by the user, or shared with a project of the user.
+func (uc *TaskViewUseCase) guardReadable(view *entity.TaskView, userId string) {
+	if view.OwnerId == userId {
+		return
+	}
+
+	if view.ProjectId == "" || uc.projectRepository.GetMemberRole(view.ProjectId, userId) == "" {
+		panic(fiber.NewError(fiber.StatusNotFound, "View not found!"))
+	}
+}
+
+func (uc *TaskViewUseCase) guardOwner(view *entity.TaskView, userId string) {
+	uc.guardReadable(view, userId)
+
+	if view.OwnerId != userId {
+		panic(fiber.NewError(fiber.StatusForbidden, "Only the owner can change the view!"))
+	}
+}
+
+// parseTaskFilter parses the filter expression, raising panic (400) if it's invalid.
+func parseTaskFilter(query string) *entity.TaskFilter {
+	filter, err := task_filter.Parse(query)
+	if err != nil {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Invalid filter: "+err.Error()))
+	}
+
+	return filter
+}
@@ -0,0 +1,165 @@
+package use_case
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// TeamUseCase handles the business logic for teams and the access they're granted on projects.
+// Teams belong to an organization, their membership is managed by the organization owner and admins.
+type TeamUseCase struct {
+	teamRepository         repository.TeamRepository
+	organizationRepository repository.OrganizationRepository
+	projectRepository      repository.ProjectRepository
+	validator              validation.ValidateTeam
+}
+
+func NewTeamUseCase(
+	teamRepository repository.TeamRepository,
+	organizationRepository repository.OrganizationRepository,
+	projectRepository repository.ProjectRepository,
+	validator validation.ValidateTeam,
+) *TeamUseCase {
+	return &TeamUseCase{
+		teamRepository:         teamRepository,
+		organizationRepository: organizationRepository,
+		projectRepository:      projectRepository,
+		validator:              validator,
+	}
+}
+
+// ExecuteAddTeam creates a team inside the organization, owner and admins are allowed.
+func (uc *TeamUseCase) ExecuteAddTeam(organizationId string, payload *entity.TeamPayload, userId string) string {
+	uc.validator.ValidatePayload(payload)
+	uc.requireManager(organizationId, userId)
+
+	return uc.teamRepository.AddTeam(organizationId, payload)
+}
+
+// ExecuteGetTeams retrieves the teams of the organization, only its members are allowed.
+func (uc *TeamUseCase) ExecuteGetTeams(organizationId string, userId string) []entity.Team {
+	uc.requireMember(organizationId, userId)
+
+	return uc.teamRepository.GetTeamsByOrganization(organizationId)
+}
+
+// ExecuteGetTeamById retrieves a team, only members of its organization are allowed.
+func (uc *TeamUseCase) ExecuteGetTeamById(id string, userId string) *entity.Team {
+	team := uc.teamRepository.GetTeamById(id)
+	uc.requireMember(team.OrganizationId, userId)
+
+	return team
+}
+
+// ExecuteUpdateTeamById renames a team, organization owner and admins are allowed.
+func (uc *TeamUseCase) ExecuteUpdateTeamById(id string, payload *entity.TeamPayload, userId string) {
+	uc.validator.ValidatePayload(payload)
+	uc.requireManager(uc.teamRepository.GetTeamById(id).OrganizationId, userId)
+
+	uc.teamRepository.UpdateTeamById(id, payload)
+}
+
+// ExecuteDeleteTeamById deletes a team along with the access granted through it.
+func (uc *TeamUseCase) ExecuteDeleteTeamById(id string, userId string) {
+	uc.requireManager(uc.teamRepository.GetTeamById(id).OrganizationId, userId)
+
+	uc.teamRepository.DeleteTeamById(id)
+}
+
+// ExecuteGetTeamMembers retrieves the members of a team, only members of its organization are allowed.
+func (uc *TeamUseCase) ExecuteGetTeamMembers(id string, userId string) []entity.TeamMember {
+	uc.requireMember(uc.teamRepository.GetTeamById(id).OrganizationId, userId)
+
+	return uc.teamRepository.GetTeamMembers(id)
+}
+
+// ExecuteAddTeamMember adds a member of the organization to the team.
+func (uc *TeamUseCase) ExecuteAddTeamMember(id string, payload *entity.TeamMemberPayload, userId string) {
+	uc.validator.ValidateMemberPayload(payload)
+
+	organizationId := uc.teamRepository.GetTeamById(id).OrganizationId
+	uc.requireManager(organizationId, userId)
+	if uc.organizationRepository.GetOrganizationRole(organizationId, payload.UserId) == "" {
+		panic(fiber.NewError(fiber.StatusBadRequest, "User is not a member of the organization!"))
+	}
+
+	uc.teamRepository.AddTeamMember(id, payload.UserId)
+}
+
+// ExecuteRemoveTeamMember removes a member from the team.
+func (uc *TeamUseCase) ExecuteRemoveTeamMember(id string, memberId string, userId string) {
+	uc.requireManager(uc.teamRepository.GetTeamById(id).OrganizationId, userId)
+
+	uc.teamRepository.RemoveTeamMember(id, memberId)
+}
+
+// ExecuteGetProjectTeams retrieves the teams granted on the project, only people of the project are allowed.
+func (uc *TeamUseCase) ExecuteGetProjectTeams(projectId string, userId string) []entity.ProjectTeam {
+	requireProjectAccess(uc.projectRepository, projectId, userId)
+
+	return uc.teamRepository.GetProjectTeams(projectId)
+}
+
+// ExecuteAddProjectTeam grants a team of the project's organization a role on the project.
+// Project owner and admins are allowed, but only the owner can grant the admin role.
+func (uc *TeamUseCase) ExecuteAddProjectTeam(projectId string, payload *entity.ProjectTeamPayload, userId string) {
+	if payload.Role == "" {
+		payload.Role = entity.ProjectRoleMember
+	}
+	uc.validator.ValidateProjectTeamPayload(payload)
+
+	role := requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, projectId)
+	if payload.Role == entity.ProjectRoleAdmin && role != entity.ProjectRoleOwner {
+		panic(fiber.NewError(fiber.StatusForbidden, "Only the project owner can grant the admin role!"))
+	}
+
+	// Teams never give access across organizations
+	if uc.teamRepository.GetTeamById(payload.TeamId).OrganizationId != uc.projectRepository.GetProjectById(projectId).OrganizationId {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Team doesn't belong to the project's organization!"))
+	}
+
+	uc.teamRepository.AddProjectTeam(projectId, payload.TeamId, payload.Role)
+}
+
+// ExecuteUpdateProjectTeamRole changes the role granted to a team, only the project owner is allowed.
+func (uc *TeamUseCase) ExecuteUpdateProjectTeamRole(projectId string, payload *entity.ProjectTeamPayload, userId string) {
+	uc.validator.ValidateProjectTeamPayload(payload)
+	requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner)
+	requireProjectWritable(uc.projectRepository, projectId)
+
+	uc.teamRepository.UpdateProjectTeamRole(projectId, payload.TeamId, payload.Role)
+}
+
+// ExecuteRemoveProjectTeam revokes the access of a team, project owner and admins are allowed.
+func (uc *TeamUseCase) ExecuteRemoveProjectTeam(projectId string, teamId string, userId string) {
+	requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, projectId)
+
+	uc.teamRepository.RemoveProjectTeam(projectId, teamId)
+}
+
+// requireManager makes sure the user is owner or admin of the organization.
+func (uc *TeamUseCase) requireManager(organizationId string, userId string) {
+	requireOrganizationRole(
+		uc.organizationRepository,
+		organizationId,
+		userId,
+		entity.OrganizationRoleOwner,
+		entity.OrganizationRoleAdmin,
+	)
+}
+
+// requireMember makes sure the user is part of the organization.
+func (uc *TeamUseCase) requireMember(organizationId string, userId string) {
+	requireOrganizationRole(
+		uc.organizationRepository,
+		organizationId,
+		userId,
+		entity.OrganizationRoleOwner,
+		entity.OrganizationRoleAdmin,
+		entity.OrganizationRoleMember,
+	)
+}
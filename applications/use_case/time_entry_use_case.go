@@ -0,0 +1,131 @@
+package use_case
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// TimeEntryUseCase handles the business logic for the time tracked on tasks, by timers or by hand.
+type TimeEntryUseCase struct {
+	timeEntryRepository repository.TimeEntryRepository
+	taskRepository      repository.TaskRepository
+	projectRepository   repository.ProjectRepository
+	validator           validation.ValidateTimeEntry
+}
+
+func NewTimeEntryUseCase(
+	timeEntryRepository repository.TimeEntryRepository,
+	taskRepository repository.TaskRepository,
+	projectRepository repository.ProjectRepository,
+	validator validation.ValidateTimeEntry,
+) *TimeEntryUseCase {
+	return &TimeEntryUseCase{
+		timeEntryRepository: timeEntryRepository,
+		taskRepository:      taskRepository,
+		projectRepository:   projectRepository,
+		validator:           validator,
+	}
+}
+
+// ExecuteStartTimer starts a timer on the task, a user has only one running timer at a time.
+func (uc *TimeEntryUseCase) ExecuteStartTimer(taskId string, payload *entity.TimerPayload, userId string) string {
+	uc.validator.ValidateTimerPayload(payload)
+	uc.requireTrackable(taskId, userId)
+
+	return uc.timeEntryRepository.StartTimer(taskId, userId, payload)
+}
+
+// ExecuteStopTimer stops the running timer of the user, returning the finished time entry.
+func (uc *TimeEntryUseCase) ExecuteStopTimer(userId string) *entity.TimeEntry {
+	id := uc.timeEntryRepository.StopTimer(userId)
+
+	return uc.timeEntryRepository.GetTimeEntryById(id)
+}
+
+// ExecuteGetRunningTimer retrieves the running timer of the user.
+func (uc *TimeEntryUseCase) ExecuteGetRunningTimer(userId string) *entity.TimeEntry {
+	return uc.timeEntryRepository.GetRunningTimer(userId)
+}
+
+// ExecuteAddTimeEntry adds time spent on the task by hand.
+func (uc *TimeEntryUseCase) ExecuteAddTimeEntry(taskId string, payload *entity.TimeEntryPayload, userId string) string {
+	uc.validator.ValidateEntryPayload(payload)
+	uc.requireTrackable(taskId, userId)
+
+	return uc.timeEntryRepository.AddTimeEntry(taskId, userId, payload)
+}
+
+// ExecuteGetTimeEntries retrieves the time tracked on the task by everyone, the task must be accessible.
+func (uc *TimeEntryUseCase) ExecuteGetTimeEntries(taskId string, userId string) []entity.TimeEntry {
+	requireTaskAccess(uc.projectRepository, uc.taskRepository.GetTaskById(taskId), userId)
+
+	return uc.timeEntryRepository.GetTimeEntriesByTask(taskId)
+}
+
+// ExecuteDeleteTimeEntryById deletes a time entry, people delete their own, project owner and admins anyone's.
+func (uc *TimeEntryUseCase) ExecuteDeleteTimeEntryById(id string, userId string) {
+	entry := uc.timeEntryRepository.GetTimeEntryById(id)
+	if entry.UserId != userId {
+		if entry.ProjectId == "" {
+			panic(fiber.NewError(fiber.StatusForbidden, "You can only delete your own time entries!"))
+		}
+		requireProjectRole(uc.projectRepository, entry.ProjectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	}
+	requireProjectWritable(uc.projectRepository, entry.ProjectId)
+
+	uc.timeEntryRepository.DeleteTimeEntryById(id)
+}
+
+// ExecuteGetTimeReport aggregates the time by day, project and user, along with its total.
+func (uc *TimeEntryUseCase) ExecuteGetTimeReport(filter *entity.TimeReportFilter, userId string) *entity.TimeReport {
+	uc.validator.ValidateReportFilter(filter)
+	requireProjectAccess(uc.projectRepository, filter.ProjectId, userId)
+
+	report := &entity.TimeReport{
+		Rows: uc.timeEntryRepository.GetTimeReport(userId, filter),
+	}
+	for _, row := range report.Rows {
+		report.TotalMinutes += row.Minutes
+	}
+
+	return report
+}
+
+// ExecuteExportTimeReport returns the time report as CSV, hours are given along with minutes for billing.
+func (uc *TimeEntryUseCase) ExecuteExportTimeReport(filter *entity.TimeReportFilter, userId string) []byte {
+	report := uc.ExecuteGetTimeReport(filter, userId)
+
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+	records := [][]string{{"date", "project_id", "project", "user_id", "username", "minutes", "hours"}}
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			row.Date,
+			row.ProjectId,
+			row.Project,
+			row.UserId,
+			row.Username,
+			fmt.Sprint(row.Minutes),
+			fmt.Sprintf("%.2f", float64(row.Minutes)/60),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		panic(fmt.Errorf("time_entry_use_case_error: write csv: %v", err))
+	}
+
+	return buffer.Bytes()
+}
+
+// requireTrackable makes sure the user may track time on the task, the task must be accessible and writable.
+// Tasks outside of any project are only tracked by their owner and assignees.
+func (uc *TimeEntryUseCase) requireTrackable(taskId string, userId string) {
+	task := uc.taskRepository.GetTaskById(taskId)
+	requireTaskAccess(uc.projectRepository, task, userId)
+	requireProjectWritable(uc.projectRepository, task.ProjectId)
+}
@@ -0,0 +1,59 @@
+package use_case
+
+import (
+	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// TrashUseCase handles the business logic for restoring and purging deleted projects and tasks.
+type TrashUseCase struct {
+	projectRepository repository.ProjectRepository
+	taskRepository    repository.TaskRepository
+	config            *commons.Config
+	cache             cache.Cache
+}
+
+func NewTrashUseCase(
+	projectRepository repository.ProjectRepository,
+	taskRepository repository.TaskRepository,
+	config *commons.Config,
+	cache cache.Cache,
+) *TrashUseCase {
+	return &TrashUseCase{
+		projectRepository: projectRepository,
+		taskRepository:    taskRepository,
+		config:            config,
+		cache:             cache,
+	}
+}
+
+// ExecuteGetTrash retrieves the deleted projects owned by the user,
+// and the deleted tasks owned by the user or inside the user's projects.
+func (uc *TrashUseCase) ExecuteGetTrash(userId string) *entity.Trash {
+	return &entity.Trash{
+		Projects: uc.projectRepository.GetDeletedProjectsByOwner(userId),
+		Tasks:    uc.taskRepository.GetDeletedTasksByUser(userId),
+	}
+}
+
+// ExecuteRestoreProjectById takes a project out of the trash, only its owner is allowed.
+func (uc *TrashUseCase) ExecuteRestoreProjectById(id string, userId string) {
+	uc.projectRepository.RestoreProjectById(id, userId)
+}
+
+// ExecuteRestoreTaskById takes a task out of the trash, only its owner or the project owner is allowed.
+func (uc *TrashUseCase) ExecuteRestoreTaskById(id string, userId string) {
+	uc.taskRepository.RestoreTaskById(id, userId)
+	invalidateProjectStats(uc.cache, uc.taskRepository.GetTaskById(id).ProjectId)
+}
+
+// ExecutePurge permanently deletes the projects and tasks that stayed in the trash longer than the retention.
+// Returning the number of purged projects and tasks.
+func (uc *TrashUseCase) ExecutePurge() (int64, int64) {
+	purgedProjects := uc.projectRepository.PurgeDeletedProjects(uc.config.TrashRetention)
+	purgedTasks := uc.taskRepository.PurgeDeletedTasks(uc.config.TrashRetention)
+
+	return purgedProjects, purgedTasks
+}
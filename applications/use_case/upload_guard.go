@@ -0,0 +1,75 @@
+package use_case
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/scanner"
+	"github.com/wisle25/task-pixie/applications/upload"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"log"
+	"mime/multipart"
+	"strings"
+)
+
+// UploadGuard checks every uploaded file before it's processed or reaches FileUpload.
+// Files the scanner finds a threat in are quarantined and refused.
+type UploadGuard struct {
+	fileScanner    scanner.FileScanner
+	fileQuarantine scanner.FileQuarantine
+}
+
+func NewUploadGuard(fileScanner scanner.FileScanner, fileQuarantine scanner.FileQuarantine) *UploadGuard {
+	return &UploadGuard{
+		fileScanner:    fileScanner,
+		fileQuarantine: fileQuarantine,
+	}
+}
+
+// Check reads the uploaded file and makes sure it satisfies the policy and is clean.
+// Should raise panic if it's too large (413), of a type not allowed (415), an image out of the dimensions or unreadable (400),
+// or if a threat is found in it (422).
+// Returning the file, named and typed from what was checked.
+func (g *UploadGuard) Check(header *multipart.FileHeader, policy upload.Policy, userId string) *upload.File {
+	file, err := upload.Read(header, policy)
+	if err != nil {
+		panic(uploadError(err))
+	}
+
+	threat := g.fileScanner.Scan(file.Content)
+	if threat == "" {
+		return file
+	}
+
+	log.Printf("upload_guard: %s uploaded by %s contains %s, quarantined", file.Name, userId, threat)
+	g.fileQuarantine.Quarantine(&entity.QuarantinedFile{
+		Name:        file.Name,
+		ContentType: file.ContentType,
+		Size:        len(file.Content),
+		Threat:      threat,
+		UploadedBy:  userId,
+		Content:     file.Content,
+	})
+
+	panic(fiber.NewError(fiber.StatusUnprocessableEntity, "File was rejected as malware!"))
+}
+
+// uploadError turns the reason the file was refused into the response, telling the limit it broke.
+func uploadError(err error) error {
+	message := err.Error()
+	message = strings.ToUpper(message[:1]) + message[1:] + "!"
+
+	switch {
+	case errors.Is(err, upload.ErrTooLarge):
+		return fiber.NewError(fiber.StatusRequestEntityTooLarge, message)
+	case errors.Is(err, upload.ErrContentType):
+		return fiber.NewError(fiber.StatusUnsupportedMediaType, message)
+	case errors.Is(err, upload.ErrDimensions):
+		return fiber.NewError(fiber.StatusBadRequest, message)
+	case errors.Is(err, upload.ErrUnreadable):
+		// The details are about the decoder, not the upload
+		return fiber.NewError(fiber.StatusBadRequest, "File couldn't be read!")
+	default:
+		return fmt.Errorf("upload_guard_err: %v", err)
+	}
+}
@@ -3,23 +3,42 @@ package use_case
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/gofiber/fiber/v2"
 	"github.com/wisle25/task-pixie/applications/cache"
 	"github.com/wisle25/task-pixie/applications/file_statics"
+	"github.com/wisle25/task-pixie/applications/identicon"
+	"github.com/wisle25/task-pixie/applications/mailer"
 	"github.com/wisle25/task-pixie/applications/security"
+	"github.com/wisle25/task-pixie/applications/upload"
 	"github.com/wisle25/task-pixie/applications/validation"
 	"github.com/wisle25/task-pixie/commons"
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/domains/repository"
-	"io"
 	"log"
+	"mime"
+	"mime/multipart"
+	"path"
+	"slices"
 	"time"
 )
 
+// What's accepted as an avatar, the type is sniffed from the content
+var avatarPolicy = upload.Policy{
+	MaxBytes:     4 << 20,
+	ContentTypes: []string{"image/jpeg", "image/png", "image/gif", "image/webp"},
+	MinDimension: 32,
+	MaxDimension: 4096,
+	MaxPixels:    4096 * 4096,
+}
+
 // UserUseCase handles the business logic for user operations.
 type UserUseCase struct {
 	userRepository repository.UserRepository
 	fileProcessing file_statics.FileProcessing
 	fileUpload     file_statics.FileUpload
+	uploadGuard    *UploadGuard
+	mailer         mailer.Mailer
+	mailRenderer   mailer.MailRenderer
 	passwordHash   security.PasswordHash
 	validator      validation.ValidateUser
 	config         *commons.Config
@@ -31,6 +50,9 @@ func NewUserUseCase(
 	userRepository repository.UserRepository,
 	fileProcessing file_statics.FileProcessing,
 	fileUpload file_statics.FileUpload,
+	uploadGuard *UploadGuard,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
 	passwordHash security.PasswordHash,
 	validator validation.ValidateUser,
 	config *commons.Config,
@@ -41,6 +63,9 @@ func NewUserUseCase(
 		userRepository: userRepository,
 		fileProcessing: fileProcessing,
 		fileUpload:     fileUpload,
+		uploadGuard:    uploadGuard,
+		mailer:         mailer,
+		mailRenderer:   mailRenderer,
 		passwordHash:   passwordHash,
 		validator:      validator,
 		config:         config,
@@ -67,6 +92,7 @@ func (uc *UserUseCase) ExecuteAdd(payload *entity.RegisterUserPayload) string {
 // Returned tokens must be added to the HTTP Header
 func (uc *UserUseCase) ExecuteLogin(payload *entity.LoginUserPayload) (*entity.TokenDetail, *entity.TokenDetail) {
 	uc.validator.ValidateLoginPayload(payload)
+	uc.renewExpiredPasswordReset(payload.Identity)
 
 	// Get user information from database then compare password
 	userInfo, encryptedPassword := uc.userRepository.GetUserForLogin(payload.Identity)
@@ -90,12 +116,28 @@ func (uc *UserUseCase) ExecuteLogin(payload *entity.LoginUserPayload) (*entity.T
 	return accessTokenDetail, refreshTokenDetail
 }
 
+// renewExpiredPasswordReset emails a new link to the user whose forced password reset expired,
+// they would be refused forever otherwise. They're still refused until they follow it.
+func (uc *UserUseCase) renewExpiredPasswordReset(identity string) {
+	token := generateSecretToken()
+
+	user := uc.userRepository.RenewExpiredPasswordReset(identity, hashSecretToken(token), uc.config.PasswordResetTTL)
+	if user == nil {
+		return
+	}
+
+	mailPasswordReset(uc.mailer, uc.mailRenderer, uc.config, user, token)
+}
+
 // ExecuteRefreshToken handles refreshing the access token using the provided refresh token.
 // Should raise panic if refresh token is invalid
 // Returned new access token should be added to HTTP Cookie
 func (uc *UserUseCase) ExecuteRefreshToken(currentRefreshToken string) *entity.TokenDetail {
 	// Verify token from JWT itself and from cache
 	tokenClaims := uc.token.ValidateToken(currentRefreshToken, uc.config.RefreshTokenPublicKey)
+	if sessionRevoked(uc.cache, tokenClaims.UserToken.Id, tokenClaims.IssuedAt) {
+		panic(fiber.NewError(fiber.StatusUnauthorized, "Session invalid or expired!"))
+	}
 	userInfoJSON := uc.cache.GetCache(tokenClaims.TokenId).(string)
 
 	// Unmarshal user info JSON
@@ -127,9 +169,12 @@ func (uc *UserUseCase) ExecuteLogout(refreshToken string, accessTokenId string)
 
 // ExecuteGuard verifies the access token and retrieves the associated user from the cache.
 // This is used as a guard middleware for JWT authentication.
-// Returning userId from token's cache
+// Returning userId from token's cache, nil when the user's sessions were revoked since
 func (uc *UserUseCase) ExecuteGuard(accessToken string) (interface{}, *entity.TokenDetail) {
 	accessTokenDetail := uc.token.ValidateToken(accessToken, uc.config.AccessTokenPublicKey)
+	if sessionRevoked(uc.cache, accessTokenDetail.UserToken.Id, accessTokenDetail.IssuedAt) {
+		return nil, accessTokenDetail
+	}
 
 	return uc.cache.GetCache(accessTokenDetail.TokenId), accessTokenDetail
 }
@@ -139,32 +184,122 @@ func (uc *UserUseCase) ExecuteGetUserById(userId string) *entity.User {
 	return uc.userRepository.GetUserById(userId)
 }
 
+// ExecuteGetPublicUserById returns the user information anyone may see, without their preferences.
+func (uc *UserUseCase) ExecuteGetPublicUserById(userId string) *entity.User {
+	user := uc.userRepository.GetUserById(userId)
+	user.Notifications = nil
+	user.DeletionScheduledAt = ""
+
+	return user
+}
+
 // ExecuteUpdateUserById Updating user information and now user can set their new password and upload an avatar.
+// The avatar is cropped to a square and saved in every size of AvatarSizes, replacing the old one at once.
+// Empty preferences are reset to their defaults, notification preferences that aren't given are kept.
 func (uc *UserUseCase) ExecuteUpdateUserById(userId string, payload *entity.UpdateUserPayload) {
 	uc.validator.ValidateUpdatePayload(payload)
 
+	if payload.TimeZone == "" {
+		payload.TimeZone = "UTC"
+	}
+	if payload.Locale == "" {
+		payload.Locale = "en"
+	}
+	if payload.DateFormat == "" {
+		payload.DateFormat = entity.DateFormatISO
+	}
+
 	// Hash password if provided only
 	if payload.Password != "" {
 		payload.Password = uc.passwordHash.Hash(payload.Password)
 	}
 
-	newAvatarLink := ""
-	// Handling avatar file
+	// Handling avatar file, no avatar removes the current one
+	var avatars map[int]string
 	if payload.Avatar != nil {
-		file, _ := payload.Avatar.Open()
-		fileBuffer, _ := io.ReadAll(file)
-
-		compressedBuffer, extension := uc.fileProcessing.CompressImage(fileBuffer, file_statics.WEBP)
-		newAvatarLink = uc.fileUpload.UploadFile(compressedBuffer, extension)
+		avatars = uc.uploadAvatar(payload.Avatar, userId)
 	}
 
-	// Updating user's repository
-	oldAvatarLink := uc.userRepository.UpdateUserById(userId, payload, newAvatarLink)
+	// Updating user's repository, the uploaded variants are removed if it fails
+	defer func() {
+		if r := recover(); r != nil {
+			uc.removeAvatar(avatars)
+			panic(r)
+		}
+	}()
+	oldFileNames := uc.userRepository.UpdateUserById(userId, payload, avatars)
 
 	// If exists, remove user's old avatar
-	if oldAvatarLink != "" {
-		uc.fileUpload.RemoveFile(oldAvatarLink)
+	for _, fileName := range oldFileNames {
+		uc.removeFile(fileName)
+	}
+}
+
+// ExecuteGetAvatar returns the avatar variant of that size, the user's identicon if they haven't uploaded one.
+// The largest variant is returned if size isn't specified.
+// Returning the picture and its content type.
+func (uc *UserUseCase) ExecuteGetAvatar(userId string, size int) ([]byte, string) {
+	if size == 0 {
+		size = entity.AvatarSizes[len(entity.AvatarSizes)-1]
 	}
+	if !slices.Contains(entity.AvatarSizes, size) {
+		panic(fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Size must be one of %v!", entity.AvatarSizes)))
+	}
+
+	fileName := uc.userRepository.GetAvatarFileName(userId, size)
+	if fileName == "" {
+		return identicon.Generate(userId, size), identicon.ContentType
+	}
+
+	return uc.fileUpload.GetFile(fileName), mime.TypeByExtension(path.Ext(fileName))
+}
+
+// uploadAvatar checks the uploaded avatar against the avatarPolicy and scans it, then uploads its square variants.
+// Returning the uploaded variants by size.
+func (uc *UserUseCase) uploadAvatar(avatar *multipart.FileHeader, userId string) map[int]string {
+	file := uc.uploadGuard.Check(avatar, avatarPolicy, userId)
+
+	variants, extension := uc.fileProcessing.CreateSquareVariants(file.Content, entity.AvatarSizes, file_statics.WEBP)
+
+	avatars := make(map[int]string, len(variants))
+	defer func() {
+		if r := recover(); r != nil {
+			uc.removeAvatar(avatars)
+			panic(r)
+		}
+	}()
+
+	for size, variant := range variants {
+		avatars[size] = uc.fileUpload.UploadFile(variant, extension)
+	}
+
+	return avatars
+}
+
+// removeAvatar removes the variants of an avatar that couldn't be saved.
+func (uc *UserUseCase) removeAvatar(avatars map[int]string) {
+	for _, fileName := range avatars {
+		uc.removeFile(fileName)
+	}
+}
+
+// removeFile removes a file, a failure is only logged since nothing references it anymore.
+func (uc *UserUseCase) removeFile(fileName string) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("remove_file_err: %s: %v", fileName, r)
+		}
+	}()
+
+	uc.fileUpload.RemoveFile(fileName)
+}
+
+// ExecuteResetPassword sets the new password of the user whose reset was forced by an administrator.
+// Should raise panic if the emailed token is unknown or expired.
+func (uc *UserUseCase) ExecuteResetPassword(payload *entity.PasswordResetPayload) {
+	uc.validator.ValidatePasswordResetPayload(payload)
+
+	uc.userRepository.ResetPassword(hashSecretToken(payload.Token), uc.passwordHash.Hash(payload.Password))
 }
 
 // ExecuteSearchUsersByUsername handles the logic for searching users by username.
@@ -0,0 +1,185 @@
+package use_case
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/applications/webhook"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"time"
+)
+
+// Deliveries claimed by the worker on every tick
+const webhookDeliveryBatch = 20
+
+// Upper bound of the delay between two attempts
+const webhookMaxBackoff = 6 * time.Hour
+
+// WebhookUseCase handles the business logic for webhook operations.
+// It also implements EventPublisher, so other use cases can queue deliveries through it.
+type WebhookUseCase struct /* implements EventPublisher */ {
+	webhookRepository repository.WebhookRepository
+	projectRepository repository.ProjectRepository
+	webhookSender     webhook.WebhookSender
+	validator         validation.ValidateWebhook
+	config            *commons.Config
+}
+
+func NewWebhookUseCase(
+	webhookRepository repository.WebhookRepository,
+	projectRepository repository.ProjectRepository,
+	webhookSender webhook.WebhookSender,
+	validator validation.ValidateWebhook,
+	config *commons.Config,
+) *WebhookUseCase {
+	return &WebhookUseCase{
+		webhookRepository: webhookRepository,
+		projectRepository: projectRepository,
+		webhookSender:     webhookSender,
+		validator:         validator,
+		config:            config,
+	}
+}
+
+// ExecuteAddWebhook registers a new webhook, only project owner and admins are allowed.
+// Returning the webhook ID and its signing secret, the secret is not shown anymore after this.
+func (uc *WebhookUseCase) ExecuteAddWebhook(projectId string, payload *entity.WebhookPayload, userId string) (string, string) {
+	requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+	requireProjectWritable(uc.projectRepository, projectId)
+	uc.validatePayload(payload)
+
+	secret := generateSecretToken()
+	webhookId := uc.webhookRepository.AddWebhook(projectId, payload, secret, userId)
+
+	return webhookId, secret
+}
+
+// ExecuteGetWebhooks retrieves the webhooks of a project.
+func (uc *WebhookUseCase) ExecuteGetWebhooks(projectId string, userId string) []entity.Webhook {
+	requireProjectRole(uc.projectRepository, projectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+
+	return uc.webhookRepository.GetWebhooksByProject(projectId)
+}
+
+// ExecuteUpdateWebhookById updates the URL, events or active state of a webhook.
+func (uc *WebhookUseCase) ExecuteUpdateWebhookById(id string, payload *entity.WebhookPayload, userId string) {
+	hook := uc.guardWebhook(id, userId)
+	requireProjectWritable(uc.projectRepository, hook.ProjectId)
+	uc.validatePayload(payload)
+
+	uc.webhookRepository.UpdateWebhookById(id, payload)
+}
+
+// ExecuteDeleteWebhookById deletes a webhook along with its delivery log.
+func (uc *WebhookUseCase) ExecuteDeleteWebhookById(id string, userId string) {
+	uc.guardWebhook(id, userId)
+
+	uc.webhookRepository.DeleteWebhookById(id)
+}
+
+// ExecuteGetDeliveries retrieves the latest deliveries of a webhook.
+func (uc *WebhookUseCase) ExecuteGetDeliveries(webhookId string, userId string) []entity.WebhookDelivery {
+	uc.guardWebhook(webhookId, userId)
+
+	return uc.webhookRepository.GetDeliveriesByWebhook(webhookId)
+}
+
+// ExecuteRedeliver queues the payload of an old delivery again as a new delivery.
+// Returning the new delivery ID.
+func (uc *WebhookUseCase) ExecuteRedeliver(webhookId string, deliveryId string, userId string) string {
+	uc.guardWebhook(webhookId, userId)
+
+	delivery := uc.webhookRepository.GetDeliveryById(deliveryId)
+	if delivery.WebhookId != webhookId {
+		panic(fiber.NewError(fiber.StatusNotFound, "Webhook delivery not found!"))
+	}
+
+	return uc.webhookRepository.AddDelivery(webhookId, delivery.Event, []byte(delivery.Payload))
+}
+
+// Publish queues a delivery for every active webhook of the project subscribed to the event.
+func (uc *WebhookUseCase) Publish(projectId string, event string, data interface{}) {
+	if projectId == "" {
+		return
+	}
+
+	webhooks := uc.webhookRepository.GetActiveWebhooksByEvent(projectId, event)
+	if len(webhooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(entity.WebhookEvent{
+		Event:      event,
+		ProjectId:  projectId,
+		OccurredAt: time.Now().UTC().Format(time.RFC3339),
+		Data:       data,
+	})
+	if err != nil {
+		panic(fmt.Errorf("publish_webhook_err: unable to marshal event: %v", err))
+	}
+
+	for _, hook := range webhooks {
+		uc.webhookRepository.AddDelivery(hook.Id, event, body)
+	}
+}
+
+// ExecuteProcessDeliveries sends the due deliveries, this is meant to be called periodically by the worker.
+// Failed attempts are retried with exponential backoff until WebhookMaxAttempts is reached.
+// Returning the number of processed deliveries.
+func (uc *WebhookUseCase) ExecuteProcessDeliveries() int {
+	deliveries := uc.webhookRepository.ClaimDueDeliveries(webhookDeliveryBatch)
+
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		response := uc.webhookSender.Send(delivery)
+
+		delivery.Attempts++
+		delivery.ResponseCode = response.StatusCode
+		delivery.Error = response.Error
+
+		var retryIn time.Duration
+		switch {
+		case response.Error == "" && response.StatusCode >= 200 && response.StatusCode < 300:
+			delivery.Status = entity.WebhookDeliverySucceeded
+		case delivery.Attempts >= uc.config.WebhookMaxAttempts:
+			delivery.Status = entity.WebhookDeliveryFailed
+		default:
+			delivery.Status = entity.WebhookDeliveryPending
+			retryIn = webhookBackoff(uc.config.WebhookBackoffBase, delivery.Attempts)
+		}
+
+		uc.webhookRepository.UpdateDeliveryResult(delivery, retryIn)
+	}
+
+	return len(deliveries)
+}
+
+// guardWebhook makes sure the user is allowed to manage the webhook's project, returning the webhook.
+func (uc *WebhookUseCase) guardWebhook(webhookId string, userId string) *entity.Webhook {
+	hook := uc.webhookRepository.GetWebhookById(webhookId)
+	requireProjectRole(uc.projectRepository, hook.ProjectId, userId, entity.ProjectRoleOwner, entity.ProjectRoleAdmin)
+
+	return hook
+}
+
+// validatePayload validates the payload, and refuses URLs naming an internal host unless private networks are allowed.
+func (uc *WebhookUseCase) validatePayload(payload *entity.WebhookPayload) {
+	uc.validator.ValidatePayload(payload)
+
+	if !uc.config.WebhookAllowPrivate && webhook.IsInternalUrl(payload.Url) {
+		panic(fiber.NewError(fiber.StatusBadRequest, "Webhook URL must be reachable on the internet!"))
+	}
+}
+
+// webhookBackoff doubles the base delay on every failed attempt.
+func webhookBackoff(base time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < webhookMaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, webhookMaxBackoff)
+}
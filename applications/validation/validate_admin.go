@@ -0,0 +1,9 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateAdmin interface defines methods for validating the filters of the admin console.
+type ValidateAdmin interface {
+	ValidateUserFilter(filter *entity.AdminUserFilter)
+	ValidateAuditLogFilter(filter *entity.AuditLogFilter)
+}
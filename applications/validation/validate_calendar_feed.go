@@ -0,0 +1,9 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateCalendarFeed interface defines methods for validating calendar feed payloads.
+type ValidateCalendarFeed interface {
+	ValidatePayload(payload *entity.CalendarFeedPayload)
+	ValidateFilter(filter *entity.CalendarFeedFilter)
+}
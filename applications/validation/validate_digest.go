@@ -0,0 +1,8 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateDigest interface defines methods for validating digest-related payloads.
+type ValidateDigest interface {
+	ValidateSubscriptionPayload(payload *entity.DigestSubscriptionPayload)
+}
@@ -0,0 +1,8 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateInvitation interface defines methods for validating project invitations.
+type ValidateInvitation interface {
+	ValidatePayload(payload *entity.InvitationPayload)
+}
@@ -0,0 +1,9 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateMilestone interface defines methods for validating milestone payloads.
+type ValidateMilestone interface {
+	ValidatePayload(payload *entity.MilestonePayload)
+	ValidateClosePayload(payload *entity.CloseMilestonePayload)
+}
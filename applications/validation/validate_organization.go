@@ -0,0 +1,9 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateOrganization interface defines methods for validating organization-related payloads.
+type ValidateOrganization interface {
+	ValidatePayload(payload *entity.OrganizationPayload)
+	ValidateMemberPayload(payload *entity.OrganizationMemberPayload)
+}
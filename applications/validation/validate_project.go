@@ -5,4 +5,12 @@ import "github.com/wisle25/task-pixie/domains/entity"
 // ValidateProject interface defines methods for validating project-related payloads.
 type ValidateProject interface {
 	ValidatePayload(payload *entity.ProjectPayload)
+
+	// ValidatePatch validates only the given fields of the payload, the ones supplied by a patch.
+	ValidatePatch(payload *entity.ProjectPayload, fields []string)
+	ValidateMemberPayload(payload *entity.MemberPayload)
+	ValidateTransferPayload(payload *entity.TransferOwnershipPayload)
+	ValidateClonePayload(payload *entity.CloneProjectPayload)
+	ValidateTemplatePayload(payload *entity.ProjectTemplatePayload)
+	ValidateStatsFilter(filter *entity.ProjectStatsFilter)
 }
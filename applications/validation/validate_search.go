@@ -0,0 +1,8 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateSearch interface defines methods for validating search queries.
+type ValidateSearch interface {
+	ValidatePayload(payload *entity.SearchPayload)
+}
@@ -5,4 +5,11 @@ import "github.com/wisle25/task-pixie/domains/entity"
 // ValidateTask interface defines methods for validating task-related payloads.
 type ValidateTask interface {
 	ValidatePayload(payload *entity.TaskPayload)
+
+	// ValidatePatch validates only the given fields of the payload, the ones supplied by a patch.
+	ValidatePatch(payload *entity.TaskPayload, fields []string)
+	ValidateBulkPayload(payload *entity.TaskBulkPayload)
+	ValidateImportPayload(payload *entity.TaskImportPayload)
+	ValidateExportFilter(filter *entity.TaskExportFilter)
+	ValidateBoardImportPayload(payload *entity.BoardImportPayload)
 }
@@ -0,0 +1,8 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateTaskView interface defines methods for validating saved view payloads.
+type ValidateTaskView interface {
+	ValidatePayload(payload *entity.TaskViewPayload)
+}
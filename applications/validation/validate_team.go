@@ -0,0 +1,10 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateTeam interface defines methods for validating team-related payloads.
+type ValidateTeam interface {
+	ValidatePayload(payload *entity.TeamPayload)
+	ValidateMemberPayload(payload *entity.TeamMemberPayload)
+	ValidateProjectTeamPayload(payload *entity.ProjectTeamPayload)
+}
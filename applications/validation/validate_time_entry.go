@@ -0,0 +1,10 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateTimeEntry interface defines methods for validating time tracking payloads.
+type ValidateTimeEntry interface {
+	ValidateTimerPayload(payload *entity.TimerPayload)
+	ValidateEntryPayload(payload *entity.TimeEntryPayload)
+	ValidateReportFilter(filter *entity.TimeReportFilter)
+}
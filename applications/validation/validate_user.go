@@ -7,4 +7,6 @@ type ValidateUser interface {
 	ValidateRegisterPayload(payload *entity.RegisterUserPayload)
 	ValidateLoginPayload(payload *entity.LoginUserPayload)
 	ValidateUpdatePayload(payload *entity.UpdateUserPayload)
+	ValidateDeletePayload(payload *entity.DeleteUserPayload)
+	ValidatePasswordResetPayload(payload *entity.PasswordResetPayload)
 }
@@ -0,0 +1,8 @@
+package validation
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// ValidateWebhook interface defines methods for validating webhook-related payloads.
+type ValidateWebhook interface {
+	ValidatePayload(payload *entity.WebhookPayload)
+}
@@ -0,0 +1,41 @@
+package webhook
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Shared address space (RFC 6598), used by carriers and cloud providers for their internal networks
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicAddress tells whether the address is reachable on the internet,
+// so a delivery can't be pointed at the server itself or the network it runs in.
+func IsPublicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		ip.IsUnspecified() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// IsInternalUrl tells whether the URL names an internal host literally, an address that isn't public or localhost.
+// Names resolving to internal addresses are only caught when delivering.
+func IsInternalUrl(rawUrl string) bool {
+	parsed, err := url.Parse(rawUrl)
+	if err != nil {
+		return false
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(parsed.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+
+	return ip != nil && !IsPublicAddress(ip)
+}
@@ -0,0 +1,47 @@
+package webhook_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/webhook"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	t.Run("Should refuse loopback, private, link-local and unspecified addresses", func(t *testing.T) {
+		for _, address := range []string{
+			"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254",
+			"0.0.0.0", "::1", "::", "fc00::1", "fe80::1", "::ffff:127.0.0.1", "224.0.0.1", "100.64.0.1",
+		} {
+			assert.False(t, webhook.IsPublicAddress(net.ParseIP(address)), address)
+		}
+	})
+
+	t.Run("Should accept public addresses", func(t *testing.T) {
+		for _, address := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+			assert.True(t, webhook.IsPublicAddress(net.ParseIP(address)), address)
+		}
+	})
+}
+
+func TestIsInternalUrl(t *testing.T) {
+	t.Run("Should catch literal internal hosts", func(t *testing.T) {
+		for _, url := range []string{
+			"http://169.254.169.254/latest/meta-data",
+			"http://localhost:6379",
+			"http://LOCALHOST./",
+			"http://api.localhost/hooks",
+			"http://[::1]:8080/",
+			"http://10.0.0.5/hooks",
+		} {
+			assert.True(t, webhook.IsInternalUrl(url), url)
+		}
+	})
+
+	t.Run("Should let public hosts through", func(t *testing.T) {
+		for _, url := range []string{"https://example.com/hooks", "http://93.184.216.34/hooks"} {
+			assert.False(t, webhook.IsInternalUrl(url), url)
+		}
+	})
+}
@@ -0,0 +1,10 @@
+package webhook
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// WebhookSender sends a webhook delivery to its receiver.
+type WebhookSender interface {
+	// Send posts the delivery payload to delivery.Url, signed with delivery.Secret.
+	// It should not panic when the receiver is unreachable, the failure is returned in WebhookResponse.Error instead.
+	Send(delivery *entity.WebhookDelivery) *entity.WebhookResponse
+}
@@ -46,6 +46,49 @@ type Config struct {
 	MinioSecretKey string `mapstructure:"MINIO_SECRET_KEY"`
 	MinioBucket    string `mapstructure:"MINIO_BUCKET"`
 	MinioLocation  string `mapstructure:"MINIO_LOCATION"`
+
+	// Webhook
+	WebhookMaxAttempts    int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBackoffBase    time.Duration `mapstructure:"WEBHOOK_BACKOFF_BASE"`
+	WebhookTimeout        time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookWorkerInterval time.Duration `mapstructure:"WEBHOOK_WORKER_INTERVAL"`
+	WebhookAllowPrivate   bool          `mapstructure:"WEBHOOK_ALLOW_PRIVATE"` // Lets deliveries reach internal addresses, local development only
+
+	// Mail
+	MailDriver      string `mapstructure:"MAIL_DRIVER"` // "file" or "smtp"
+	MailFrom        string `mapstructure:"MAIL_FROM"`
+	MailFileSinkDir string `mapstructure:"MAIL_FILE_SINK_DIR"`
+	SmtpHost        string `mapstructure:"SMTP_HOST"`
+	SmtpPort        string `mapstructure:"SMTP_PORT"`
+	SmtpUsername    string `mapstructure:"SMTP_USERNAME"`
+	SmtpPassword    string `mapstructure:"SMTP_PASSWORD"`
+
+	// Digest
+	DigestWorkerInterval time.Duration `mapstructure:"DIGEST_WORKER_INTERVAL"`
+	DigestDueSoonDays    int           `mapstructure:"DIGEST_DUE_SOON_DAYS"`
+
+	// Trash
+	TrashRetention      time.Duration `mapstructure:"TRASH_RETENTION"` // Deleted projects and tasks are purged after this
+	PurgeWorkerInterval time.Duration `mapstructure:"PURGE_WORKER_INTERVAL"`
+
+	// Invitation
+	InvitationTTL     time.Duration `mapstructure:"INVITATION_TTL"`
+	InviteTokenSecret string        `mapstructure:"INVITE_TOKEN_SECRET"` // Defaults to the access token private key
+
+	// Account
+	AccountDeletionGracePeriod time.Duration `mapstructure:"ACCOUNT_DELETION_GRACE_PERIOD"` // Deleted accounts can be restored until then
+	AccountExportTTL           time.Duration `mapstructure:"ACCOUNT_EXPORT_TTL"`            // Export archives are removed after this
+	AccountWorkerInterval      time.Duration `mapstructure:"ACCOUNT_WORKER_INTERVAL"`
+
+	// Admin
+	PasswordResetTTL time.Duration `mapstructure:"PASSWORD_RESET_TTL"` // Forced password reset links expire after this
+	OrphanedFileAge  time.Duration `mapstructure:"ORPHANED_FILE_AGE"`  // Unreferenced files younger than this may still be in use
+
+	// Upload
+	UploadScanner       string        `mapstructure:"UPLOAD_SCANNER"` // "none" or "clamav"
+	ClamAVAddress       string        `mapstructure:"CLAMAV_ADDRESS"`
+	ClamAVTimeout       time.Duration `mapstructure:"CLAMAV_TIMEOUT"`
+	UploadQuarantineDir string        `mapstructure:"UPLOAD_QUARANTINE_DIR"` // Files the scanner found a threat in are kept there
 }
 
 // LoadConfig loads configuration from the specified path.
@@ -60,6 +103,35 @@ func LoadConfig(path string) *Config {
 	viper.SetConfigName(".env")
 	viper.SetConfigType("env")
 
+	// Defaults for optional values
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("WEBHOOK_BACKOFF_BASE", "30s")
+	viper.SetDefault("WEBHOOK_TIMEOUT", "10s")
+	viper.SetDefault("WEBHOOK_WORKER_INTERVAL", "5s")
+	viper.SetDefault("WEBHOOK_ALLOW_PRIVATE", false)
+	viper.SetDefault("MAIL_DRIVER", "file")
+	viper.SetDefault("MAIL_FROM", "TaskPixie <no-reply@taskpixie.local>")
+	viper.SetDefault("MAIL_FILE_SINK_DIR", "mails")
+	viper.SetDefault("SMTP_HOST", "")
+	viper.SetDefault("SMTP_PORT", "587")
+	viper.SetDefault("SMTP_USERNAME", "")
+	viper.SetDefault("SMTP_PASSWORD", "")
+	viper.SetDefault("DIGEST_WORKER_INTERVAL", "5m")
+	viper.SetDefault("DIGEST_DUE_SOON_DAYS", 3)
+	viper.SetDefault("TRASH_RETENTION", "720h")
+	viper.SetDefault("PURGE_WORKER_INTERVAL", "1h")
+	viper.SetDefault("INVITATION_TTL", "168h")
+	viper.SetDefault("INVITE_TOKEN_SECRET", "")
+	viper.SetDefault("ACCOUNT_DELETION_GRACE_PERIOD", "336h")
+	viper.SetDefault("ACCOUNT_EXPORT_TTL", "168h")
+	viper.SetDefault("ACCOUNT_WORKER_INTERVAL", "1m")
+	viper.SetDefault("PASSWORD_RESET_TTL", "24h")
+	viper.SetDefault("ORPHANED_FILE_AGE", "1h")
+	viper.SetDefault("UPLOAD_SCANNER", "none")
+	viper.SetDefault("CLAMAV_ADDRESS", "localhost:3310")
+	viper.SetDefault("CLAMAV_TIMEOUT", "30s")
+	viper.SetDefault("UPLOAD_QUARANTINE_DIR", "quarantine")
+
 	// Read the .env file
 	err = viper.ReadInConfig()
 	if err != nil {
@@ -73,5 +145,9 @@ func LoadConfig(path string) *Config {
 		panic(fmt.Errorf("load_config_err: unmarshal: %v", err))
 	}
 
+	if cfg.InviteTokenSecret == "" {
+		cfg.InviteTokenSecret = cfg.AccessTokenPrivateKey
+	}
+
 	return &cfg
 }
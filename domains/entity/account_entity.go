@@ -0,0 +1,95 @@
+package entity
+
+// Statuses of an account export.
+const (
+	AccountExportPending = "pending"
+	AccountExportReady   = "ready"
+	AccountExportFailed  = "failed"
+)
+
+// DeleteUserPayload represents the payload for deleting the account, the password confirms it.
+type DeleteUserPayload struct {
+	Password string `json:"password"`
+}
+
+// AccountDeletion represents the scheduled deletion of an account.
+type AccountDeletion struct {
+	ScheduledAt string `json:"scheduledAt"` // The account is anonymized once it's passed
+}
+
+// AccountExport represents an archive of the data of a user, built in the background.
+type AccountExport struct {
+	Id          string `json:"id"`
+	UserId      string `json:"-"`
+	Status      string `json:"status"`
+	Error       string `json:"error,omitempty"`
+	FileName    string `json:"-"`           // Object of the archive once ready
+	CompletedAt string `json:"completedAt"` // Empty while pending
+	ExpiresAt   string `json:"expiresAt"`   // Empty while pending, the archive is removed once it's passed
+	Expired     bool   `json:"expired"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// AccountData represents everything exported from the account of a user.
+type AccountData struct {
+	Profile       *User                 `json:"profile"`
+	Organizations []AccountOrganization `json:"organizations"`
+	Projects      []AccountProject      `json:"projects"`
+	Tasks         []AccountTask         `json:"tasks"`
+	TimeEntries   []AccountTimeEntry    `json:"timeEntries"`
+	Views         []AccountView         `json:"views"`
+}
+
+// AccountOrganization represents an organization the user is a member of.
+type AccountOrganization struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joinedAt"`
+}
+
+// AccountProject represents a project the user owns or is a member of.
+type AccountProject struct {
+	Id           string `json:"id"`
+	Organization string `json:"organization"` // Organization name
+	Title        string `json:"title"`
+	Detail       string `json:"detail"`
+	Priority     string `json:"priority"`
+	Status       string `json:"status"`
+	Role         string `json:"role"`
+	CreatedAt    string `json:"createdAt"`
+}
+
+// AccountTask represents a task the user owns or is assigned to.
+type AccountTask struct {
+	Id          string `json:"id"`
+	Project     string `json:"project"` // Project title, empty for personal tasks
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Detail      string `json:"detail"`
+	Priority    string `json:"priority"`
+	Status      string `json:"status"`
+	DueDate     string `json:"dueDate"` // YYYY-MM-DD, empty when none
+	Owned       bool   `json:"owned"`
+	Assigned    bool   `json:"assigned"`
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// AccountTimeEntry represents time the user spent on a task.
+type AccountTimeEntry struct {
+	Id        string `json:"id"`
+	Task      string `json:"task"` // Task title
+	Note      string `json:"note"`
+	StartedAt string `json:"startedAt"`
+	EndedAt   string `json:"endedAt"` // Empty while running
+}
+
+// AccountView represents a task view saved by the user.
+type AccountView struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Query     string `json:"query"`
+	Project   string `json:"project"` // Title of the project it's shared with, empty when private
+	CreatedAt string `json:"createdAt"`
+}
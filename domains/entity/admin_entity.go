@@ -0,0 +1,116 @@
+package entity
+
+// Statuses of accounts, as filtered by administrators.
+const (
+	AccountStatusActive   = "active"
+	AccountStatusDisabled = "disabled"
+	AccountStatusDeleted  = "deleted"
+)
+
+// Actions written to the audit log.
+const (
+	AuditDisableUser        = "user.disable"
+	AuditEnableUser         = "user.enable"
+	AuditForcePasswordReset = "user.force_password_reset"
+	AuditPurgeFiles         = "files.purge"
+)
+
+// Types of the targets of audited actions.
+const (
+	AuditTargetUser = "user"
+	AuditTargetFile = "file"
+)
+
+// AdminUserFilter represents the query parameters of the list of users, every filter is optional.
+type AdminUserFilter struct {
+	Query  string `query:"q"`      // Part of the username, email or display name
+	Status string `query:"status"` // active, disabled or deleted
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+// AdminUser represents a user as seen by administrators.
+type AdminUser struct {
+	Id                    string `json:"id"`
+	Username              string `json:"username"`
+	Email                 string `json:"email"`
+	DisplayName           string `json:"displayName"`
+	IsAdmin               bool   `json:"isAdmin"`
+	Status                string `json:"status"`
+	DisabledAt            string `json:"disabledAt"` // Empty when not disabled
+	PasswordResetRequired bool   `json:"passwordResetRequired"`
+	DeletionScheduledAt   string `json:"deletionScheduledAt"` // Empty when not scheduled
+	Projects              int    `json:"projects"`            // Owned projects
+	Tasks                 int    `json:"tasks"`               // Owned tasks
+}
+
+// AdminUserList represents a page of users, with the number of every user matching the filter.
+type AdminUserList struct {
+	Users []AdminUser `json:"users"`
+	Total int         `json:"total"`
+}
+
+// SystemStats represents the system-wide counts shown to administrators.
+type SystemStats struct {
+	Users                    int `json:"users"` // Deleted accounts excluded
+	DisabledUsers            int `json:"disabledUsers"`
+	DeletedUsers             int `json:"deletedUsers"`
+	Admins                   int `json:"admins"`
+	Organizations            int `json:"organizations"`
+	Projects                 int `json:"projects"` // Trash and templates excluded
+	Tasks                    int `json:"tasks"`    // Trash excluded
+	OpenTasks                int `json:"openTasks"`
+	TimeEntries              int `json:"timeEntries"`
+	Webhooks                 int `json:"webhooks"`
+	PendingWebhookDeliveries int `json:"pendingWebhookDeliveries"`
+	PendingExports           int `json:"pendingExports"`
+}
+
+// StoredFile represents an object of the file store.
+type StoredFile struct {
+	Name         string `json:"name"`
+	Size         int64  `json:"size"`
+	LastModified string `json:"lastModified"`
+}
+
+// OrphanedFiles represents the objects of the file store nothing references anymore.
+type OrphanedFiles struct {
+	Files []StoredFile `json:"files"`
+	Size  int64        `json:"size"` // Total size, in bytes
+}
+
+// AuditLogFilter represents the query parameters of the audit log, every filter is optional.
+type AuditLogFilter struct {
+	ActorId    string `query:"actorId"`
+	Action     string `query:"action"`
+	TargetType string `query:"targetType"`
+	TargetId   string `query:"targetId"`
+	Limit      int    `query:"limit"`
+	Offset     int    `query:"offset"`
+}
+
+// AuditLog represents an action of an administrator.
+type AuditLog struct {
+	Id         int64                  `json:"id"`
+	ActorId    string                 `json:"actorId"` // Empty once the actor is deleted
+	Actor      string                 `json:"actor"`   // Username of the actor
+	Action     string                 `json:"action"`
+	TargetType string                 `json:"targetType"`
+	TargetId   string                 `json:"targetId"`
+	Detail     map[string]interface{} `json:"detail"`
+	CreatedAt  string                 `json:"createdAt"`
+}
+
+// PasswordResetPayload represents the payload for choosing a new password with the emailed token.
+type PasswordResetPayload struct {
+	Token           string `json:"token"`
+	Password        string `json:"password"`
+	ConfirmPassword string `json:"confirmPassword"`
+}
+
+// PasswordResetMail represents the content of the email asking the user to reset their password.
+type PasswordResetMail struct {
+	Username  string
+	Link      string // Link to choose a new password, carrying the token
+	ExpiresAt string
+}
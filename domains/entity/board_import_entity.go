@@ -0,0 +1,49 @@
+package entity
+
+import "mime/multipart"
+
+// BoardImportPayload represents the multipart form importing a board exported from another tool into a project.
+type BoardImportPayload struct {
+	File    *multipart.FileHeader
+	Source  string `form:"source"`  // trello or jira
+	Mapping string `form:"mapping"` // JSON BoardImportMapping, the suggestions of the preview by default
+}
+
+// BoardImportMapping overrides the suggestions of the preview.
+type BoardImportMapping struct {
+	Statuses map[string]string `json:"statuses"` // State ID to task status
+	Members  map[string]string `json:"members"`  // Member ID to username or email, empty to leave unassigned
+	DueDate  string            `json:"dueDate"`  // YYYY-MM-DD given to the cards without one
+}
+
+// BoardImportReport represents the preview of a board import, or its outcome once committed.
+type BoardImportReport struct {
+	Source   string                `json:"source"`
+	Board    string                `json:"board"`
+	Imported bool                  `json:"imported"`
+	Total    int                   `json:"total"`
+	Skipped  int                   `json:"skipped"` // Archived cards
+	Failed   int                   `json:"failed"`
+	States   []BoardImportState    `json:"states"`
+	Members  []BoardImportMember   `json:"members"`
+	Labels   []string              `json:"labels"`
+	Warnings []string              `json:"warnings"`
+	Rows     []TaskImportRowResult `json:"rows"`
+}
+
+// BoardImportState represents a list or status of the board, and the task status it's mapped to.
+type BoardImportState struct {
+	Id     string `json:"id"`
+	Name   string `json:"name"`
+	Tasks  int    `json:"tasks"`
+	Status string `json:"status"`
+}
+
+// BoardImportMember represents a member of the board, and the user of the project it's mapped to.
+type BoardImportMember struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	UserId   string `json:"userId"` // Empty when unmatched, the member's cards are left unassigned
+	Username string `json:"username"`
+}
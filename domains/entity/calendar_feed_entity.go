@@ -0,0 +1,41 @@
+package entity
+
+// Kinds of entries of a calendar feed, events show in every calendar app, to-dos only in the ones with tasks.
+const (
+	CalendarFeedEvents = "events"
+	CalendarFeedTodos  = "todos"
+)
+
+// CalendarFeedPayload represents the payload for creating a calendar feed.
+// Without project, the feed publishes the tasks the user owns or is assigned to.
+type CalendarFeedPayload struct {
+	ProjectId string `json:"projectId"`
+}
+
+// CalendarFeed represents a secret calendar URL of a user, its token is only known when created.
+type CalendarFeed struct {
+	Id         string `json:"id"`
+	UserId     string `json:"userId"`
+	ProjectId  string `json:"projectId"` // Empty for the feed of the user's own tasks
+	Project    string `json:"project"`   // Project name
+	LastUsedAt string `json:"lastUsedAt"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// CalendarFeedFilter represents the query of a calendar feed.
+type CalendarFeedFilter struct {
+	Kind string `query:"kind"` // events (default) or todos
+}
+
+// CalendarTask represents a task with a due date, as published by calendar feeds.
+type CalendarTask struct {
+	Id          string
+	Title       string
+	Description string
+	Priority    string
+	Status      string
+	Project     string // Project name, empty for tasks outside of any project
+	DueDate     string // YYYY-MM-DD
+	UpdatedAt   string // RFC 3339 in UTC
+	Version     int
+}
@@ -0,0 +1,52 @@
+package entity
+
+// Frequencies of the email digest.
+const (
+	DigestDaily  = "daily"
+	DigestWeekly = "weekly"
+)
+
+// DigestSubscriptionPayload represents the payload for opting in to the email digest.
+type DigestSubscriptionPayload struct {
+	Frequency string `json:"frequency"` // daily or weekly
+	TimeZone  string `json:"timeZone"`  // IANA time zone, e.g. Asia/Jakarta
+	SendHour  int    `json:"sendHour"`  // Local hour (0-23) when the digest is sent
+}
+
+// DigestSubscription represents a user who opted in to the email digest.
+type DigestSubscription struct {
+	UserId    string `json:"userId"`
+	Username  string `json:"username"`
+	Email     string `json:"email"`
+	Frequency string `json:"frequency"`
+	TimeZone  string `json:"timeZone"`
+	SendHour  int    `json:"sendHour"`
+}
+
+// DigestTask represents an open task assigned to the digest recipient.
+type DigestTask struct {
+	Id       string
+	Title    string
+	Priority string
+	Status   string
+	Project  string
+	DueDate  string // YYYY-MM-DD, empty if the task has no due date
+}
+
+// Digest is the content of a single email digest.
+type Digest struct {
+	Username    string
+	Frequency   string
+	PeriodLabel string // Human friendly period, e.g. "Monday, 3 June 2024" or "Week 23, 2024"
+	Assigned    []DigestTask
+	DueSoon     []DigestTask
+	Overdue     []DigestTask
+}
+
+// MailMessage represents an email with HTML and plain-text alternatives.
+type MailMessage struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+}
@@ -0,0 +1,47 @@
+package entity
+
+// Statuses of a project invitation.
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationDeclined = "declined"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+// InvitationPayload represents the payload for inviting someone to a project.
+type InvitationPayload struct {
+	Invitee string `json:"invitee"` // Username, or email of a registered or not yet registered user
+	Role    string `json:"role"`    // admin or member, defaults to member
+}
+
+// InvitationTokenPayload represents the payload for answering an invitation from its emailed link.
+type InvitationTokenPayload struct {
+	Token string `json:"token"`
+}
+
+// Invitation represents an invitation to join a project.
+type Invitation struct {
+	Id        string `json:"id"`
+	ProjectId string `json:"projectId"`
+	Project   string `json:"project"` // Project name
+	Email     string `json:"email"`
+	UserId    string `json:"-"`        // Empty until the invitee is known to be registered
+	Username  string `json:"username"` // Empty when the invitee is not registered yet
+	Role      string `json:"role"`
+	Status    string `json:"status"`
+	InvitedBy string `json:"invitedBy"` // Username of the inviter
+	Expired   bool   `json:"-"`
+	ExpiresAt string `json:"expiresAt"`
+	CreatedAt string `json:"createdAt"`
+}
+
+// InvitationMail represents the content of the invitation email.
+type InvitationMail struct {
+	Project    string
+	InvitedBy  string
+	Role       string
+	Link       string // Link to answer the invitation, carrying the signed token
+	Registered bool   // Whether the invitee already has an account
+	ExpiresAt  string
+}
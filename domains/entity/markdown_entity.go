@@ -0,0 +1,8 @@
+package entity
+
+// Markdown represents a Markdown source rendered to HTML.
+type Markdown struct {
+	HTML      string   // Sanitized, safe to insert into pages as is
+	TaskLinks []string // IDs of the tasks linked with #<taskId>
+	Mentions  []string // Usernames mentioned with @username
+}
@@ -0,0 +1,79 @@
+package entity
+
+// States of a milestone, a project has at most one active milestone.
+const (
+	MilestoneStatePlanned = "planned"
+	MilestoneStateActive  = "active"
+	MilestoneStateClosed  = "closed"
+)
+
+// Changes of the scope of a milestone.
+const (
+	ScopeChangeAdded   = "added"
+	ScopeChangeRemoved = "removed"
+)
+
+// MilestonePayload represents the payload for creating or updating a milestone, dates are YYYY-MM-DD.
+type MilestonePayload struct {
+	Name      string `json:"name"`
+	Goal      string `json:"goal"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+// CloseMilestonePayload represents the payload for closing a milestone.
+// Unfinished tasks are carried to the next milestone, the earliest planned one of the project by default.
+type CloseMilestonePayload struct {
+	NextMilestoneId string `json:"nextMilestoneId"`
+}
+
+// Milestone represents a sprint of a project, times are RFC 3339 in UTC.
+type Milestone struct {
+	Id          string `json:"id"`
+	ProjectId   string `json:"projectId"`
+	Name        string `json:"name"`
+	Goal        string `json:"goal"`
+	StartDate   string `json:"startDate"`
+	EndDate     string `json:"endDate"`
+	State       string `json:"state"`
+	ActivatedAt string `json:"activatedAt"` // Empty until started
+	ClosedAt    string `json:"closedAt"`    // Empty until closed
+	Tasks       int    `json:"tasks"`
+	Completed   int    `json:"completed"`
+	CreatedAt   string `json:"createdAt"`
+}
+
+// MilestoneTask represents a task inside the scope of a milestone at some point.
+type MilestoneTask struct {
+	TaskId   string `json:"taskId"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Estimate int    `json:"estimate"` // Minutes
+}
+
+// ScopeChange represents a task added to or removed from a milestone.
+type ScopeChange struct {
+	TaskId    string `json:"taskId"`
+	Title     string `json:"title"`
+	Change    string `json:"change"` // added or removed
+	ChangedAt string `json:"changedAt"`
+}
+
+// SprintWork represents an amount of work, in tasks and estimated minutes.
+type SprintWork struct {
+	Tasks    int `json:"tasks"`
+	Estimate int `json:"estimate"`
+}
+
+// SprintReport represents the work committed when the milestone started against the work completed by its end,
+// or by now while it's active.
+type SprintReport struct {
+	Milestone    Milestone       `json:"milestone"`
+	Committed    SprintWork      `json:"committed"`
+	Completed    SprintWork      `json:"completed"`
+	Remaining    SprintWork      `json:"remaining"` // Unfinished tasks of the final scope, carried over on close
+	Added        int             `json:"added"`
+	Removed      int             `json:"removed"`
+	ScopeChanges []ScopeChange   `json:"scopeChanges"`
+	Tasks        []MilestoneTask `json:"tasks"` // Final scope
+}
@@ -0,0 +1,40 @@
+package entity
+
+// Roles of a user inside an organization.
+// Owners and admins manage the member directory and see every project of the organization.
+const (
+	OrganizationRoleOwner  = "owner"
+	OrganizationRoleAdmin  = "admin"
+	OrganizationRoleMember = "member"
+)
+
+// OrganizationPayload represents the payload for creating or updating an organization.
+type OrganizationPayload struct {
+	Name string `json:"name"`
+}
+
+// OrganizationMemberPayload represents the payload for adding a member or changing the role of a member.
+type OrganizationMemberPayload struct {
+	UserId string `json:"userId"`
+	Role   string `json:"role"` // admin or member
+}
+
+// Organization represents a tenant owning projects.
+// Every user has a personal workspace, which can't be deleted.
+type Organization struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	IsPersonal bool   `json:"isPersonal"`
+	Role       string `json:"role"` // Role of the requesting user
+	CreatedAt  string `json:"createdAt"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+// OrganizationMember represents an entry of the member directory.
+type OrganizationMember struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joinedAt"`
+}
@@ -1,28 +1,64 @@
 ﻿package entity
 
+// Roles of a user inside a project.
+// The owner is the user who created the project, admins are members allowed to manage it.
+const (
+	ProjectRoleOwner  = "owner"
+	ProjectRoleAdmin  = "admin"
+	ProjectRoleMember = "member"
+)
+
 // ProjectPayload represents the payload for creating or updating a project.
+// Members join through invitations, see InvitationPayload.
 type ProjectPayload struct {
-	Title     string   `json:"title"`
-	Detail    string   `json:"detail"`
-	Priority  string   `json:"priority"`
-	Status    string   `json:"status"`
-	MembersId []string `json:"members"` // User IDs
+	Title          string `json:"title"`
+	Detail         string `json:"detail"`
+	Priority       string `json:"priority"`
+	Status         string `json:"status"`
+	OrganizationId string `json:"organizationId"` // Only used on creation, defaults to the personal workspace
+}
+
+// MemberPayload represents the payload for adding a member or changing the role of a member.
+type MemberPayload struct {
+	UserId string `json:"userId"`
+	Role   string `json:"role"` // admin or member
+}
+
+// TransferOwnershipPayload represents the payload for handing the project over to one of its members.
+type TransferOwnershipPayload struct {
+	UserId string `json:"userId"`
+}
+
+// ProjectMember represents a user taking part in a project, including its owner.
+// Role is the effective one, the highest of the direct membership and the teams granted on the project.
+type ProjectMember struct {
+	Id       string   `json:"id"`
+	Username string   `json:"username"`
+	Role     string   `json:"role"`
+	Teams    []string `json:"teams"` // Teams the access comes from, empty for direct members only
 }
 
 // PreviewProject represents a brief overview of a project.
 type PreviewProject struct {
-	Id    string `json:"id"`
-	Title string `json:"title"`
+	Id             string `json:"id"`
+	Title          string `json:"title"`
+	OrganizationId string `json:"organizationId"`
 }
 
 // Project represents the detailed view of a project in the system.
 type Project struct {
 	Id              string   `json:"id"`
+	OrganizationId  string   `json:"organizationId"`
 	Title           string   `json:"title"`
-	Detail          string   `json:"detail"`
+	Detail          string   `json:"detail"`     // Markdown source
+	DetailHTML      string   `json:"detailHtml"` // Detail rendered to sanitized HTML
+	TaskLinks       []string `json:"taskLinks"`  // IDs of the tasks linked by the detail
+	Mentions        []string `json:"mentions"`   // Usernames mentioned by the detail
 	Priority        string   `json:"priority"`
 	Status          string   `json:"status"`
-	MembersUsername []string `json:"members"` // Usernames or User IDs as needed
+	MembersUsername []string `json:"members"`    // Usernames or User IDs as needed
+	ArchivedAt      string   `json:"archivedAt"` // Empty when the project is not archived
 	CreatedAt       string   `json:"createdAt"`
 	UpdatedAt       string   `json:"updatedAt"`
+	Version         int      `json:"version"` // Changes on every change of the project, see the ETag
 }
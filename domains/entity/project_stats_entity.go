@@ -0,0 +1,61 @@
+package entity
+
+// ProjectStatsFilter represents the query parameters of the project statistics.
+// The range of the series defaults to the last 30 days.
+type ProjectStatsFilter struct {
+	From string `query:"from"` // YYYY-MM-DD (UTC), inclusive
+	To   string `query:"to"`   // YYYY-MM-DD (UTC), inclusive
+}
+
+// TaskCount represents the number of tasks of a project sharing a status and a priority.
+type TaskCount struct {
+	Status   string
+	Priority string
+	Count    int
+	Overdue  int
+}
+
+// DailyStatusCount represents the number of tasks of a project in a status at the end of a day.
+type DailyStatusCount struct {
+	Date   string
+	Status string
+	Count  int
+}
+
+// AssigneeWorkload represents the tasks of a project assigned to a user.
+type AssigneeWorkload struct {
+	UserId    string `json:"userId"`
+	Username  string `json:"username"`
+	Open      int    `json:"open"`
+	Completed int    `json:"completed"`
+	Overdue   int    `json:"overdue"`
+	Estimate  int    `json:"estimate"` // Minutes estimated for the open tasks
+}
+
+// CumulativeFlowPoint represents the number of tasks by status at the end of a day.
+type CumulativeFlowPoint struct {
+	Date     string         `json:"date"`
+	ByStatus map[string]int `json:"byStatus"`
+}
+
+// BurndownPoint represents the tasks left to do at the end of a day, along with the ideal pace.
+type BurndownPoint struct {
+	Date      string  `json:"date"`
+	Remaining int     `json:"remaining"`
+	Ideal     float64 `json:"ideal"`
+}
+
+// ProjectStats represents the dashboard of a project.
+// The series come from the history of the tasks, days are UTC.
+type ProjectStats struct {
+	Total          int                   `json:"total"`
+	ByStatus       map[string]int        `json:"byStatus"`
+	ByPriority     map[string]int        `json:"byPriority"`
+	Overdue        int                   `json:"overdue"`
+	CompletionRate float64               `json:"completionRate"` // Completed out of the tasks not canceled, from 0 to 1
+	Workload       []AssigneeWorkload    `json:"workload"`
+	From           string                `json:"from"`
+	To             string                `json:"to"`
+	CumulativeFlow []CumulativeFlowPoint `json:"cumulativeFlow"`
+	Burndown       []BurndownPoint       `json:"burndown"`
+}
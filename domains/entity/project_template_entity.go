@@ -0,0 +1,28 @@
+package entity
+
+// CloneProjectPayload represents the options for copying a project, or instantiating a template.
+type CloneProjectPayload struct {
+	Title          string `json:"title"`
+	OrganizationId string `json:"organizationId"` // Defaults to the organization of the copied project
+	StartDate      string `json:"startDate"`      // Due dates are shifted so the earliest one falls on it (YYYY-MM-DD)
+	IncludeTasks   bool   `json:"includeTasks"`
+	IncludeMembers bool   `json:"includeMembers"` // Members and teams, plus task assignees along with the tasks
+	ResetStatus    bool   `json:"resetStatus"`    // Copied tasks start as "To Do"
+}
+
+// ProjectTemplatePayload represents the payload for saving a project as a template.
+type ProjectTemplatePayload struct {
+	Title string `json:"title"`
+}
+
+// ProjectTemplate represents a reusable project skeleton of an organization, along with its tasks.
+type ProjectTemplate struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organizationId"`
+	Title          string `json:"title"`
+	Detail         string `json:"detail"`
+	TasksCount     int    `json:"tasksCount"`
+	OwnerId        string `json:"ownerId"`
+	CreatedBy      string `json:"createdBy"` // Username
+	CreatedAt      string `json:"createdAt"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+// Types of resource returned by the search.
+const (
+	SearchTypeTask    = "task"
+	SearchTypeProject = "project"
+)
+
+// SearchPayload represents the query parameters of the search.
+type SearchPayload struct {
+	Query  string `query:"q"`
+	Type   string `query:"type"` // Optional, "task" or "project"
+	Limit  int    `query:"limit"`
+	Offset int    `query:"offset"`
+}
+
+// SearchResult represents a single ranked search hit.
+// Title and Snippet are HTML escaped, matched terms are wrapped in <mark>.
+type SearchResult struct {
+	Type      string  `json:"type"`
+	Id        string  `json:"id"`
+	Title     string  `json:"title"`
+	Snippet   string  `json:"snippet"`
+	ProjectId string  `json:"projectId"`
+	Rank      float64 `json:"rank"`
+}
@@ -0,0 +1,39 @@
+package entity
+
+// Operations of a bulk task request.
+const (
+	TaskBulkSetStatus      = "set-status"
+	TaskBulkSetPriority    = "set-priority"
+	TaskBulkAddAssignee    = "add-assignee"
+	TaskBulkRemoveAssignee = "remove-assignee"
+	TaskBulkMoveToProject  = "move-to-project"
+	TaskBulkDelete         = "delete"
+)
+
+// Outcomes of a task inside a bulk report.
+// Tasks are skipped when another task of the request failed, nothing is applied then.
+const (
+	TaskBulkResultApplied = "applied"
+	TaskBulkResultFailed  = "failed"
+	TaskBulkResultSkipped = "skipped"
+)
+
+// TaskBulkPayload represents a single operation applied to many tasks at once.
+type TaskBulkPayload struct {
+	TaskIds   []string `json:"taskIds"`
+	Operation string   `json:"operation"`
+	Value     string   `json:"value"` // Status, priority, user ID or project ID, empty to delete
+}
+
+// TaskBulkResult represents the outcome of the operation for one task.
+type TaskBulkResult struct {
+	TaskId  string `json:"taskId"`
+	Result  string `json:"result"`
+	Message string `json:"message,omitempty"` // Why the task failed
+}
+
+// TaskBulkReport represents the outcome of a bulk request, either every task is applied or none.
+type TaskBulkReport struct {
+	Applied bool             `json:"applied"`
+	Results []TaskBulkResult `json:"results"`
+}
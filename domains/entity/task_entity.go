@@ -1,5 +1,13 @@
 ﻿package entity
 
+// Statuses of a task, completed and canceled tasks are done.
+const (
+	TaskStatusToDo       = "To Do"
+	TaskStatusInProgress = "In Progress"
+	TaskStatusCompleted  = "Completed"
+	TaskStatusCanceled   = "Canceled"
+)
+
 // TaskPayload represents the payload for creating or updating a task.
 type TaskPayload struct {
 	Title        string   `json:"title"`
@@ -9,7 +17,9 @@ type TaskPayload struct {
 	Status       string   `json:"status"`
 	ProjectId    string   `json:"projectId"`
 	DueDate      string   `json:"dueDate"`
-	AssignedToId []string `json:"assignedTo"` // User IDs
+	AssignedToId []string `json:"assignedTo"`  // User IDs
+	Estimate     int      `json:"estimate"`    // Minutes, 0 when not estimated
+	MilestoneId  string   `json:"milestoneId"` // Milestone of the task's project, optional
 }
 
 // PreviewTask represents a brief overview of a task.
@@ -27,13 +37,22 @@ type Task struct {
 	ID                  string   `json:"id"`
 	Title               string   `json:"title"`
 	Description         string   `json:"description"`
-	Detail              string   `json:"detail"`
+	Detail              string   `json:"detail"`     // Markdown source
+	DetailHTML          string   `json:"detailHtml"` // Detail rendered to sanitized HTML
+	TaskLinks           []string `json:"taskLinks"`  // IDs of the tasks linked by the detail
+	Mentions            []string `json:"mentions"`   // Usernames mentioned by the detail
 	Priority            string   `json:"priority"`
 	Status              string   `json:"status"`
 	Project             string   `json:"project"`
 	AssignedToUsernames []string `json:"assignedTo"` // Usernames
+	AssignedToIds       []string `json:"assignedToIds"`
 	DueDate             string   `json:"dueDate"`
 	CreatedAt           string   `json:"createdAt"`
 	UpdatedAt           string   `json:"updatedAt"`
 	ProjectId           string   `json:"projectId"`
+	OwnerId             string   `json:"ownerId"`
+	Version             int      `json:"version"`   // Changes on every change of the task, see the ETag
+	Estimate            int      `json:"estimate"`  // Minutes, 0 when not estimated
+	TimeSpent           int      `json:"timeSpent"` // Minutes tracked by everyone, running timers included up to now
+	MilestoneId         string   `json:"milestoneId"`
 }
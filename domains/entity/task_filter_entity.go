@@ -0,0 +1,77 @@
+package entity
+
+// Comparison operators used by TaskFilterDue.
+const (
+	TaskFilterEqual        = "="
+	TaskFilterLess         = "<"
+	TaskFilterLessEqual    = "<="
+	TaskFilterGreater      = ">"
+	TaskFilterGreaterEqual = ">="
+)
+
+// Users matched by TaskFilterUser.
+const (
+	TaskFilterAssignee = "assignee"
+	TaskFilterOwner    = "owner"
+)
+
+// TaskFilter is the syntax tree of a task filter expression like
+// `status:"In Progress" priority:Urgent due:<7d assignee:@me`.
+// All conditions must be met by the task.
+type TaskFilter struct {
+	Conditions []TaskFilterCondition
+}
+
+// TaskFilterCondition is implemented by every node of the TaskFilter.
+type TaskFilterCondition interface {
+	isTaskFilterCondition()
+}
+
+// TaskFilterNot negates its condition, written with a leading "-", e.g. `-status:Completed`.
+type TaskFilterNot struct {
+	Condition TaskFilterCondition
+}
+
+// TaskFilterStatus matches tasks having any of the statuses.
+type TaskFilterStatus struct {
+	Statuses []string
+}
+
+// TaskFilterPriority matches tasks having any of the priorities.
+type TaskFilterPriority struct {
+	Priorities []string
+}
+
+// TaskFilterProject matches tasks inside any of the projects, referenced by ID or title.
+type TaskFilterProject struct {
+	Projects []string
+}
+
+// TaskFilterUser matches tasks assigned to, or owned by, any of the users.
+// Me refers to the user running the filter (written as "@me").
+type TaskFilterUser struct {
+	Role      string // TaskFilterAssignee or TaskFilterOwner
+	Me        bool
+	Usernames []string
+}
+
+// TaskFilterDue compares the due date of tasks.
+type TaskFilterDue struct {
+	Operator string
+	None     bool   // Matches tasks without due date, written as "due:none"
+	Days     int    // Days from today, used when Date is empty
+	Date     string // Absolute date (YYYY-MM-DD)
+}
+
+// TaskFilterText matches tasks containing the text in their title or description.
+type TaskFilterText struct {
+	Text string
+}
+
+func (TaskFilterNot) isTaskFilterCondition()      {}
+func (TaskFilterStatus) isTaskFilterCondition()   {}
+func (TaskFilterPriority) isTaskFilterCondition() {}
+func (TaskFilterProject) isTaskFilterCondition()  {}
+func (TaskFilterUser) isTaskFilterCondition()     {}
+func (TaskFilterDue) isTaskFilterCondition()      {}
+func (TaskFilterText) isTaskFilterCondition()     {}
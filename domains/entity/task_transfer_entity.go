@@ -0,0 +1,55 @@
+package entity
+
+import "mime/multipart"
+
+// Formats of task exports and imports.
+const (
+	TransferFormatCSV  = "csv"
+	TransferFormatJSON = "json"
+)
+
+// ExportedTask represents a task of a project as exported, the JSON format of imports is the same.
+type ExportedTask struct {
+	Id          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Detail      string   `json:"detail"`
+	Priority    string   `json:"priority"`
+	Status      string   `json:"status"`
+	DueDate     string   `json:"dueDate"`   // YYYY-MM-DD, empty when none
+	Estimate    int      `json:"estimate"`  // Minutes, 0 when not estimated
+	Milestone   string   `json:"milestone"` // Milestone name
+	Assignees   []string `json:"assignees"` // Usernames
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+}
+
+// TaskExportFilter represents the query of a task export.
+type TaskExportFilter struct {
+	Format string `query:"format"` // csv (default) or json
+}
+
+// TaskImportPayload represents the multipart form importing tasks into a project.
+type TaskImportPayload struct {
+	File    *multipart.FileHeader
+	Format  string `form:"format"`  // csv (default) or json
+	Mapping string `form:"mapping"` // JSON object of task fields to CSV columns, the export's columns by default
+	DryRun  bool   `form:"dryRun"`  // Only checks the rows
+}
+
+// TaskImportReport represents the outcome of an import, rows are only imported when they're all valid.
+type TaskImportReport struct {
+	DryRun   bool                  `json:"dryRun"`
+	Imported bool                  `json:"imported"`
+	Total    int                   `json:"total"`
+	Failed   int                   `json:"failed"`
+	Rows     []TaskImportRowResult `json:"rows"`
+}
+
+// TaskImportRowResult represents the outcome of a single row of an import.
+type TaskImportRowResult struct {
+	Row    int      `json:"row"` // 1 is the first task of the file
+	Title  string   `json:"title"`
+	TaskId string   `json:"taskId,omitempty"` // Once imported
+	Errors []string `json:"errors,omitempty"`
+}
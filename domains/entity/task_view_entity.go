@@ -0,0 +1,20 @@
+package entity
+
+// TaskViewPayload represents the payload for saving or updating a view.
+type TaskViewPayload struct {
+	Name      string `json:"name"`
+	Query     string `json:"query"`     // Filter expression, e.g. `status:"In Progress" due:<7d assignee:@me`
+	ProjectId string `json:"projectId"` // Shares the view with the project members when not empty
+}
+
+// TaskView represents a saved task filter.
+// A view shared with a project only returns the tasks of that project.
+type TaskView struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Query     string `json:"query"`
+	OwnerId   string `json:"ownerId"`
+	ProjectId string `json:"projectId"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
@@ -0,0 +1,40 @@
+package entity
+
+// TeamPayload represents the payload for creating or renaming a team.
+type TeamPayload struct {
+	Name string `json:"name"`
+}
+
+// TeamMemberPayload represents the payload for adding an organization member to a team.
+type TeamMemberPayload struct {
+	UserId string `json:"userId"`
+}
+
+// ProjectTeamPayload represents the payload for granting a team a role on a project.
+type ProjectTeamPayload struct {
+	TeamId string `json:"teamId"`
+	Role   string `json:"role"` // admin or member
+}
+
+// Team represents a named group of organization members, granted access to projects at once.
+type Team struct {
+	Id             string `json:"id"`
+	OrganizationId string `json:"organizationId"`
+	Name           string `json:"name"`
+	MembersCount   int    `json:"membersCount"`
+	CreatedAt      string `json:"createdAt"`
+	UpdatedAt      string `json:"updatedAt"`
+}
+
+// TeamMember represents a user inside a team.
+type TeamMember struct {
+	Id       string `json:"id"`
+	Username string `json:"username"`
+}
+
+// ProjectTeam represents a team granted a role on a project.
+type ProjectTeam struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+// Formats of the time report.
+const (
+	TimeReportFormatJSON = "json"
+	TimeReportFormatCSV  = "csv"
+)
+
+// TimerPayload represents the payload for starting a timer on a task.
+type TimerPayload struct {
+	Note string `json:"note"`
+}
+
+// TimeEntryPayload represents a time entry added by hand, times are RFC 3339.
+type TimeEntryPayload struct {
+	StartedAt string `json:"startedAt"`
+	EndedAt   string `json:"endedAt"`
+	Note      string `json:"note"`
+}
+
+// TimeEntry represents time spent by a user on a task, running timers have no end yet.
+type TimeEntry struct {
+	Id        string `json:"id"`
+	TaskId    string `json:"taskId"`
+	Task      string `json:"task"` // Task title
+	ProjectId string `json:"projectId"`
+	UserId    string `json:"userId"`
+	Username  string `json:"username"`
+	Note      string `json:"note"`
+	StartedAt string `json:"startedAt"`
+	EndedAt   string `json:"endedAt"` // Empty while running
+	Minutes   int    `json:"minutes"` // Up to now while running
+	Running   bool   `json:"running"`
+}
+
+// TimeReportFilter represents the query parameters of the time report, every filter is optional.
+type TimeReportFilter struct {
+	ProjectId string `query:"projectId"`
+	UserId    string `query:"userId"`
+	From      string `query:"from"`   // YYYY-MM-DD (UTC), inclusive
+	To        string `query:"to"`     // YYYY-MM-DD (UTC), inclusive
+	Format    string `query:"format"` // json (default) or csv
+}
+
+// TimeReportRow represents the time spent by a user on a project during a day (UTC).
+type TimeReportRow struct {
+	Date      string `json:"date"`
+	ProjectId string `json:"projectId"` // Empty for tasks outside of any project
+	Project   string `json:"project"`
+	UserId    string `json:"userId"`
+	Username  string `json:"username"`
+	Minutes   int    `json:"minutes"`
+}
+
+// TimeReport represents the time spent matching a filter, along with its total.
+type TimeReport struct {
+	Rows         []TimeReportRow `json:"rows"`
+	TotalMinutes int             `json:"totalMinutes"`
+}
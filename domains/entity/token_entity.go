@@ -7,6 +7,7 @@ type TokenDetail struct {
 	Token     string // Token the actual access token or refresh token
 	TokenId   string // TokenId used for cache validation
 	ExpiresIn int64  // ExpiresIn the duration in seconds until the token expires
+	IssuedAt  int64  // IssuedAt the Unix time the token was created, older tokens are refused once the sessions are revoked
 	MaxAge    int    // MaxAge the maximum age of the token in seconds
 	UserToken *User  // User Information of the user to whom the token belongs
 }
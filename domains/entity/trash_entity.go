@@ -0,0 +1,22 @@
+package entity
+
+// TrashedProject represents a deleted project waiting to be purged.
+type TrashedProject struct {
+	Id        string `json:"id"`
+	Title     string `json:"title"`
+	DeletedAt string `json:"deletedAt"`
+}
+
+// TrashedTask represents a deleted task waiting to be purged.
+type TrashedTask struct {
+	Id        string `json:"id"`
+	Title     string `json:"title"`
+	Project   string `json:"project"` // Project name
+	DeletedAt string `json:"deletedAt"`
+}
+
+// Trash represents the deleted projects and tasks a user is able to restore.
+type Trash struct {
+	Projects []TrashedProject `json:"projects"`
+	Tasks    []TrashedTask    `json:"tasks"`
+}
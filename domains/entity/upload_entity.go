@@ -0,0 +1,11 @@
+package entity
+
+// QuarantinedFile represents an upload the scanner found a threat in, kept aside instead of being stored.
+type QuarantinedFile struct {
+	Name        string `json:"name"` // Sanitized name it was uploaded with
+	ContentType string `json:"contentType"`
+	Size        int    `json:"size"`
+	Threat      string `json:"threat"` // As named by the scanner
+	UploadedBy  string `json:"uploadedBy"`
+	Content     []byte `json:"-"`
+}
@@ -17,11 +17,36 @@ type LoginUserPayload struct {
 }
 
 type UpdateUserPayload struct {
-	Username        string `json:"username"`
-	Email           string `json:"email"`
-	Password        string `json:"password"`
-	ConfirmPassword string `json:"confirmPassword"`
-	Avatar          *multipart.FileHeader
+	Username         string `json:"username"`
+	Email            string `json:"email"`
+	Password         string `json:"password"`
+	ConfirmPassword  string `json:"confirmPassword"`
+	DisplayName      string `json:"displayName"`
+	Bio              string `json:"bio"`
+	TimeZone         string `json:"timeZone"`         // IANA name, defaults to UTC
+	Locale           string `json:"locale"`           // BCP 47 tag, defaults to en
+	DateFormat       string `json:"dateFormat"`       // One of the DateFormats, defaults to YYYY-MM-DD
+	EmailInvitations *bool  `json:"emailInvitations"` // Kept as is when not given
+	EmailDigests     *bool  `json:"emailDigests"`     // Kept as is when not given
+	Avatar           *multipart.FileHeader
+}
+
+// Date formats users can choose to show dates in
+const (
+	DateFormatISO      = "YYYY-MM-DD"
+	DateFormatDayFirst = "DD/MM/YYYY"
+	DateFormatUS       = "MM/DD/YYYY"
+	DateFormatDotted   = "DD.MM.YYYY"
+)
+
+// AvatarSizes are the sizes in pixels of the square variants of avatars, the largest one is the avatarLink.
+var AvatarSizes = []int{32, 64, 128, 256}
+
+// NotificationPreferences tells which emails the user accepts.
+// Pending invitations are still listed to registered users who turned their emails off.
+type NotificationPreferences struct {
+	EmailInvitations bool `json:"emailInvitations"`
+	EmailDigests     bool `json:"emailDigests"` // Subscriptions are kept but not sent when off
 }
 
 // User represents a user in the system.
@@ -30,4 +55,14 @@ type User struct {
 	Username   string `json:"username"`   // Username of the user, Username should be unique
 	Email      string `json:"email"`      // Email address of the user, Email should be unique
 	AvatarLink string `json:"avatarLink"` // AvatarLink to the user's avatar image
+
+	DisplayName string `json:"displayName"` // Name shown instead of the username when set
+	Bio         string `json:"bio"`
+	TimeZone    string `json:"timeZone"`   // IANA name, times are rendered in it with ?timeZone=local
+	Locale      string `json:"locale"`     // BCP 47 tag
+	DateFormat  string `json:"dateFormat"` // One of the DateFormats
+
+	// Only shown to the user themselves
+	Notifications       *NotificationPreferences `json:"notifications,omitempty"`
+	DeletionScheduledAt string                   `json:"deletionScheduledAt,omitempty"` // Set while the account waits to be deleted
 }
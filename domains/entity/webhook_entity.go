@@ -0,0 +1,69 @@
+package entity
+
+// Events that can be subscribed by a webhook.
+const (
+	WebhookEventTaskCreated    = "task.created"
+	WebhookEventTaskUpdated    = "task.updated"
+	WebhookEventTaskDeleted    = "task.deleted"
+	WebhookEventProjectUpdated = "project.updated"
+)
+
+// Statuses of a webhook delivery.
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliverySucceeded = "succeeded"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookPayload represents the payload for registering or updating a webhook.
+type WebhookPayload struct {
+	Url      string   `json:"url"`
+	Events   []string `json:"events"`
+	IsActive *bool    `json:"isActive"` // Defaults to true when omitted
+}
+
+// Webhook represents a registered webhook of a project.
+// Secret is only exposed once, right after the webhook is registered.
+type Webhook struct {
+	Id        string   `json:"id"`
+	ProjectId string   `json:"projectId"`
+	Url       string   `json:"url"`
+	Secret    string   `json:"-"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"isActive"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
+}
+
+// WebhookEvent is the JSON body sent to the webhook receiver.
+type WebhookEvent struct {
+	Event      string      `json:"event"`
+	ProjectId  string      `json:"projectId"`
+	OccurredAt string      `json:"occurredAt"`
+	Data       interface{} `json:"data"`
+}
+
+// WebhookDelivery represents a single delivery of an event to a webhook, including its last response.
+type WebhookDelivery struct {
+	Id            string `json:"id"`
+	WebhookId     string `json:"webhookId"`
+	Event         string `json:"event"`
+	Payload       string `json:"payload"` // Raw JSON body of WebhookEvent
+	Status        string `json:"status"`
+	Attempts      int    `json:"attempts"`
+	ResponseCode  int    `json:"responseCode"`
+	Error         string `json:"error"`
+	NextAttemptAt string `json:"nextAttemptAt"`
+	DeliveredAt   string `json:"deliveredAt"`
+	CreatedAt     string `json:"createdAt"`
+
+	// Target of the delivery, only filled when the delivery is claimed by the worker
+	Url    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// WebhookResponse is the outcome of sending a delivery to its receiver.
+type WebhookResponse struct {
+	StatusCode int
+	Error      string // Transport error, empty when the receiver responded
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
+
+// AccountRepository defines methods for interacting with the exports and the deletion of accounts in the database.
+type AccountRepository interface {
+	// AddExport queues a new export of the user's data.
+	// Returns the ID of the export.
+	AddExport(userId string) string
+
+	// GetLatestExport returns the most recent export of the user, nil if there's none.
+	GetLatestExport(userId string) *entity.AccountExport
+
+	// ClaimPendingExports leases pending exports to the caller, so other workers don't build them too.
+	ClaimPendingExports(limit int) []entity.AccountExport
+	CompleteExport(id string, fileName string, ttl time.Duration)
+	FailExport(id string, message string)
+
+	// DeleteExpiredExports deletes the exports whose archive expired.
+	// Returns the file names of their archives, to be removed.
+	DeleteExpiredExports() []string
+
+	// GetExportFileNames returns the file names of every archive of the user.
+	GetExportFileNames(userId string) []string
+
+	// GetAccountData collects everything the user owns or takes part in, their profile is left to the caller.
+	GetAccountData(userId string) *entity.AccountData
+
+	// GetSharedOwnedProjects returns the projects owned by the user that other users or teams have access to.
+	GetSharedOwnedProjects(userId string) []entity.PreviewProject
+
+	// ScheduleDeletion schedules the deletion of the account after the grace period.
+	// Returns the time it's scheduled at.
+	ScheduleDeletion(userId string, gracePeriod time.Duration) string
+
+	// CancelDeletion It should raise panic if the deletion isn't scheduled
+	CancelDeletion(userId string)
+
+	// GetAccountsDueForDeletion returns the IDs of the users whose grace period is over.
+	GetAccountsDueForDeletion() []string
+
+	// AnonymizeAccount deletes the personal data of the user and keeps what the team still needs, under an anonymous name.
+	// Organizations and shared projects the user owns go to another member, projects and tasks nobody else uses are deleted.
+	// Returns the avatar files of the user, to be removed.
+	AnonymizeAccount(userId string) []string
+}
@@ -0,0 +1,32 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// AdminRepository defines methods for interacting with the data managed by the administrators in the database.
+type AdminRepository interface {
+	// IsAdmin tells whether the user is an administrator, deleted and disabled accounts never are.
+	IsAdmin(userId string) bool
+
+	// GetUsers returns a page of the users matching the filter, sorted by username.
+	GetUsers(filter *entity.AdminUserFilter) *entity.AdminUserList
+
+	// GetUser It should raise panic if user is not existed
+	GetUser(id string) *entity.AdminUser
+
+	// SetUserDisabled disables or enables the account.
+	// It should raise panic if user is not existed or was deleted
+	SetUserDisabled(id string, disabled bool)
+
+	// GetStats counts the users and what they've made across the whole system.
+	GetStats() *entity.SystemStats
+
+	// GetReferencedFileNames returns the names of every stored file still referenced,
+	// the avatars with their variants and the export archives.
+	GetReferencedFileNames() []string
+
+	// AddAuditLog records an action of the actor, detail is stored as JSON.
+	AddAuditLog(actorId string, action string, targetType string, targetId string, detail map[string]interface{})
+
+	// GetAuditLogs returns a page of the audit log matching the filter, the latest first.
+	GetAuditLogs(filter *entity.AuditLogFilter) []entity.AuditLog
+}
@@ -0,0 +1,23 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// CalendarFeedRepository defines methods for interacting with calendar feeds and the tasks they publish in the database.
+type CalendarFeedRepository interface {
+	// AddCalendarFeed creates the feed of the user for the project (or their own tasks if empty),
+	// replacing the previous one so its token is revoked.
+	AddCalendarFeed(userId string, projectId string, tokenHash string) string
+	GetCalendarFeedsByUser(userId string) []entity.CalendarFeed
+
+	// GetCalendarFeedByToken returns the feed of the token and marks it as used.
+	// It should raise panic if no feed has the token, or its user is disabled
+	GetCalendarFeedByToken(tokenHash string) *entity.CalendarFeed
+
+	// DeleteCalendarFeed revokes the feed of the user.
+	// It should raise panic if the user has no such feed
+	DeleteCalendarFeed(id string, userId string)
+
+	// GetUserCalendarTasks returns the tasks with due date the user owns or is assigned to, in projects they can still access.
+	GetUserCalendarTasks(userId string) []entity.CalendarTask
+	GetProjectCalendarTasks(projectId string) []entity.CalendarTask
+}
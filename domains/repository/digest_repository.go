@@ -0,0 +1,26 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// DigestRepository defines methods for interacting with the email digest data in the database.
+type DigestRepository interface {
+	// UpsertSubscription opts the user in to the digest, or updates the existing subscription.
+	UpsertSubscription(userId string, payload *entity.DigestSubscriptionPayload)
+
+	// GetSubscriptionByUser It should raise panic if the user hasn't opted in
+	GetSubscriptionByUser(userId string) *entity.DigestSubscription
+	DeleteSubscription(userId string)
+
+	// GetSubscriptions returns the subscriptions of the users who haven't turned the digest emails off.
+	GetSubscriptions() []entity.DigestSubscription
+
+	// GetOpenAssignedTasks returns tasks assigned to the user which are not completed or canceled yet.
+	GetOpenAssignedTasks(userId string) []entity.DigestTask
+
+	// ClaimDigestPeriod marks the period as sent for the user.
+	// Returns false if it was already claimed, so a digest is never sent twice for the same period.
+	ClaimDigestPeriod(userId string, periodKey string) bool
+
+	// ReleaseDigestPeriod removes the claim, used when sending the digest failed.
+	ReleaseDigestPeriod(userId string, periodKey string)
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
+
+// InvitationRepository defines methods for interacting with the project invitations in the database.
+type InvitationRepository interface {
+	// AddInvitation invites the email to the project, userId is empty when the invitee isn't registered.
+	// The invitation expires after ttl, an expired pending invitation of the same email is marked as expired first.
+	// It should raise panic if the email already has a pending invitation to the project
+	// Returns the ID of the newly created invitation.
+	AddInvitation(projectId string, email string, userId string, role string, invitedBy string, ttl time.Duration) string
+
+	// GetInvitationById It should raise panic if invitation is not existed
+	GetInvitationById(id string) *entity.Invitation
+
+	// GetPendingInvitationsByProject returns the pending invitations of the project which aren't expired.
+	GetPendingInvitationsByProject(projectId string) []entity.Invitation
+
+	// GetPendingInvitationsByInvitee returns the pending invitations sent to the user's account.
+	// Invitations sent to an email before registering aren't, only their emailed token proves they're the user's.
+	GetPendingInvitationsByInvitee(userId string) []entity.Invitation
+
+	// AcceptInvitation adds the user as a member with the invited role and marks the invitation as accepted.
+	// It should raise panic if the invitation is not pending anymore
+	AcceptInvitation(id string, userId string)
+
+	// UpdateInvitationStatus answers a pending invitation with the status (declined or revoked).
+	// It should raise panic if the invitation is not pending anymore
+	UpdateInvitationStatus(id string, status string)
+}
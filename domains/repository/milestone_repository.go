@@ -0,0 +1,36 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// MilestoneRepository defines methods for interacting with the milestones of projects in the database.
+type MilestoneRepository interface {
+	AddMilestone(projectId string, payload *entity.MilestonePayload) string
+
+	// GetMilestoneById It should raise panic if milestone is not existed
+	GetMilestoneById(id string) *entity.Milestone
+	GetMilestonesByProject(projectId string) []entity.Milestone
+
+	// UpdateMilestoneById It should raise panic if milestone is not existed
+	UpdateMilestoneById(id string, payload *entity.MilestonePayload)
+
+	// DeleteMilestoneById deletes the milestone, its tasks are left without milestone.
+	DeleteMilestoneById(id string)
+
+	// StartMilestone makes the planned milestone active, committing to its current scope.
+	// It should raise panic if the milestone is not planned, or the project already has an active milestone
+	StartMilestone(id string)
+
+	// CloseMilestone closes the active milestone in a single transaction, carrying its unfinished tasks
+	// to the next milestone, or out of any milestone if next is empty. Returns the number of carried tasks.
+	// It should raise panic if the milestone is not active
+	CloseMilestone(id string, nextMilestoneId string) int
+
+	// GetNextMilestone returns the earliest planned milestone of the project after the milestone, empty if none.
+	GetNextMilestone(id string) string
+
+	// GetMilestoneTasksAt returns the tasks inside the milestone just before the time (RFC 3339), replaying their history.
+	GetMilestoneTasksAt(id string, at string) []entity.MilestoneTask
+
+	// GetScopeChanges returns the tasks added to or removed from the milestone between from and to (RFC 3339).
+	GetScopeChanges(id string, from string, to string) []entity.ScopeChange
+}
@@ -0,0 +1,42 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// OrganizationRepository defines methods for interacting with the organization-related data in the database.
+type OrganizationRepository interface {
+	// AddOrganization creates the organization with the user as its owner.
+	AddOrganization(payload *entity.OrganizationPayload, ownerId string) string
+
+	// EnsurePersonalOrganization returns the personal workspace of the user, creating it if needed.
+	EnsurePersonalOrganization(userId string) string
+
+	// GetOrganizationById returns the organization along with the role of the user inside it.
+	// It should raise panic if organization is not existed or the user is not a member of it
+	GetOrganizationById(id string, userId string) *entity.Organization
+	GetOrganizationsByUser(userId string) []entity.Organization
+	UpdateOrganizationById(id string, payload *entity.OrganizationPayload)
+
+	// DeleteOrganizationById deletes the organization, along with its projects.
+	// It should raise panic if organization is not existed
+	DeleteOrganizationById(id string)
+
+	// CountProjects returns the number of projects of the organization, including the ones inside the trash.
+	CountProjects(id string) int
+
+	// GetOrganizationRole returns the role of the user inside the organization, empty if the user is not a member.
+	// It should raise panic if organization is not existed
+	GetOrganizationRole(id string, userId string) string
+
+	GetOrganizationMembers(id string) []entity.OrganizationMember
+
+	// AddOrganizationMember It should raise panic if user is not existed or already a member
+	AddOrganizationMember(id string, userId string, role string)
+
+	// UpdateOrganizationMemberRole It should raise panic if user is not a member of the organization
+	UpdateOrganizationMemberRole(id string, userId string, role string)
+
+	// RemoveOrganizationMember removes the user from the organization, along with the user's teams,
+	// project memberships and assignments in the organization.
+	// It should raise panic if user is not a member of the organization or still owns projects of it
+	RemoveOrganizationMember(id string, userId string)
+}
@@ -1,14 +1,90 @@
 ﻿package repository
 
-import "github.com/wisle25/task-pixie/domains/entity"
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
 
 // ProjectRepository defines methods for interacting with the project-related data in the database.
 type ProjectRepository interface {
 	AddProject(payload *entity.ProjectPayload, ownerId string) string
+
+	// GetProjectById It should raise panic if project is not existed, templates aren't projects
 	GetProjectById(id string) *entity.Project
-	GetProjectMembers(id string) []entity.User
-	UpdateProjectById(id string, payload *entity.ProjectPayload)
-	DeleteProjectById(id string)
-	GetProjectsByOwner(ownerId string) []entity.PreviewProject
-	GetProjectsByMember(memberId string) []entity.PreviewProject
+
+	// GetProjectMembers returns everyone having a role on the project, along with the role GetMemberRole gives them:
+	// the owner, the members directly or through teams, and the owners and admins of the organization.
+	GetProjectMembers(id string) []entity.ProjectMember
+
+	// UpdateProjectById overwrites the project, only the given versions of it are, any version if there are none.
+	// It should raise panic if the project is not existed, or has another version
+	UpdateProjectById(id string, payload *entity.ProjectPayload, versions []int)
+
+	// DeleteProjectById moves the project to the trash, it's still restorable until purged.
+	// Only the given versions of the project are deleted, any version if there are none.
+	DeleteProjectById(id string, versions []int)
+
+	// GetTaskCounts counts the tasks of the project by status and priority, along with the overdue ones.
+	// Tasks are overdue when their due date is before today (YYYY-MM-DD) and they're not done.
+	GetTaskCounts(projectId string, today string) []entity.TaskCount
+
+	// GetAssigneeWorkload counts the tasks of the project assigned to each user, see GetTaskCounts.
+	GetAssigneeWorkload(projectId string, today string) []entity.AssigneeWorkload
+
+	// GetDailyStatusCounts counts the tasks of the project by status at the end of each day (UTC) from from to to,
+	// replaying the history of the tasks.
+	GetDailyStatusCounts(projectId string, from string, to string) []entity.DailyStatusCount
+
+	// GetAccessibleProjects returns the projects the user can access, limited to the organization unless it's empty.
+	// Organization owners and admins access every project of the organization.
+	GetAccessibleProjects(userId string, organizationId string) []entity.PreviewProject
+
+	// GetMemberRole returns the effective role of the user inside the project, empty if the user is not part of it.
+	// It's the highest of the direct membership and the teams granted on the project.
+	// Users outside the project's organization have no role, organization owners and admins are project admins.
+	// It should raise panic if project is not existed, nobody has a role in a template
+	GetMemberRole(projectId string, userId string) string
+
+	// AddMember adds the user to the project with the role, the user joins the project's organization if needed.
+	// It should raise panic if user is not existed or already a member
+	AddMember(projectId string, userId string, role string)
+
+	// UpdateMemberRole It should raise panic if user is not a member of the project
+	UpdateMemberRole(projectId string, userId string, role string)
+
+	// RemoveMember removes the user from the project, unassigning the user from the project's tasks.
+	// Tasks of the project owned by the user are handed over to the project owner,
+	// unless the user still has access through a team.
+	// It should raise panic if user is not a member of the project
+	RemoveMember(projectId string, userId string)
+
+	// TransferOwnership makes the member the new project owner, the previous owner stays as admin.
+	// It should raise panic if user is not a member of the project
+	TransferOwnership(projectId string, newOwnerId string)
+
+	// SetProjectArchived archives or unarchives the project, archived projects are read-only.
+	SetProjectArchived(id string, archived bool)
+
+	// GetDeletedProjectsByOwner returns the projects of the owner inside the trash.
+	GetDeletedProjectsByOwner(ownerId string) []entity.TrashedProject
+
+	// RestoreProjectById takes the project of the owner out of the trash.
+	// It should raise panic if the owner has no such project inside the trash
+	RestoreProjectById(id string, ownerId string)
+
+	// CloneProject deep copies the project in a single transaction, the copy is owned by ownerId
+	// inside payload.OrganizationId. Members from outside the organization are left out.
+	// It should raise panic if project is not existed
+	CloneProject(sourceId string, payload *entity.CloneProjectPayload, ownerId string, isTemplate bool) string
+
+	// GetTemplateById It should raise panic if template is not existed
+	GetTemplateById(id string) *entity.ProjectTemplate
+	GetTemplatesByOrganization(organizationId string) []entity.ProjectTemplate
+
+	// DeleteTemplateById permanently deletes the template along with its tasks.
+	DeleteTemplateById(id string)
+
+	// PurgeDeletedProjects permanently deletes the projects deleted longer than retention ago, along with their tasks.
+	// Returns the number of purged projects.
+	PurgeDeletedProjects(retention time.Duration) int64
 }
@@ -0,0 +1,10 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// SearchRepository defines methods for full-text searching the data in the database.
+type SearchRepository interface {
+	// Search returns tasks and projects matching the payload, ordered by rank.
+	// Only resources the user owns, is a member of or is assigned to are returned.
+	Search(userId string, payload *entity.SearchPayload) []entity.SearchResult
+}
@@ -1,14 +1,54 @@
 ﻿package repository
 
-import "github.com/wisle25/task-pixie/domains/entity"
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
 
 // TaskRepository defines methods for interacting with the task-related data in the database.
 type TaskRepository interface {
 	AddTask(payload *entity.TaskPayload, ownerId string) string
 	GetTaskById(id string) *entity.Task
-	UpdateTaskById(id string, payload *entity.TaskPayload)
-	DeleteTaskById(id string)
+	// UpdateTaskById overwrites the task, its assignments are replaced by the payload's.
+	// Only the given versions of the task are overwritten, any version if there are none.
+	// It should raise panic if the task is not existed, or has another version
+	UpdateTaskById(id string, payload *entity.TaskPayload, versions []int)
+
+	// UpdateTaskFields overwrites the task like UpdateTaskById, but keeps its assignments as they are.
+	UpdateTaskFields(id string, payload *entity.TaskPayload, versions []int)
+
+	// DeleteTaskById moves the task to the trash, it's still restorable until purged.
+	// Only the given versions of the task are deleted, any version if there are none.
+	DeleteTaskById(id string, versions []int)
+
+	// ApplyBulkOperation applies the operation (see entity.TaskBulkSetStatus) to every task at once, in a single transaction.
+	// The tasks and their projects are locked, then checked again: the user must still access them, the projects mustn't be archived,
+	// and an added assignee must access them too.
+	// It should raise panic (409) if any task doesn't pass anymore, nothing is changed then
+	ApplyBulkOperation(ids []string, operation string, value string, userId string)
+
+	// ImportTasks adds every task in a single transaction, nothing is added if any of them fails.
+	// Returns the IDs of the tasks, in the order of the payloads.
+	ImportTasks(payloads []entity.TaskPayload, ownerId string) []string
+
+	// ExportTasksByProject calls each with every task of the project, as they're read from the database.
+	ExportTasksByProject(projectId string, each func(task *entity.ExportedTask))
 	GetTasksByProjects(projectId string) []entity.PreviewTask
 	GetTasksByOwner(ownerId string) []entity.PreviewTask
 	GetTasksByAssignedUser(userId string) []entity.PreviewTask
+
+	// GetTasksByFilter returns the tasks accessible by the user matching the filter.
+	// Relative due dates of the filter are computed from today (YYYY-MM-DD).
+	GetTasksByFilter(filter *entity.TaskFilter, userId string, today string) []entity.PreviewTask
+
+	// GetDeletedTasksByUser returns the tasks inside the trash owned by the user, or inside the user's projects.
+	GetDeletedTasksByUser(userId string) []entity.TrashedTask
+
+	// RestoreTaskById takes the task out of the trash, only its owner or the project owner is allowed.
+	// It should raise panic if the user has no such task inside the trash
+	RestoreTaskById(id string, userId string)
+
+	// PurgeDeletedTasks permanently deletes the tasks deleted longer than retention ago.
+	// Returns the number of purged tasks.
+	PurgeDeletedTasks(retention time.Duration) int64
 }
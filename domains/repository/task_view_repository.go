@@ -0,0 +1,18 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// TaskViewRepository defines methods for interacting with the saved views in the database.
+type TaskViewRepository interface {
+	// AddView saves a new view of the owner.
+	// Returns the ID of the newly created view.
+	AddView(payload *entity.TaskViewPayload, ownerId string) string
+
+	// GetViewById It should raise panic if view is not existed
+	GetViewById(id string) *entity.TaskView
+
+	// GetViewsByUser returns the views owned by the user and the ones shared with the user's projects.
+	GetViewsByUser(userId string) []entity.TaskView
+	UpdateViewById(id string, payload *entity.TaskViewPayload)
+	DeleteViewById(id string)
+}
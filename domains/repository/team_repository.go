@@ -0,0 +1,43 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// TeamRepository defines methods for interacting with the teams and their project grants in the database.
+type TeamRepository interface {
+	// AddTeam It should raise panic if the organization already has a team with the name
+	AddTeam(organizationId string, payload *entity.TeamPayload) string
+
+	// GetTeamById It should raise panic if team is not existed
+	GetTeamById(id string) *entity.Team
+	GetTeamsByOrganization(organizationId string) []entity.Team
+
+	// UpdateTeamById It should raise panic if the organization already has a team with the name
+	UpdateTeamById(id string, payload *entity.TeamPayload)
+
+	// DeleteTeamById deletes the team, its members lose the access granted through it.
+	// Those left without access are unassigned from the tasks of its projects, the tasks they own go to the project owner.
+	DeleteTeamById(id string)
+
+	GetTeamMembers(id string) []entity.TeamMember
+
+	// AddTeamMember It should raise panic if user is not existed or already a member
+	AddTeamMember(id string, userId string)
+
+	// RemoveTeamMember removes the user from the team, cleaning up their tasks like DeleteTeamById.
+	// It should raise panic if user is not a member of the team
+	RemoveTeamMember(id string, userId string)
+
+	// GetProjectTeams returns the teams granted on the project along with their role.
+	GetProjectTeams(projectId string) []entity.ProjectTeam
+
+	// AddProjectTeam grants the team the role on the project.
+	// It should raise panic if the team is already granted
+	AddProjectTeam(projectId string, teamId string, role string)
+
+	// UpdateProjectTeamRole It should raise panic if the team is not granted on the project
+	UpdateProjectTeamRole(projectId string, teamId string, role string)
+
+	// RemoveProjectTeam removes the grant of the team, cleaning up the tasks of its members like DeleteTeamById.
+	// It should raise panic if the team is not granted on the project
+	RemoveProjectTeam(projectId string, teamId string)
+}
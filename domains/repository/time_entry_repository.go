@@ -0,0 +1,32 @@
+package repository
+
+import "github.com/wisle25/task-pixie/domains/entity"
+
+// TimeEntryRepository defines methods for interacting with the time tracked on tasks in the database.
+type TimeEntryRepository interface {
+	// StartTimer starts a timer of the user on the task.
+	// It should raise panic if the user already has a running timer
+	StartTimer(taskId string, userId string, payload *entity.TimerPayload) string
+
+	// StopTimer stops the running timer of the user, returning its ID.
+	// It should raise panic if the user has no running timer
+	StopTimer(userId string) string
+
+	// GetRunningTimer It should raise panic if the user has no running timer
+	GetRunningTimer(userId string) *entity.TimeEntry
+
+	// AddTimeEntry It should raise panic if the task is not existed
+	AddTimeEntry(taskId string, userId string, payload *entity.TimeEntryPayload) string
+
+	// GetTimeEntryById It should raise panic if time entry is not existed
+	GetTimeEntryById(id string) *entity.TimeEntry
+	GetTimeEntriesByTask(taskId string) []entity.TimeEntry
+
+	// GetTimeSpentByTask sums the minutes tracked on the task, running timers included up to now.
+	GetTimeSpentByTask(taskId string) int
+	DeleteTimeEntryById(id string)
+
+	// GetTimeReport aggregates the time by day, project and user.
+	// Only the time of the viewer and the time spent on projects the viewer can access are counted.
+	GetTimeReport(viewerId string, filter *entity.TimeReportFilter) []entity.TimeReportRow
+}
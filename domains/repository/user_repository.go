@@ -1,6 +1,9 @@
 package repository
 
-import "github.com/wisle25/task-pixie/domains/entity"
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
 
 // UserRepository defines methods for interacting with the user-related data in the database.
 type UserRepository interface {
@@ -10,7 +13,7 @@ type UserRepository interface {
 	AddUser(payload *entity.RegisterUserPayload) string
 
 	// GetUserForLogin retrieves user details based on the provided identity (username or email) for login purpose.
-	// It should raise panic if user is not existed
+	// It should raise panic if user is not existed, is disabled or must reset their password
 	// Returns the user's information and hashed password to be decrypted.
 	GetUserForLogin(identity string) (*entity.User, string)
 
@@ -18,10 +21,30 @@ type UserRepository interface {
 	// It should raise panic if user is not existed
 	GetUserById(id string) *entity.User
 
-	// UpdateUserById Updating user data
+	// FindUserByIdentity retrieves the user by username or email (case-insensitive).
+	// Returns nil if user is not existed or was deleted
+	FindUserByIdentity(identity string) *entity.User
+
+	// UpdateUserById Updating user data, the variants of the avatar are replaced by the given ones (by size)
+	// It should raise panic if user is not existed
+	// Returns the files of the old avatar (They are used to delete the old one)
+	UpdateUserById(id string, payload *entity.UpdateUserPayload, avatars map[int]string) []string
+
+	// GetAvatarFileName returns the file of the avatar variant of that size, empty if the user has no avatar.
 	// It should raise panic if user is not existed
-	// Returns old avatar link (Link is used to delete the old one)
-	UpdateUserById(id string, payload *entity.UpdateUserPayload, newAvatarLink string) string
+	GetAvatarFileName(id string, size int) string
 
 	SearchUsersByUsername(username string) []entity.User
+
+	// RequirePasswordReset stores the hash of the reset token, the user can't log in until the password is reset.
+	// It should raise panic if user is not existed or was deleted
+	RequirePasswordReset(id string, tokenHash string, ttl time.Duration)
+
+	// RenewExpiredPasswordReset replaces the expired reset token of the user with the identity (email or username).
+	// Returns the user, nil if they have no reset pending or it's not expired yet
+	RenewExpiredPasswordReset(identity string, tokenHash string, ttl time.Duration) *entity.User
+
+	// ResetPassword sets the new password of the user holding the reset token and forgets the token.
+	// It should raise panic if the token is unknown or expired
+	ResetPassword(tokenHash string, encryptedPassword string)
 }
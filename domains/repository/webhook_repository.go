@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/wisle25/task-pixie/domains/entity"
+	"time"
+)
+
+// WebhookRepository defines methods for interacting with the webhook-related data in the database.
+type WebhookRepository interface {
+	// AddWebhook registers a new webhook for the project.
+	// Returns the ID of the newly created webhook.
+	AddWebhook(projectId string, payload *entity.WebhookPayload, secret string, createdBy string) string
+
+	// GetWebhookById It should raise panic if webhook is not existed
+	GetWebhookById(id string) *entity.Webhook
+	GetWebhooksByProject(projectId string) []entity.Webhook
+
+	// GetActiveWebhooksByEvent returns active webhooks of the project subscribed to the event, including their secret.
+	GetActiveWebhooksByEvent(projectId string, event string) []entity.Webhook
+	UpdateWebhookById(id string, payload *entity.WebhookPayload)
+	DeleteWebhookById(id string)
+
+	// AddDelivery queues a pending delivery of the payload to the webhook.
+	// Returns the ID of the newly created delivery.
+	AddDelivery(webhookId string, event string, payload []byte) string
+
+	// GetDeliveryById It should raise panic if delivery is not existed
+	GetDeliveryById(id string) *entity.WebhookDelivery
+	GetDeliveriesByWebhook(webhookId string) []entity.WebhookDelivery
+
+	// ClaimDueDeliveries locks at most limit pending deliveries whose next attempt is due,
+	// so they are not picked up again while being sent. Deliveries of inactive webhooks are skipped.
+	// Url and Secret of the target are filled.
+	ClaimDueDeliveries(limit int) []entity.WebhookDelivery
+
+	// UpdateDeliveryResult records the response of an attempt.
+	// The next attempt is scheduled after retryIn, it only matters when the delivery is still pending.
+	UpdateDeliveryResult(delivery *entity.WebhookDelivery, retryIn time.Duration)
+}
@@ -15,6 +15,8 @@ require (
 	github.com/minio/minio-go/v7 v7.0.71
 	github.com/spf13/viper v1.19.0
 	github.com/stretchr/testify v1.9.0
+	golang.org/x/image v0.17.0
+	golang.org/x/net v0.26.0
 )
 
 require (
@@ -39,8 +41,6 @@ require (
 	github.com/valyala/bytebufferpool v1.0.0 // indirect
 	github.com/valyala/fasthttp v1.54.0 // indirect
 	github.com/valyala/tcplisten v1.0.0 // indirect
-	golang.org/x/image v0.17.0 // indirect
-	golang.org/x/net v0.26.0 // indirect
 )
 
 require (
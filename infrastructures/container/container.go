@@ -7,14 +7,20 @@ import (
 	"database/sql"
 	"github.com/google/wire"
 	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/event"
 	"github.com/wisle25/task-pixie/applications/file_statics"
 	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/applications/markdown"
+	"github.com/wisle25/task-pixie/applications/scanner"
 	"github.com/wisle25/task-pixie/applications/use_case"
 	"github.com/wisle25/task-pixie/commons"
 	"github.com/wisle25/task-pixie/infrastructures/repository"
 	"github.com/wisle25/task-pixie/infrastructures/security"
 	"github.com/wisle25/task-pixie/infrastructures/services"
 	"github.com/wisle25/task-pixie/infrastructures/validation"
+	"github.com/wisle25/task-pixie/infrastructures/webhook"
 )
 
 // Dependency Injection for User Use Case
@@ -25,6 +31,10 @@ func NewUserContainer(
 	idGenerator generator.IdGenerator,
 	fileProcessing file_statics.FileProcessing,
 	fileUpload file_statics.FileUpload,
+	fileScanner scanner.FileScanner,
+	fileQuarantine scanner.FileQuarantine,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
 	validator *services.Validation,
 ) *use_case.UserUseCase {
 	wire.Build(
@@ -32,6 +42,7 @@ func NewUserContainer(
 		security.NewArgon2,
 		validation.NewValidateUser,
 		security.NewJwtToken,
+		use_case.NewUploadGuard,
 		use_case.NewUserUseCase,
 	)
 
@@ -39,10 +50,18 @@ func NewUserContainer(
 }
 
 // Dependency Injection for Project Use Case
-func NewProjectContainer(idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.ProjectUseCase {
+func NewProjectContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	cache cache.Cache,
+	validator *services.Validation,
+	eventPublisher event.EventPublisher,
+	markdownRenderer markdown.MarkdownRenderer,
+) *use_case.ProjectUseCase {
 	wire.Build(
 		validation.NewValidateProject,
 		repository.NewProjectRepositoryPG,
+		repository.NewOrganizationRepositoryPG,
 		use_case.NewProjectUseCase,
 	)
 	return nil
@@ -52,13 +71,290 @@ func NewProjectContainer(idGenerator generator.IdGenerator, db *sql.DB, validato
 func NewTaskContainer(
 	idgenerator generator.IdGenerator,
 	db *sql.DB,
+	cache cache.Cache,
 	validator *services.Validation,
+	eventPublisher event.EventPublisher,
+	markdownRenderer markdown.MarkdownRenderer,
 ) *use_case.TaskUseCase {
 	wire.Build(
 		validation.NewValidateTask,
 		repository.NewTaskRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		repository.NewMilestoneRepositoryPG,
+		repository.NewTimeEntryRepositoryPG,
 		use_case.NewTaskUseCase,
 	)
 
 	return nil
 }
+
+// Dependency Injection for Webhook Use Case
+func NewWebhookContainer(
+	config *commons.Config,
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	validator *services.Validation,
+) *use_case.WebhookUseCase {
+	wire.Build(
+		validation.NewValidateWebhook,
+		repository.NewWebhookRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		wire.FieldsOf(new(*commons.Config), "WebhookTimeout", "WebhookAllowPrivate"),
+		webhook.NewHttpWebhookSender,
+		use_case.NewWebhookUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Digest Use Case
+func NewDigestContainer(
+	config *commons.Config,
+	db *sql.DB,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	validator *services.Validation,
+) *use_case.DigestUseCase {
+	wire.Build(
+		validation.NewValidateDigest,
+		repository.NewDigestRepositoryPG,
+		use_case.NewDigestUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Search Use Case
+func NewSearchContainer(db *sql.DB, validator *services.Validation) *use_case.SearchUseCase {
+	wire.Build(
+		validation.NewValidateSearch,
+		repository.NewSearchRepositoryPG,
+		use_case.NewSearchUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Task View Use Case
+func NewTaskViewContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	validator *services.Validation,
+) *use_case.TaskViewUseCase {
+	wire.Build(
+		validation.NewValidateTaskView,
+		repository.NewTaskViewRepositoryPG,
+		repository.NewTaskRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		use_case.NewTaskViewUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Trash Use Case
+func NewTrashContainer(
+	config *commons.Config,
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	cache cache.Cache,
+) *use_case.TrashUseCase {
+	wire.Build(
+		repository.NewProjectRepositoryPG,
+		repository.NewTaskRepositoryPG,
+		use_case.NewTrashUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Invitation Use Case
+func NewInvitationContainer(
+	config *commons.Config,
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	validator *services.Validation,
+) *use_case.InvitationUseCase {
+	wire.Build(
+		validation.NewValidateInvitation,
+		repository.NewInvitationRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		repository.NewUserRepositoryPG,
+		wire.FieldsOf(new(*commons.Config), "InviteTokenSecret"),
+		security.NewHmacInviteToken,
+		use_case.NewInvitationUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Organization Use Case
+func NewOrganizationContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	validator *services.Validation,
+) *use_case.OrganizationUseCase {
+	wire.Build(
+		validation.NewValidateOrganization,
+		repository.NewOrganizationRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		use_case.NewOrganizationUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Team Use Case
+func NewTeamContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	validator *services.Validation,
+) *use_case.TeamUseCase {
+	wire.Build(
+		validation.NewValidateTeam,
+		repository.NewTeamRepositoryPG,
+		repository.NewOrganizationRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		use_case.NewTeamUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Time Entry Use Case
+func NewTimeEntryContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	validator *services.Validation,
+) *use_case.TimeEntryUseCase {
+	wire.Build(
+		validation.NewValidateTimeEntry,
+		repository.NewTimeEntryRepositoryPG,
+		repository.NewTaskRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		use_case.NewTimeEntryUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Milestone Use Case
+func NewMilestoneContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	cache cache.Cache,
+	validator *services.Validation,
+) *use_case.MilestoneUseCase {
+	wire.Build(
+		validation.NewValidateMilestone,
+		repository.NewMilestoneRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		use_case.NewMilestoneUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Calendar Feed Use Case
+func NewCalendarFeedContainer(
+	config *commons.Config,
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	validator *services.Validation,
+) *use_case.CalendarFeedUseCase {
+	wire.Build(
+		validation.NewValidateCalendarFeed,
+		repository.NewCalendarFeedRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		use_case.NewCalendarFeedUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Task Transfer Use Case
+func NewTaskTransferContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	cache cache.Cache,
+	validator *services.Validation,
+	fileScanner scanner.FileScanner,
+	fileQuarantine scanner.FileQuarantine,
+	eventPublisher event.EventPublisher,
+) *use_case.TaskTransferUseCase {
+	wire.Build(
+		validation.NewValidateTask,
+		use_case.NewUploadGuard,
+		repository.NewTaskRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		repository.NewUserRepositoryPG,
+		use_case.NewTaskTransferUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Board Import Use Case
+func NewBoardImportContainer(
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	cache cache.Cache,
+	validator *services.Validation,
+	adapters []importer.Adapter,
+	fileScanner scanner.FileScanner,
+	fileQuarantine scanner.FileQuarantine,
+	eventPublisher event.EventPublisher,
+) *use_case.BoardImportUseCase {
+	wire.Build(
+		validation.NewValidateTask,
+		use_case.NewUploadGuard,
+		repository.NewTaskRepositoryPG,
+		repository.NewProjectRepositoryPG,
+		repository.NewUserRepositoryPG,
+		use_case.NewBoardImportUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Account Use Case
+func NewAccountContainer(
+	config *commons.Config,
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	fileUpload file_statics.FileUpload,
+	validator *services.Validation,
+) *use_case.AccountUseCase {
+	wire.Build(
+		repository.NewAccountRepositoryPG,
+		repository.NewUserRepositoryPG,
+		security.NewArgon2,
+		validation.NewValidateUser,
+		use_case.NewAccountUseCase,
+	)
+
+	return nil
+}
+
+// Dependency Injection for Admin Use Case
+func NewAdminContainer(
+	config *commons.Config,
+	idGenerator generator.IdGenerator,
+	db *sql.DB,
+	cache cache.Cache,
+	fileUpload file_statics.FileUpload,
+	mailer mailer.Mailer,
+	mailRenderer mailer.MailRenderer,
+	validator *services.Validation,
+) *use_case.AdminUseCase {
+	wire.Build(
+		validation.NewValidateAdmin,
+		repository.NewAdminRepositoryPG,
+		repository.NewUserRepositoryPG,
+		use_case.NewAdminUseCase,
+	)
+
+	return nil
+}
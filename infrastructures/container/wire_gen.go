@@ -9,40 +9,197 @@ package container
 import (
 	"database/sql"
 	"github.com/wisle25/task-pixie/applications/cache"
+	"github.com/wisle25/task-pixie/applications/event"
 	"github.com/wisle25/task-pixie/applications/file_statics"
 	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/applications/markdown"
+	"github.com/wisle25/task-pixie/applications/scanner"
 	"github.com/wisle25/task-pixie/applications/use_case"
 	"github.com/wisle25/task-pixie/commons"
 	"github.com/wisle25/task-pixie/infrastructures/repository"
 	"github.com/wisle25/task-pixie/infrastructures/security"
 	"github.com/wisle25/task-pixie/infrastructures/services"
 	"github.com/wisle25/task-pixie/infrastructures/validation"
+	"github.com/wisle25/task-pixie/infrastructures/webhook"
 )
 
 // Injectors from container.go:
 
 // Dependency Injection for User Use Case
-func NewUserContainer(config *commons.Config, db *sql.DB, cache2 cache.Cache, idGenerator generator.IdGenerator, fileProcessing file_statics.FileProcessing, fileUpload file_statics.FileUpload, validator *services.Validation) *use_case.UserUseCase {
+func NewUserContainer(config *commons.Config, db *sql.DB, cache2 cache.Cache, idGenerator generator.IdGenerator, fileProcessing file_statics.FileProcessing, fileUpload file_statics.FileUpload, fileScanner scanner.FileScanner, fileQuarantine scanner.FileQuarantine, mailer2 mailer.Mailer, mailRenderer mailer.MailRenderer, validator *services.Validation) *use_case.UserUseCase {
 	userRepository := repository.NewUserRepositoryPG(db, idGenerator)
+	uploadGuard := use_case.NewUploadGuard(fileScanner, fileQuarantine)
 	passwordHash := security.NewArgon2()
 	validateUser := validation.NewValidateUser(validator)
 	token := security.NewJwtToken(idGenerator)
-	userUseCase := use_case.NewUserUseCase(userRepository, fileProcessing, fileUpload, passwordHash, validateUser, config, token, cache2)
+	userUseCase := use_case.NewUserUseCase(userRepository, fileProcessing, fileUpload, uploadGuard, mailer2, mailRenderer, passwordHash, validateUser, config, token, cache2)
 	return userUseCase
 }
 
 // Dependency Injection for Project Use Case
-func NewProjectContainer(idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.ProjectUseCase {
+func NewProjectContainer(idGenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache, validator *services.Validation, eventPublisher event.EventPublisher, markdownRenderer markdown.MarkdownRenderer) *use_case.ProjectUseCase {
 	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	organizationRepository := repository.NewOrganizationRepositoryPG(db, idGenerator)
 	validateProject := validation.NewValidateProject(validator)
-	projectUseCase := use_case.NewProjectUseCase(projectRepository, validateProject)
+	projectUseCase := use_case.NewProjectUseCase(projectRepository, organizationRepository, validateProject, eventPublisher, cache2, markdownRenderer)
 	return projectUseCase
 }
 
 // Dependency Injection for Task Use Case
-func NewTaskContainer(idgenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.TaskUseCase {
+func NewTaskContainer(idgenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache, validator *services.Validation, eventPublisher event.EventPublisher, markdownRenderer markdown.MarkdownRenderer) *use_case.TaskUseCase {
 	taskRepository := repository.NewTaskRepositoryPG(idgenerator, db)
+	projectRepository := repository.NewProjectRepositoryPG(db, idgenerator)
+	milestoneRepository := repository.NewMilestoneRepositoryPG(db, idgenerator)
+	timeEntryRepository := repository.NewTimeEntryRepositoryPG(db, idgenerator)
 	validateTask := validation.NewValidateTask(validator)
-	taskUseCase := use_case.NewTaskUseCase(taskRepository, validateTask)
+	taskUseCase := use_case.NewTaskUseCase(taskRepository, projectRepository, milestoneRepository, timeEntryRepository, validateTask, eventPublisher, cache2, markdownRenderer)
 	return taskUseCase
 }
+
+// Dependency Injection for Webhook Use Case
+func NewWebhookContainer(config *commons.Config, idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.WebhookUseCase {
+	webhookRepository := repository.NewWebhookRepositoryPG(db, idGenerator)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	duration := config.WebhookTimeout
+	bool2 := config.WebhookAllowPrivate
+	webhookSender := webhook.NewHttpWebhookSender(duration, bool2)
+	validateWebhook := validation.NewValidateWebhook(validator)
+	webhookUseCase := use_case.NewWebhookUseCase(webhookRepository, projectRepository, webhookSender, validateWebhook, config)
+	return webhookUseCase
+}
+
+// Dependency Injection for Digest Use Case
+func NewDigestContainer(config *commons.Config, db *sql.DB, mailer2 mailer.Mailer, mailRenderer mailer.MailRenderer, validator *services.Validation) *use_case.DigestUseCase {
+	digestRepository := repository.NewDigestRepositoryPG(db)
+	validateDigest := validation.NewValidateDigest(validator)
+	digestUseCase := use_case.NewDigestUseCase(digestRepository, mailer2, mailRenderer, validateDigest, config)
+	return digestUseCase
+}
+
+// Dependency Injection for Search Use Case
+func NewSearchContainer(db *sql.DB, validator *services.Validation) *use_case.SearchUseCase {
+	searchRepository := repository.NewSearchRepositoryPG(db)
+	validateSearch := validation.NewValidateSearch(validator)
+	searchUseCase := use_case.NewSearchUseCase(searchRepository, validateSearch)
+	return searchUseCase
+}
+
+// Dependency Injection for Task View Use Case
+func NewTaskViewContainer(idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.TaskViewUseCase {
+	taskViewRepository := repository.NewTaskViewRepositoryPG(db, idGenerator)
+	taskRepository := repository.NewTaskRepositoryPG(idGenerator, db)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	validateTaskView := validation.NewValidateTaskView(validator)
+	taskViewUseCase := use_case.NewTaskViewUseCase(taskViewRepository, taskRepository, projectRepository, validateTaskView)
+	return taskViewUseCase
+}
+
+// Dependency Injection for Trash Use Case
+func NewTrashContainer(config *commons.Config, idGenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache) *use_case.TrashUseCase {
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	taskRepository := repository.NewTaskRepositoryPG(idGenerator, db)
+	trashUseCase := use_case.NewTrashUseCase(projectRepository, taskRepository, config, cache2)
+	return trashUseCase
+}
+
+// Dependency Injection for Invitation Use Case
+func NewInvitationContainer(config *commons.Config, idGenerator generator.IdGenerator, db *sql.DB, mailer2 mailer.Mailer, mailRenderer mailer.MailRenderer, validator *services.Validation) *use_case.InvitationUseCase {
+	invitationRepository := repository.NewInvitationRepositoryPG(db, idGenerator)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	userRepository := repository.NewUserRepositoryPG(db, idGenerator)
+	string2 := config.InviteTokenSecret
+	inviteToken := security.NewHmacInviteToken(string2)
+	validateInvitation := validation.NewValidateInvitation(validator)
+	invitationUseCase := use_case.NewInvitationUseCase(invitationRepository, projectRepository, userRepository, inviteToken, mailer2, mailRenderer, validateInvitation, config)
+	return invitationUseCase
+}
+
+// Dependency Injection for Organization Use Case
+func NewOrganizationContainer(idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.OrganizationUseCase {
+	organizationRepository := repository.NewOrganizationRepositoryPG(db, idGenerator)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	validateOrganization := validation.NewValidateOrganization(validator)
+	organizationUseCase := use_case.NewOrganizationUseCase(organizationRepository, projectRepository, validateOrganization)
+	return organizationUseCase
+}
+
+// Dependency Injection for Team Use Case
+func NewTeamContainer(idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.TeamUseCase {
+	teamRepository := repository.NewTeamRepositoryPG(db, idGenerator)
+	organizationRepository := repository.NewOrganizationRepositoryPG(db, idGenerator)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	validateTeam := validation.NewValidateTeam(validator)
+	teamUseCase := use_case.NewTeamUseCase(teamRepository, organizationRepository, projectRepository, validateTeam)
+	return teamUseCase
+}
+
+// Dependency Injection for Time Entry Use Case
+func NewTimeEntryContainer(idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.TimeEntryUseCase {
+	timeEntryRepository := repository.NewTimeEntryRepositoryPG(db, idGenerator)
+	taskRepository := repository.NewTaskRepositoryPG(idGenerator, db)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	validateTimeEntry := validation.NewValidateTimeEntry(validator)
+	timeEntryUseCase := use_case.NewTimeEntryUseCase(timeEntryRepository, taskRepository, projectRepository, validateTimeEntry)
+	return timeEntryUseCase
+}
+
+// Dependency Injection for Milestone Use Case
+func NewMilestoneContainer(idGenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache, validator *services.Validation) *use_case.MilestoneUseCase {
+	milestoneRepository := repository.NewMilestoneRepositoryPG(db, idGenerator)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	validateMilestone := validation.NewValidateMilestone(validator)
+	milestoneUseCase := use_case.NewMilestoneUseCase(milestoneRepository, projectRepository, validateMilestone, cache2)
+	return milestoneUseCase
+}
+
+// Dependency Injection for Calendar Feed Use Case
+func NewCalendarFeedContainer(config *commons.Config, idGenerator generator.IdGenerator, db *sql.DB, validator *services.Validation) *use_case.CalendarFeedUseCase {
+	calendarFeedRepository := repository.NewCalendarFeedRepositoryPG(db, idGenerator)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	validateCalendarFeed := validation.NewValidateCalendarFeed(validator)
+	calendarFeedUseCase := use_case.NewCalendarFeedUseCase(calendarFeedRepository, projectRepository, validateCalendarFeed, config)
+	return calendarFeedUseCase
+}
+
+// Dependency Injection for Task Transfer Use Case
+func NewTaskTransferContainer(idGenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache, validator *services.Validation, fileScanner scanner.FileScanner, fileQuarantine scanner.FileQuarantine, eventPublisher event.EventPublisher) *use_case.TaskTransferUseCase {
+	taskRepository := repository.NewTaskRepositoryPG(idGenerator, db)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	userRepository := repository.NewUserRepositoryPG(db, idGenerator)
+	validateTask := validation.NewValidateTask(validator)
+	uploadGuard := use_case.NewUploadGuard(fileScanner, fileQuarantine)
+	taskTransferUseCase := use_case.NewTaskTransferUseCase(taskRepository, projectRepository, userRepository, validateTask, uploadGuard, eventPublisher, cache2)
+	return taskTransferUseCase
+}
+
+// Dependency Injection for Board Import Use Case
+func NewBoardImportContainer(idGenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache, validator *services.Validation, adapters []importer.Adapter, fileScanner scanner.FileScanner, fileQuarantine scanner.FileQuarantine, eventPublisher event.EventPublisher) *use_case.BoardImportUseCase {
+	taskRepository := repository.NewTaskRepositoryPG(idGenerator, db)
+	projectRepository := repository.NewProjectRepositoryPG(db, idGenerator)
+	userRepository := repository.NewUserRepositoryPG(db, idGenerator)
+	validateTask := validation.NewValidateTask(validator)
+	uploadGuard := use_case.NewUploadGuard(fileScanner, fileQuarantine)
+	boardImportUseCase := use_case.NewBoardImportUseCase(taskRepository, projectRepository, userRepository, validateTask, adapters, uploadGuard, eventPublisher, cache2)
+	return boardImportUseCase
+}
+
+// Dependency Injection for Account Use Case
+func NewAccountContainer(config *commons.Config, idGenerator generator.IdGenerator, db *sql.DB, fileUpload file_statics.FileUpload, validator *services.Validation) *use_case.AccountUseCase {
+	accountRepository := repository.NewAccountRepositoryPG(db, idGenerator)
+	userRepository := repository.NewUserRepositoryPG(db, idGenerator)
+	passwordHash := security.NewArgon2()
+	validateUser := validation.NewValidateUser(validator)
+	accountUseCase := use_case.NewAccountUseCase(accountRepository, userRepository, fileUpload, passwordHash, validateUser, config)
+	return accountUseCase
+}
+
+// Dependency Injection for Admin Use Case
+func NewAdminContainer(config *commons.Config, idGenerator generator.IdGenerator, db *sql.DB, cache2 cache.Cache, fileUpload file_statics.FileUpload, mailer2 mailer.Mailer, mailRenderer mailer.MailRenderer, validator *services.Validation) *use_case.AdminUseCase {
+	adminRepository := repository.NewAdminRepositoryPG(db)
+	userRepository := repository.NewUserRepositoryPG(db, idGenerator)
+	validateAdmin := validation.NewValidateAdmin(validator)
+	adminUseCase := use_case.NewAdminUseCase(adminRepository, userRepository, fileUpload, mailer2, mailRenderer, cache2, validateAdmin, config)
+	return adminUseCase
+}
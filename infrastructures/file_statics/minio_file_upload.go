@@ -7,7 +7,10 @@ import (
 	"github.com/minio/minio-go/v7"
 	"github.com/wisle25/task-pixie/applications/file_statics"
 	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
 	"io"
+	"mime"
+	"time"
 )
 
 type MinioFileUpload struct {
@@ -40,8 +43,12 @@ func (m *MinioFileUpload) UploadFile(buffer []byte, extension string) string {
 	newName := m.idGenerator.Generate() + extension
 
 	// Upload
+	contentType := mime.TypeByExtension(extension)
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
 	uploadOpts := minio.PutObjectOptions{
-		ContentType: "image/" + extension[1:],
+		ContentType: contentType,
 	}
 	_, err = m.minio.PutObject(
 		ctx,
@@ -87,3 +94,22 @@ func (m *MinioFileUpload) RemoveFile(oldFileLink string) {
 		panic(fmt.Errorf("minio: remove file err: %v", err))
 	}
 }
+
+func (m *MinioFileUpload) ListFiles() []entity.StoredFile {
+	ctx := context.Background()
+
+	files := make([]entity.StoredFile, 0)
+	for object := range m.minio.ListObjects(ctx, m.bucketName, minio.ListObjectsOptions{Recursive: true}) {
+		if object.Err != nil {
+			panic(fmt.Errorf("minio: list files err: %v", object.Err))
+		}
+
+		files = append(files, entity.StoredFile{
+			Name:         object.Key,
+			Size:         object.Size,
+			LastModified: object.LastModified.UTC().Format(time.RFC3339),
+		})
+	}
+
+	return files
+}
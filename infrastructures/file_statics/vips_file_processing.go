@@ -58,6 +58,58 @@ func (v *VipsFileProcessing) CompressImage(buffer []byte, to file_statics.Conver
 	return result, extension
 }
 
+func (v *VipsFileProcessing) CreateSquareVariants(
+	buffer []byte,
+	sizes []int,
+	to file_statics.ConvertTo,
+) (map[int][]byte, string) {
+	variants := make(map[int][]byte, len(sizes))
+	extension := ""
+
+	for _, size := range sizes {
+		// Thumbnails are rotated upright and cropped around the center
+		image, err := vips.NewThumbnailFromBuffer(buffer, size, size, vips.InterestingCentre)
+		if err != nil {
+			panic(fmt.Errorf("vips: square variant %d: %v", size, err))
+		}
+
+		variants[size], extension = v.export(image, to, 80)
+		image.Close()
+	}
+
+	return variants, extension
+}
+
+// export encodes the image to the format at the quality.
+// Returning the encoded image and its extension.
+func (v *VipsFileProcessing) export(image *vips.ImageRef, to file_statics.ConvertTo, quality int) ([]byte, string) {
+	switch to {
+	case file_statics.JPG:
+		options := vips.NewJpegExportParams()
+		options.Quality = quality
+		options.StripMetadata = true
+
+		result, _, err := image.ExportJpeg(options)
+		if err != nil {
+			panic(fmt.Errorf("vips: export jpeg: %v", err))
+		}
+
+		return result, ".jpg"
+	default:
+		options := vips.NewWebpExportParams()
+		options.Quality = quality
+		options.StripMetadata = true
+		options.Lossless = false
+
+		result, _, err := image.ExportWebp(options)
+		if err != nil {
+			panic(fmt.Errorf("vips: export webp: %v", err))
+		}
+
+		return result, ".webp"
+	}
+}
+
 func (v *VipsFileProcessing) AddWatermark(buffer []byte) []byte {
 	// Open original image
 	originalImage, err := vips.NewImageFromBuffer(buffer)
@@ -0,0 +1,252 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"io"
+	"strings"
+)
+
+// jiraIssue is the part of an issue of the Jira REST API (GET /rest/api/3/search) we read.
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string          `json:"summary"`
+		Description json.RawMessage `json:"description"` // Plain text (API v2) or Atlassian Document Format (API v3)
+		Status      *struct {
+			Id             string `json:"id"`
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"` // new, indeterminate or done
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		Assignee *struct {
+			AccountId    string `json:"accountId"`
+			DisplayName  string `json:"displayName"`
+			EmailAddress string `json:"emailAddress"`
+		} `json:"assignee"`
+		DueDate string   `json:"duedate"`
+		Labels  []string `json:"labels"`
+		Project *struct {
+			Name string `json:"name"`
+		} `json:"project"`
+		Subtasks []struct {
+			Fields struct {
+				Summary string `json:"summary"`
+				Status  *struct {
+					StatusCategory struct {
+						Key string `json:"key"`
+					} `json:"statusCategory"`
+				} `json:"status"`
+			} `json:"fields"`
+		} `json:"subtasks"`
+	} `json:"fields"`
+}
+
+// adfNode is a node of the Atlassian Document Format, only its text is kept.
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+type JiraAdapter struct{} // implements Adapter
+
+func NewJiraAdapter() importer.Adapter {
+	return &JiraAdapter{}
+}
+
+func (a *JiraAdapter) Source() string {
+	return "jira"
+}
+
+func (a *JiraAdapter) Parse(r io.Reader) (*importer.Board, error) {
+	issues, err := readJiraIssues(r)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &importer.Board{}
+	states := make(map[string]bool)
+	members := make(map[string]bool)
+
+	for _, issue := range issues {
+		fields := &issue.Fields
+		if board.Name == "" && fields.Project != nil {
+			board.Name = fields.Project.Name
+		}
+
+		description, err := jiraText(fields.Description)
+		if err != nil {
+			return nil, fmt.Errorf("issue %s has an invalid description", issue.Key)
+		}
+
+		card := importer.Card{
+			Title:       fields.Summary,
+			Description: description,
+			DueDate:     fields.DueDate,
+			Labels:      fields.Labels,
+		}
+
+		if fields.Priority != nil {
+			card.Priority = jiraPriority(fields.Priority.Name)
+		}
+
+		if status := fields.Status; status != nil {
+			card.StateId = status.Id
+			if !states[status.Id] {
+				states[status.Id] = true
+				board.States = append(board.States, importer.State{
+					Id:     status.Id,
+					Name:   status.Name,
+					Status: jiraStatus(status.Name, status.StatusCategory.Key),
+				})
+			}
+		}
+
+		if assignee := fields.Assignee; assignee != nil && assignee.AccountId != "" {
+			card.MemberIds = []string{assignee.AccountId}
+			if !members[assignee.AccountId] {
+				members[assignee.AccountId] = true
+				board.Members = append(board.Members, importer.Member{
+					Id:    assignee.AccountId,
+					Name:  assignee.DisplayName,
+					Email: assignee.EmailAddress,
+				})
+			}
+		}
+
+		// Subtasks aren't imported on their own, they're ticked off inside their parent
+		if len(fields.Subtasks) > 0 {
+			checklist := importer.Checklist{Name: "Subtasks"}
+			for _, subtask := range fields.Subtasks {
+				done := subtask.Fields.Status != nil && subtask.Fields.Status.StatusCategory.Key == "done"
+				checklist.Items = append(checklist.Items, importer.ChecklistItem{Name: subtask.Fields.Summary, Done: done})
+			}
+			card.Checklists = []importer.Checklist{checklist}
+		}
+
+		board.Cards = append(board.Cards, card)
+	}
+
+	return board, nil
+}
+
+// readJiraIssues reads either a search result ({"issues": [...]}) or a plain array of issues.
+func readJiraIssues(r io.Reader) ([]jiraIssue, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read file: %v", err)
+	}
+	data = bytes.TrimSpace(data)
+
+	if len(data) > 0 && data[0] == '[' {
+		var issues []jiraIssue
+		if err = json.Unmarshal(data, &issues); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		return issues, nil
+	}
+
+	var result struct {
+		Issues []jiraIssue `json:"issues"`
+	}
+	if err = json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if result.Issues == nil {
+		return nil, errors.New("not a Jira export, issues are missing")
+	}
+
+	return result.Issues, nil
+}
+
+// jiraText returns the text of a description, whether it's plain text or a document.
+func jiraText(raw json.RawMessage) (string, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil
+	}
+
+	var document adfNode
+	if err := json.Unmarshal(raw, &document); err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	writeADF(&builder, &document)
+
+	return strings.TrimSpace(builder.String()), nil
+}
+
+// writeADF writes the text of the node, blocks are separated by new lines.
+func writeADF(builder *strings.Builder, node *adfNode) {
+	switch node.Type {
+	case "text":
+		builder.WriteString(node.Text)
+	case "hardBreak":
+		builder.WriteString("\n")
+	case "listItem":
+		builder.WriteString("- ")
+	}
+
+	for i := range node.Content {
+		writeADF(builder, &node.Content[i])
+	}
+
+	switch node.Type {
+	case "paragraph", "heading", "codeBlock", "blockquote":
+		builder.WriteString("\n\n")
+	}
+}
+
+// jiraStatus maps a status to a task status by its category, unless its name tells it's canceled.
+func jiraStatus(name string, category string) string {
+	if suggested := importer.SuggestStatus(name); suggested == entity.TaskStatusCanceled {
+		return suggested
+	}
+
+	switch category {
+	case "done":
+		return entity.TaskStatusCompleted
+	case "indeterminate":
+		return entity.TaskStatusInProgress
+	case "new":
+		return entity.TaskStatusToDo
+	default:
+		return importer.SuggestStatus(name)
+	}
+}
+
+// jiraPriority maps the default priorities of Jira, and their older names, to task priorities.
+func jiraPriority(name string) string {
+	switch strings.ToLower(name) {
+	case "highest", "blocker", "critical":
+		return "Urgent"
+	case "high", "major":
+		return "High"
+	case "":
+		return ""
+	default:
+		return "Low"
+	}
+}
+
+// NewImportAdapters returns the adapters of every supported source.
+func NewImportAdapters() []importer.Adapter {
+	return []importer.Adapter{
+		NewTrelloAdapter(),
+		NewJiraAdapter(),
+	}
+}
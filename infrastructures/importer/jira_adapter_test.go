@@ -0,0 +1,95 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/domains/entity"
+	adapters "github.com/wisle25/task-pixie/infrastructures/importer"
+)
+
+const jiraExport = `{"issues": [
+	{
+		"key": "PIX-1",
+		"fields": {
+			"summary": "Release",
+			"description": {"type": "doc", "content": [
+				{"type": "paragraph", "content": [{"type": "text", "text": "Go "}, {"type": "text", "text": "live"}]},
+				{"type": "paragraph", "content": [{"type": "text", "text": "Soon"}]}
+			]},
+			"status": {"id": "3", "name": "In Progress", "statusCategory": {"key": "indeterminate"}},
+			"priority": {"name": "Highest"},
+			"assignee": {"accountId": "a1", "displayName": "Pixie", "emailAddress": "pixie@mail.com"},
+			"duedate": "2024-09-30",
+			"labels": ["ops"],
+			"project": {"name": "Pixie"},
+			"subtasks": [
+				{"fields": {"summary": "Build", "status": {"statusCategory": {"key": "done"}}}},
+				{"fields": {"summary": "Ship", "status": {"statusCategory": {"key": "new"}}}}
+			]
+		}
+	},
+	{
+		"key": "PIX-2",
+		"fields": {
+			"summary": "Old idea",
+			"description": "Plain text",
+			"status": {"id": "6", "name": "Won't Do", "statusCategory": {"key": "done"}},
+			"priority": {"name": "Medium"}
+		}
+	}
+]}`
+
+func TestJiraAdapter(t *testing.T) {
+	adapter := adapters.NewJiraAdapter()
+
+	t.Run("Should read statuses, assignees and issues", func(t *testing.T) {
+		// Action
+		board, err := adapter.Parse(strings.NewReader(jiraExport))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "jira", adapter.Source())
+		assert.Equal(t, "Pixie", board.Name)
+		assert.Equal(t, []importer.State{
+			{Id: "3", Name: "In Progress", Status: entity.TaskStatusInProgress},
+			{Id: "6", Name: "Won't Do", Status: entity.TaskStatusCanceled},
+		}, board.States)
+		assert.Equal(t, []importer.Member{{Id: "a1", Name: "Pixie", Email: "pixie@mail.com"}}, board.Members)
+
+		assert.Len(t, board.Cards, 2)
+		assert.Equal(t, importer.Card{
+			Title:       "Release",
+			Description: "Go live\n\nSoon",
+			StateId:     "3",
+			Priority:    "Urgent",
+			DueDate:     "2024-09-30",
+			MemberIds:   []string{"a1"},
+			Labels:      []string{"ops"},
+			Checklists: []importer.Checklist{{
+				Name:  "Subtasks",
+				Items: []importer.ChecklistItem{{Name: "Build", Done: true}, {Name: "Ship"}},
+			}},
+		}, board.Cards[0])
+		assert.Equal(t, "Plain text", board.Cards[1].Description)
+		assert.Equal(t, "Low", board.Cards[1].Priority)
+	})
+
+	t.Run("Should read plain arrays of issues", func(t *testing.T) {
+		board, err := adapter.Parse(strings.NewReader(`[{"key": "PIX-1", "fields": {"summary": "Release"}}]`))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Release", board.Cards[0].Title)
+		assert.Equal(t, "", board.Cards[0].Priority)
+	})
+
+	t.Run("Should reject files which aren't exports", func(t *testing.T) {
+		_, err := adapter.Parse(strings.NewReader(`{"lists": []}`))
+		assert.ErrorContains(t, err, "not a Jira export")
+
+		_, err = adapter.Parse(strings.NewReader(`[{"fields": `))
+		assert.ErrorContains(t, err, "invalid JSON")
+	})
+}
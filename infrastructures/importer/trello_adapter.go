@@ -0,0 +1,141 @@
+package importer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"io"
+	"sort"
+	"time"
+)
+
+// trelloBoard is the part of a Trello board export (Menu > Print, export and share > Export as JSON) we read.
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Lists []struct {
+		Id     string  `json:"id"`
+		Name   string  `json:"name"`
+		Closed bool    `json:"closed"`
+		Pos    float64 `json:"pos"`
+	} `json:"lists"`
+	Members []struct {
+		Id       string `json:"id"`
+		FullName string `json:"fullName"`
+		Username string `json:"username"`
+	} `json:"members"`
+	Checklists []struct {
+		Id         string  `json:"id"`
+		IdCard     string  `json:"idCard"`
+		Name       string  `json:"name"`
+		Pos        float64 `json:"pos"`
+		CheckItems []struct {
+			Name  string  `json:"name"`
+			State string  `json:"state"` // complete or incomplete
+			Pos   float64 `json:"pos"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+	Cards []struct {
+		Id        string   `json:"id"`
+		Name      string   `json:"name"`
+		Desc      string   `json:"desc"`
+		IdList    string   `json:"idList"`
+		Due       *string  `json:"due"`
+		Closed    bool     `json:"closed"`
+		IdMembers []string `json:"idMembers"`
+		Labels    []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+}
+
+type TrelloAdapter struct{} // implements Adapter
+
+func NewTrelloAdapter() importer.Adapter {
+	return &TrelloAdapter{}
+}
+
+func (a *TrelloAdapter) Source() string {
+	return "trello"
+}
+
+func (a *TrelloAdapter) Parse(r io.Reader) (*importer.Board, error) {
+	var export trelloBoard
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %v", err)
+	}
+	if export.Lists == nil || export.Cards == nil {
+		return nil, errors.New("not a Trello board export, lists and cards are missing")
+	}
+
+	board := &importer.Board{Name: export.Name}
+
+	// Lists are shown by position, archived lists still hold cards
+	sort.SliceStable(export.Lists, func(i, j int) bool { return export.Lists[i].Pos < export.Lists[j].Pos })
+	closedLists := make(map[string]bool)
+	for _, list := range export.Lists {
+		board.States = append(board.States, importer.State{
+			Id:     list.Id,
+			Name:   list.Name,
+			Status: importer.SuggestStatus(list.Name),
+		})
+		closedLists[list.Id] = list.Closed
+	}
+
+	// Trello doesn't export emails, members are matched by hand
+	for _, member := range export.Members {
+		name := member.FullName
+		if name == "" {
+			name = member.Username
+		}
+		board.Members = append(board.Members, importer.Member{Id: member.Id, Name: name})
+	}
+
+	sort.SliceStable(export.Checklists, func(i, j int) bool { return export.Checklists[i].Pos < export.Checklists[j].Pos })
+	checklists := make(map[string][]importer.Checklist)
+	for _, checklist := range export.Checklists {
+		sort.SliceStable(checklist.CheckItems, func(i, j int) bool {
+			return checklist.CheckItems[i].Pos < checklist.CheckItems[j].Pos
+		})
+
+		items := make([]importer.ChecklistItem, 0, len(checklist.CheckItems))
+		for _, item := range checklist.CheckItems {
+			items = append(items, importer.ChecklistItem{Name: item.Name, Done: item.State == "complete"})
+		}
+		checklists[checklist.IdCard] = append(checklists[checklist.IdCard], importer.Checklist{Name: checklist.Name, Items: items})
+	}
+
+	for _, exported := range export.Cards {
+		card := importer.Card{
+			Title:       exported.Name,
+			Description: exported.Desc,
+			StateId:     exported.IdList,
+			MemberIds:   exported.IdMembers,
+			Checklists:  checklists[exported.Id],
+			Archived:    exported.Closed || closedLists[exported.IdList],
+		}
+
+		// Due dates are instants, their day is taken in UTC
+		if exported.Due != nil && *exported.Due != "" {
+			due, err := time.Parse(time.RFC3339, *exported.Due)
+			if err != nil {
+				return nil, fmt.Errorf("card %q has an invalid due date", exported.Name)
+			}
+			card.DueDate = due.UTC().Format(time.DateOnly)
+		}
+
+		// Unnamed labels are only told apart by their color
+		for _, label := range exported.Labels {
+			if label.Name != "" {
+				card.Labels = append(card.Labels, label.Name)
+			} else if label.Color != "" {
+				card.Labels = append(card.Labels, label.Color)
+			}
+		}
+
+		board.Cards = append(board.Cards, card)
+	}
+
+	return board, nil
+}
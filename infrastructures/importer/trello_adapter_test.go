@@ -0,0 +1,77 @@
+package importer_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/applications/importer"
+	"github.com/wisle25/task-pixie/domains/entity"
+	adapters "github.com/wisle25/task-pixie/infrastructures/importer"
+)
+
+const trelloExport = `{
+	"name": "Launch",
+	"lists": [
+		{"id": "l2", "name": "Done", "closed": false, "pos": 2},
+		{"id": "l1", "name": "Doing", "closed": false, "pos": 1},
+		{"id": "l3", "name": "Old", "closed": true, "pos": 3}
+	],
+	"members": [{"id": "m1", "fullName": "Pixie", "username": "pixie"}, {"id": "m2", "username": "dixie"}],
+	"checklists": [{
+		"idCard": "c1", "name": "Steps", "pos": 1,
+		"checkItems": [{"name": "Ship", "state": "incomplete", "pos": 2}, {"name": "Build", "state": "complete", "pos": 1}]
+	}],
+	"cards": [
+		{
+			"id": "c1", "name": "Release", "desc": "Go live", "idList": "l1", "due": "2024-09-30T23:30:00.000-02:00",
+			"idMembers": ["m1"], "labels": [{"name": "ops", "color": "red"}, {"name": "", "color": "blue"}]
+		},
+		{"id": "c2", "name": "Archived", "idList": "l2", "closed": true},
+		{"id": "c3", "name": "In an old list", "idList": "l3"}
+	]
+}`
+
+func TestTrelloAdapter(t *testing.T) {
+	adapter := adapters.NewTrelloAdapter()
+
+	t.Run("Should read lists, members and cards", func(t *testing.T) {
+		// Action
+		board, err := adapter.Parse(strings.NewReader(trelloExport))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, "trello", adapter.Source())
+		assert.Equal(t, "Launch", board.Name)
+		assert.Equal(t, []importer.State{
+			{Id: "l1", Name: "Doing", Status: entity.TaskStatusInProgress},
+			{Id: "l2", Name: "Done", Status: entity.TaskStatusCompleted},
+			{Id: "l3", Name: "Old", Status: entity.TaskStatusToDo},
+		}, board.States)
+		assert.Equal(t, []importer.Member{{Id: "m1", Name: "Pixie"}, {Id: "m2", Name: "dixie"}}, board.Members)
+
+		assert.Len(t, board.Cards, 3)
+		assert.Equal(t, importer.Card{
+			Title:       "Release",
+			Description: "Go live",
+			StateId:     "l1",
+			DueDate:     "2024-10-01",
+			MemberIds:   []string{"m1"},
+			Labels:      []string{"ops", "blue"},
+			Checklists: []importer.Checklist{{
+				Name:  "Steps",
+				Items: []importer.ChecklistItem{{Name: "Build", Done: true}, {Name: "Ship"}},
+			}},
+		}, board.Cards[0])
+		assert.True(t, board.Cards[1].Archived)
+		assert.True(t, board.Cards[2].Archived)
+	})
+
+	t.Run("Should reject files which aren't board exports", func(t *testing.T) {
+		_, err := adapter.Parse(strings.NewReader(`{"issues": []}`))
+		assert.ErrorContains(t, err, "not a Trello board export")
+
+		_, err = adapter.Parse(strings.NewReader(`{"lists": [`))
+		assert.ErrorContains(t, err, "invalid JSON")
+	})
+}
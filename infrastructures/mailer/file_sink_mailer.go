@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSinkMailer implements Mailer by writing every email as an .eml file into a directory.
+// It's meant for local development and tests, the files can be opened by any email client.
+type FileSinkMailer struct /* implements Mailer */ {
+	directory   string
+	from        string
+	idGenerator generator.IdGenerator
+}
+
+func NewFileSinkMailer(directory string, from string, idGenerator generator.IdGenerator) mailer.Mailer {
+	return &FileSinkMailer{
+		directory:   directory,
+		from:        from,
+		idGenerator: idGenerator,
+	}
+}
+
+func (m *FileSinkMailer) Send(message *entity.MailMessage) {
+	if err := os.MkdirAll(m.directory, 0o755); err != nil {
+		panic(fmt.Errorf("file_sink_mailer_err: create directory: %v", err))
+	}
+
+	now := time.Now()
+	fileName := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405"), m.idGenerator.Generate())
+
+	err := os.WriteFile(filepath.Join(m.directory, fileName), buildMessage(m.from, message, now), 0o644)
+	if err != nil {
+		panic(fmt.Errorf("file_sink_mailer_err: write email: %v", err))
+	}
+}
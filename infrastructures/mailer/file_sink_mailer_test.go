@@ -0,0 +1,45 @@
+package mailer_test
+
+import (
+	"net/mail"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/generator"
+	"github.com/wisle25/task-pixie/infrastructures/mailer"
+)
+
+func TestFileSinkMailer(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	fileSinkMailer := mailer.NewFileSinkMailer(directory, "TaskPixie <no-reply@taskpixie.local>", generator.NewUUIDGenerator())
+
+	message := &entity.MailMessage{
+		To:      "pixie@example.com",
+		Subject: "Your digest",
+		HTML:    "<p>Hello</p>",
+		Text:    "Hello",
+	}
+
+	// Action
+	assert.NotPanics(t, func() {
+		fileSinkMailer.Send(message)
+	})
+
+	// Assert
+	files, _ := filepath.Glob(filepath.Join(directory, "*.eml"))
+	assert.Len(t, files, 1)
+
+	file, err := os.Open(files[0])
+	assert.NoError(t, err)
+	defer file.Close()
+
+	email, err := mail.ReadMessage(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "pixie@example.com", email.Header.Get("To"))
+	assert.Equal(t, "Your digest", email.Header.Get("Subject"))
+	assert.Contains(t, email.Header.Get("Content-Type"), "multipart/alternative")
+}
@@ -0,0 +1,16 @@
+package mailer
+
+import (
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/commons"
+)
+
+// NewMailer picks the Mailer implementation based on MAIL_DRIVER ("smtp" or "file").
+func NewMailer(config *commons.Config, idGenerator generator.IdGenerator) mailer.Mailer {
+	if config.MailDriver == "smtp" {
+		return NewSmtpMailer(config.SmtpHost, config.SmtpPort, config.SmtpUsername, config.SmtpPassword, config.MailFrom)
+	}
+
+	return NewFileSinkMailer(config.MailFileSinkDir, config.MailFrom, idGenerator)
+}
@@ -0,0 +1,56 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/textproto"
+	"time"
+)
+
+// buildMessage builds a multipart/alternative email with plain-text and HTML parts (RFC 2046).
+func buildMessage(from string, message *entity.MailMessage, now time.Time) []byte {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	writePart(writer, "text/plain; charset=UTF-8", message.Text)
+	writePart(writer, "text/html; charset=UTF-8", message.HTML)
+
+	if err := writer.Close(); err != nil {
+		panic(fmt.Errorf("mailer_err: close multipart: %v", err))
+	}
+
+	var email bytes.Buffer
+	fmt.Fprintf(&email, "From: %s\r\n", from)
+	fmt.Fprintf(&email, "To: %s\r\n", message.To)
+	fmt.Fprintf(&email, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", message.Subject))
+	fmt.Fprintf(&email, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	fmt.Fprintf(&email, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&email, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", writer.Boundary())
+	email.Write(body.Bytes())
+
+	return email.Bytes()
+}
+
+func writePart(writer *multipart.Writer, contentType string, content string) {
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Transfer-Encoding", "quoted-printable")
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		panic(fmt.Errorf("mailer_err: create part: %v", err))
+	}
+
+	encoder := quotedprintable.NewWriter(part)
+	_, err = encoder.Write([]byte(content))
+	if err == nil {
+		err = encoder.Close()
+	}
+	if err != nil {
+		panic(fmt.Errorf("mailer_err: write part: %v", err))
+	}
+}
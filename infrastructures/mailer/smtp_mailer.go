@@ -0,0 +1,42 @@
+package mailer
+
+import (
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// SmtpMailer implements Mailer by sending the emails to an SMTP server.
+type SmtpMailer struct /* implements Mailer */ {
+	address string
+	auth    smtp.Auth
+	from    string
+}
+
+func NewSmtpMailer(host string, port string, username string, password string, from string) mailer.Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SmtpMailer{
+		address: host + ":" + port,
+		auth:    auth,
+		from:    from,
+	}
+}
+
+func (m *SmtpMailer) Send(message *entity.MailMessage) {
+	sender, err := mail.ParseAddress(m.from)
+	if err != nil {
+		panic(fmt.Errorf("smtp_mailer_err: parse sender address: %v", err))
+	}
+
+	err = smtp.SendMail(m.address, m.auth, sender.Address, []string{message.To}, buildMessage(m.from, message, time.Now()))
+	if err != nil {
+		panic(fmt.Errorf("smtp_mailer_err: send mail: %v", err))
+	}
+}
@@ -0,0 +1,96 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/mailer"
+	"github.com/wisle25/task-pixie/domains/entity"
+	htmlTemplate "html/template"
+	textTemplate "text/template"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// digestSection groups tasks under a title inside the digest templates
+type digestSection struct {
+	Title string
+	Tasks []entity.DigestTask
+}
+
+func newDigestSection(title string, tasks []entity.DigestTask) digestSection {
+	return digestSection{Title: title, Tasks: tasks}
+}
+
+// TemplateMailRenderer implements MailRenderer using Go templates.
+// HTML parts are rendered with html/template, so the content is always escaped.
+type TemplateMailRenderer struct /* implements MailRenderer */ {
+	digestHTML     *htmlTemplate.Template
+	digestText     *textTemplate.Template
+	invitationHTML *htmlTemplate.Template
+	invitationText *textTemplate.Template
+	resetHTML      *htmlTemplate.Template
+	resetText      *textTemplate.Template
+}
+
+func NewTemplateMailRenderer() mailer.MailRenderer {
+	digestHTML := htmlTemplate.Must(
+		htmlTemplate.New("digest.html.tmpl").
+			Funcs(htmlTemplate.FuncMap{"section": newDigestSection}).
+			ParseFS(templates, "templates/digest.html.tmpl"),
+	)
+	digestText := textTemplate.Must(
+		textTemplate.New("digest.txt.tmpl").
+			Funcs(textTemplate.FuncMap{"section": newDigestSection}).
+			ParseFS(templates, "templates/digest.txt.tmpl"),
+	)
+
+	return &TemplateMailRenderer{
+		digestHTML:     digestHTML,
+		digestText:     digestText,
+		invitationHTML: htmlTemplate.Must(htmlTemplate.ParseFS(templates, "templates/invitation.html.tmpl")),
+		invitationText: textTemplate.Must(textTemplate.ParseFS(templates, "templates/invitation.txt.tmpl")),
+		resetHTML:      htmlTemplate.Must(htmlTemplate.ParseFS(templates, "templates/password_reset.html.tmpl")),
+		resetText:      textTemplate.Must(textTemplate.ParseFS(templates, "templates/password_reset.txt.tmpl")),
+	}
+}
+
+func (r *TemplateMailRenderer) RenderDigest(digest *entity.Digest) (string, string) {
+	var html, text bytes.Buffer
+
+	if err := r.digestHTML.Execute(&html, digest); err != nil {
+		panic(fmt.Errorf("mail_renderer_err: render digest html: %v", err))
+	}
+	if err := r.digestText.Execute(&text, digest); err != nil {
+		panic(fmt.Errorf("mail_renderer_err: render digest text: %v", err))
+	}
+
+	return html.String(), text.String()
+}
+
+func (r *TemplateMailRenderer) RenderInvitation(invitation *entity.InvitationMail) (string, string) {
+	var html, text bytes.Buffer
+
+	if err := r.invitationHTML.Execute(&html, invitation); err != nil {
+		panic(fmt.Errorf("mail_renderer_err: render invitation html: %v", err))
+	}
+	if err := r.invitationText.Execute(&text, invitation); err != nil {
+		panic(fmt.Errorf("mail_renderer_err: render invitation text: %v", err))
+	}
+
+	return html.String(), text.String()
+}
+
+func (r *TemplateMailRenderer) RenderPasswordReset(reset *entity.PasswordResetMail) (string, string) {
+	var html, text bytes.Buffer
+
+	if err := r.resetHTML.Execute(&html, reset); err != nil {
+		panic(fmt.Errorf("mail_renderer_err: render password reset html: %v", err))
+	}
+	if err := r.resetText.Execute(&text, reset); err != nil {
+		panic(fmt.Errorf("mail_renderer_err: render password reset text: %v", err))
+	}
+
+	return html.String(), text.String()
+}
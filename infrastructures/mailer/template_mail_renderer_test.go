@@ -0,0 +1,122 @@
+package mailer_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/mailer"
+)
+
+func TestTemplateMailRenderer(t *testing.T) {
+	renderer := mailer.NewTemplateMailRenderer()
+
+	digest := &entity.Digest{
+		Username:    "pixie",
+		Frequency:   entity.DigestDaily,
+		PeriodLabel: "Monday, 3 June 2024",
+		Overdue: []entity.DigestTask{
+			{Id: "1", Title: "Fix <script>alert(1)</script>", Priority: "Urgent", Status: "In Progress", Project: "Website", DueDate: "2024-06-01"},
+		},
+		Assigned: []entity.DigestTask{
+			{Id: "2", Title: "Write docs", Priority: "Low", Status: "To Do"},
+		},
+	}
+
+	t.Run("Should render both parts with every section", func(t *testing.T) {
+		// Action
+		html, text := renderer.RenderDigest(digest)
+
+		// Assert
+		for _, part := range []string{html, text} {
+			assert.Contains(t, part, "Hi pixie,")
+			assert.Contains(t, part, "Monday, 3 June 2024")
+			assert.Contains(t, part, "Overdue (1)")
+			assert.Contains(t, part, "Due soon (0)")
+			assert.Contains(t, part, "Assigned to you (1)")
+			assert.Contains(t, part, "Write docs")
+		}
+		assert.Contains(t, text, "- Fix <script>alert(1)</script> [Website] | Urgent | In Progress | due 2024-06-01")
+	})
+
+	t.Run("Should escape task content in HTML part", func(t *testing.T) {
+		// Action
+		html, _ := renderer.RenderDigest(digest)
+
+		// Assert
+		assert.NotContains(t, html, "<script>")
+		assert.Contains(t, html, "Fix &lt;script&gt;alert(1)&lt;/script&gt;")
+	})
+}
+
+func TestTemplateMailRendererInvitation(t *testing.T) {
+	renderer := mailer.NewTemplateMailRenderer()
+
+	invitation := &entity.InvitationMail{
+		Project:   "<b>Website</b>",
+		InvitedBy: "pixie",
+		Role:      entity.ProjectRoleAdmin,
+		Link:      "http://localhost:3000/invitations?token=abc.def",
+		ExpiresAt: "Monday, 10 June 2024",
+	}
+
+	t.Run("Should render the link in both parts", func(t *testing.T) {
+		// Action
+		html, text := renderer.RenderInvitation(invitation)
+
+		// Assert
+		assert.Contains(t, html, `href="http://localhost:3000/invitations?token=abc.def"`)
+		assert.Contains(t, text, "Answer the invitation: http://localhost:3000/invitations?token=abc.def")
+		assert.Contains(t, text, "pixie invited you to join <b>Website</b> on TaskPixie as admin.")
+		assert.Contains(t, text, "Sign up with this email address first")
+	})
+
+	t.Run("Should escape project name in HTML part", func(t *testing.T) {
+		// Action
+		html, _ := renderer.RenderInvitation(invitation)
+
+		// Assert
+		assert.NotContains(t, html, "<b>Website</b>")
+		assert.Contains(t, html, "&lt;b&gt;Website&lt;/b&gt;")
+	})
+
+	t.Run("Should not ask registered invitee to sign up", func(t *testing.T) {
+		// Action
+		registered := *invitation
+		registered.Registered = true
+		html, text := renderer.RenderInvitation(&registered)
+
+		// Assert
+		assert.NotContains(t, html, "Sign up")
+		assert.NotContains(t, text, "Sign up")
+	})
+}
+
+func TestTemplateMailRendererPasswordReset(t *testing.T) {
+	renderer := mailer.NewTemplateMailRenderer()
+
+	reset := &entity.PasswordResetMail{
+		Username:  "<i>pixie</i>",
+		Link:      "http://localhost:3000/password-reset?token=abc",
+		ExpiresAt: "Monday, 10 June 2024",
+	}
+
+	t.Run("Should render the link in both parts", func(t *testing.T) {
+		// Action
+		html, text := renderer.RenderPasswordReset(reset)
+
+		// Assert
+		assert.Contains(t, html, `href="http://localhost:3000/password-reset?token=abc"`)
+		assert.Contains(t, text, "Choose a new password: http://localhost:3000/password-reset?token=abc")
+		assert.Contains(t, text, "This link expires on Monday, 10 June 2024.")
+	})
+
+	t.Run("Should escape username in HTML part", func(t *testing.T) {
+		// Action
+		html, _ := renderer.RenderPasswordReset(reset)
+
+		// Assert
+		assert.NotContains(t, html, "<i>pixie</i>")
+		assert.Contains(t, html, "&lt;i&gt;pixie&lt;/i&gt;")
+	})
+}
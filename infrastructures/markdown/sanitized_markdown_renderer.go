@@ -0,0 +1,628 @@
+package markdown
+
+import (
+	"github.com/wisle25/task-pixie/applications/markdown"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))??(?:[ \t]+#+)?[ \t]*$`)
+	fencePattern       = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`\\s]*)")
+	thematicPattern    = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	quotePattern       = regexp.MustCompile(`^ {0,3}> ?`)
+	listItemPattern    = regexp.MustCompile(`^( {0,3})([-*+]|\d{1,9}[.)])( +|$)(.*)$`)
+	taskItemPattern    = regexp.MustCompile(`^\[([ xX])\](?: +|$)`)
+	languagePattern    = regexp.MustCompile(`^[\w+#-]+$`)
+	taskLinkPattern    = regexp.MustCompile(`^#([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})`)
+	mentionPattern     = regexp.MustCompile(`^@([A-Za-z0-9]{3,50})`)
+	autolinkPattern    = regexp.MustCompile(`^<((?:https?|mailto):[^\s<>]*)>`)
+	bareURLPattern     = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]+`)
+	urlTrailingPattern = regexp.MustCompile(`[.,:;!?'"*_~]+$`)
+)
+
+// SanitizedMarkdownRenderer implements MarkdownRenderer with a renderer of the common Markdown syntax:
+// CommonMark blocks and inlines, with GitHub's task lists, strikethrough and autolinks.
+// Raw HTML is escaped while rendering, the output then goes through an allow-list sanitizer.
+type SanitizedMarkdownRenderer struct{} // implements MarkdownRenderer
+
+func NewSanitizedMarkdownRenderer() markdown.MarkdownRenderer {
+	return &SanitizedMarkdownRenderer{}
+}
+
+func (r *SanitizedMarkdownRenderer) Render(source string) *entity.Markdown {
+	source = strings.NewReplacer("\r\n", "\n", "\r", "\n", "\t", "    ", "\x00", "\uFFFD").Replace(source)
+
+	var out strings.Builder
+	state := &renderState{taskLinks: []string{}, mentions: []string{}}
+	state.renderBlocks(&out, strings.Split(source, "\n"), false)
+
+	return &entity.Markdown{
+		HTML:      sanitizeHTML(out.String()),
+		TaskLinks: state.taskLinks,
+		Mentions:  state.mentions,
+	}
+}
+
+// renderState collects the task links and mentions met while rendering.
+type renderState struct {
+	taskLinks []string
+	mentions  []string
+}
+
+// renderBlocks renders the lines as blocks, paragraphs of tight list items aren't wrapped in <p>.
+func (s *renderState) renderBlocks(out *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+		case fencePattern.MatchString(line):
+			i = s.renderCodeBlock(out, lines, i)
+		case headingPattern.MatchString(line):
+			match := headingPattern.FindStringSubmatch(line)
+			level := strconv.Itoa(len(match[1]))
+			out.WriteString("<h" + level + ">" + s.renderInline(strings.TrimSpace(match[2]), false) + "</h" + level + ">\n")
+			i++
+		case thematicPattern.MatchString(line):
+			out.WriteString("<hr>\n")
+			i++
+		case quotePattern.MatchString(line):
+			i = s.renderBlockquote(out, lines, i)
+		case listItemPattern.MatchString(line):
+			i = s.renderList(out, lines, i)
+		default:
+			i = s.renderParagraph(out, lines, i, tight)
+		}
+	}
+}
+
+// isBlockStart tells whether the line starts a block other than a paragraph, interrupting paragraphs.
+func isBlockStart(line string) bool {
+	return fencePattern.MatchString(line) ||
+		headingPattern.MatchString(line) ||
+		thematicPattern.MatchString(line) ||
+		quotePattern.MatchString(line) ||
+		listItemPattern.MatchString(line)
+}
+
+func (s *renderState) renderCodeBlock(out *strings.Builder, lines []string, i int) int {
+	match := fencePattern.FindStringSubmatch(lines[i])
+	indent, fence, language := len(match[1]), match[2], match[3]
+
+	var code []string
+	j := i + 1
+	for ; j < len(lines); j++ {
+		closing := strings.TrimSpace(lines[j])
+		if len(closing) >= len(fence) && strings.Trim(closing, fence[:1]) == "" {
+			j++
+			break
+		}
+
+		// The indentation of the opening fence is removed from the content
+		line := lines[j]
+		line = line[min(indent, leadingSpaces(line)):]
+		code = append(code, line)
+	}
+
+	out.WriteString("<pre><code")
+	if languagePattern.MatchString(language) {
+		out.WriteString(` class="language-` + escapeHTML(language) + `"`)
+	}
+	out.WriteString(">")
+	if len(code) > 0 {
+		out.WriteString(escapeHTML(strings.Join(code, "\n")) + "\n")
+	}
+	out.WriteString("</code></pre>\n")
+
+	return j
+}
+
+func (s *renderState) renderBlockquote(out *strings.Builder, lines []string, i int) int {
+	var inner []string
+	j := i
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if quotePattern.MatchString(line) {
+			inner = append(inner, quotePattern.ReplaceAllString(line, ""))
+			continue
+		}
+
+		// Lazy continuation of a paragraph of the quote
+		if strings.TrimSpace(line) == "" || isBlockStart(line) || strings.TrimSpace(inner[len(inner)-1]) == "" {
+			break
+		}
+		inner = append(inner, line)
+	}
+
+	out.WriteString("<blockquote>\n")
+	s.renderBlocks(out, inner, false)
+	out.WriteString("</blockquote>\n")
+
+	return j
+}
+
+func (s *renderState) renderList(out *strings.Builder, lines []string, i int) int {
+	first := listItemPattern.FindStringSubmatch(lines[i])
+	kind := listKind(first[2])
+
+	if kind == "." || kind == ")" {
+		start, _ := strconv.Atoi(first[2][:len(first[2])-1])
+		if start == 1 {
+			out.WriteString("<ol>\n")
+		} else {
+			out.WriteString(`<ol start="` + strconv.Itoa(start) + `">` + "\n")
+		}
+	} else {
+		out.WriteString("<ul>\n")
+	}
+
+	j := i
+	for j < len(lines) {
+		match := listItemPattern.FindStringSubmatch(lines[j])
+		if match == nil || listKind(match[2]) != kind || thematicPattern.MatchString(lines[j]) {
+			break
+		}
+
+		// Content is indented past the marker, unless it's indented code
+		indent := len(match[1]) + len(match[2]) + len(match[3])
+		content := []string{match[4]}
+		if match[3] == "" || len(match[3]) > 4 {
+			indent = len(match[1]) + len(match[2]) + 1
+			content[0] = strings.Repeat(" ", max(len(match[3])-1, 0)) + match[4]
+		}
+
+		for j++; j < len(lines); j++ {
+			line := lines[j]
+			if strings.TrimSpace(line) == "" {
+				// Blank lines are part of the item when it goes on afterward
+				next := j
+				for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+					next++
+				}
+				if next < len(lines) && leadingSpaces(lines[next]) >= indent {
+					content = append(content, make([]string, next-j)...)
+					j = next - 1
+					continue
+				}
+				break
+			}
+
+			if leadingSpaces(line) >= indent {
+				content = append(content, line[indent:])
+				continue
+			}
+
+			// Lazy continuation of a paragraph of the item
+			if isBlockStart(line) || strings.TrimSpace(content[len(content)-1]) == "" {
+				break
+			}
+			content = append(content, line)
+		}
+
+		s.renderListItem(out, content, kind)
+
+		// Items may be separated by blank lines
+		next := j
+		for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+			next++
+		}
+		if next < len(lines) && next > j {
+			if match = listItemPattern.FindStringSubmatch(lines[next]); match != nil && listKind(match[2]) == kind {
+				j = next
+			}
+		}
+	}
+
+	if kind == "." || kind == ")" {
+		out.WriteString("</ol>\n")
+	} else {
+		out.WriteString("</ul>\n")
+	}
+
+	return j
+}
+
+func (s *renderState) renderListItem(out *strings.Builder, content []string, kind string) {
+	// Items made of paragraphs separated by blank lines are loose, their paragraphs are kept
+	tight := true
+	for k := 1; k < len(content)-1; k++ {
+		if strings.TrimSpace(content[k]) == "" {
+			tight = false
+		}
+	}
+
+	out.WriteString("<li")
+	if match := taskItemPattern.FindStringSubmatch(content[0]); match != nil && kind != "." && kind != ")" {
+		out.WriteString(` class="task-list-item"><input type="checkbox"`)
+		if match[1] != " " {
+			out.WriteString(" checked")
+		}
+		out.WriteString(" disabled> ")
+		content[0] = content[0][len(match[0]):]
+	} else {
+		out.WriteString(">")
+	}
+
+	var item strings.Builder
+	s.renderBlocks(&item, content, tight)
+	out.WriteString(strings.TrimSuffix(item.String(), "\n"))
+	out.WriteString("</li>\n")
+}
+
+// listKind returns the bullet or the delimiter of ordered lists, items of another kind start another list.
+func listKind(marker string) string {
+	return marker[len(marker)-1:]
+}
+
+func (s *renderState) renderParagraph(out *strings.Builder, lines []string, i int, tight bool) int {
+	var text []string
+	j := i
+	for ; j < len(lines); j++ {
+		line := lines[j]
+		if strings.TrimSpace(line) == "" || (j > i && isBlockStart(line)) {
+			break
+		}
+
+		// Lines ending with two spaces break, like the ones ending with a backslash
+		trimmed := strings.TrimRight(strings.TrimLeft(line, " "), " ")
+		if len(line)-len(strings.TrimRight(line, " ")) >= 2 {
+			trimmed += "\\"
+		}
+		text = append(text, trimmed)
+	}
+
+	// Breaks are only made between lines
+	last := len(text) - 1
+	text[last] = strings.TrimRight(strings.TrimSuffix(text[last], "\\"), " ")
+	if strings.HasSuffix(lines[i+last], "\\") {
+		text[last] += "\\"
+	}
+
+	content := s.renderInline(strings.Join(text, "\n"), false)
+	if tight {
+		out.WriteString(content + "\n")
+	} else {
+		out.WriteString("<p>" + content + "</p>\n")
+	}
+
+	return j
+}
+
+// renderInline renders the inlines of the text, inside links nothing else is linked.
+func (s *renderState) renderInline(text string, inLink bool) string {
+	var out strings.Builder
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		var prev byte
+		if i > 0 {
+			prev = text[i-1]
+		}
+
+		switch {
+		case c == '\\' && i+1 < len(text) && text[i+1] == '\n':
+			out.WriteString("<br>\n")
+			i += 2
+			continue
+		case c == '\\' && i+1 < len(text) && isPunctuation(text[i+1]):
+			out.WriteString(escapeHTML(text[i+1 : i+2]))
+			i += 2
+			continue
+		case c == '`':
+			if code, n := renderCodeSpan(text[i:]); n > 0 {
+				out.WriteString(code)
+				i += n
+				continue
+			}
+
+			// Unmatched backticks are literal, the whole run at once
+			n := len(text[i:]) - len(strings.TrimLeft(text[i:], "`"))
+			out.WriteString(text[i : i+n])
+			i += n
+			continue
+		case c == '!' && i+1 < len(text) && text[i+1] == '[':
+			if label, destination, title, n := parseLink(text[i+1:]); n > 0 {
+				out.WriteString(`<img src="` + escapeHTML(destination) + `" alt="` + escapeHTML(plainText(label)) + `"`)
+				if title != "" {
+					out.WriteString(` title="` + escapeHTML(title) + `"`)
+				}
+				out.WriteString(">")
+				i += n + 1
+				continue
+			}
+		case c == '[' && !inLink:
+			if label, destination, title, n := parseLink(text[i:]); n > 0 {
+				out.WriteString(`<a href="` + escapeHTML(destination) + `"`)
+				if title != "" {
+					out.WriteString(` title="` + escapeHTML(title) + `"`)
+				}
+				out.WriteString(">" + s.renderInline(label, true) + "</a>")
+				i += n
+				continue
+			}
+		case c == '<' && !inLink:
+			if match := autolinkPattern.FindStringSubmatch(text[i:]); match != nil {
+				out.WriteString(`<a href="` + escapeHTML(match[1]) + `">` + escapeHTML(match[1]) + "</a>")
+				i += len(match[0])
+				continue
+			}
+		case c == '*' || c == '_' || c == '~':
+			if emphasis, n := s.renderEmphasis(text[i:], prev, inLink); n > 0 {
+				out.WriteString(emphasis)
+				i += n
+				continue
+			}
+
+			// Unmatched delimiters are literal, the whole run at once
+			n := len(text[i:]) - len(strings.TrimLeft(text[i:], string(c)))
+			out.WriteString(text[i : i+n])
+			i += n
+			continue
+		case c == '#' && !inLink && !isWordByte(prev):
+			if match := taskLinkPattern.FindStringSubmatch(text[i:]); match != nil && !isWordAt(text, i+len(match[0])) {
+				id := strings.ToLower(match[1])
+				s.taskLinks = appendUnique(s.taskLinks, id)
+				out.WriteString(`<a href="/tasks/` + id + `" class="task-link">#` + id + "</a>")
+				i += len(match[0])
+				continue
+			}
+		case c == '@' && !inLink && !isWordByte(prev) && prev != '@':
+			if match := mentionPattern.FindStringSubmatch(text[i:]); match != nil && !isWordAt(text, i+len(match[0])) {
+				s.mentions = appendUnique(s.mentions, match[1])
+				out.WriteString(`<span class="mention">@` + match[1] + "</span>")
+				i += len(match[0])
+				continue
+			}
+		case (c == 'h' || c == 'w') && !inLink && !isWordByte(prev):
+			if url := bareURL(text[i:]); url != "" {
+				href := url
+				if strings.HasPrefix(url, "www.") {
+					href = "http://" + url
+				}
+				out.WriteString(`<a href="` + escapeHTML(href) + `">` + escapeHTML(url) + "</a>")
+				i += len(url)
+				continue
+			}
+		}
+
+		out.WriteString(escapeHTML(text[i : i+1]))
+		i++
+	}
+
+	return out.String()
+}
+
+// renderEmphasis renders the emphasis opened at the start of text, returning how much of text it took.
+// A single delimiter emphasizes, two make it strong, three both. Strikethrough takes two tildes.
+func (s *renderState) renderEmphasis(text string, prev byte, inLink bool) (string, int) {
+	delimiter := text[0]
+	run := len(text) - len(strings.TrimLeft(text, string(delimiter)))
+
+	// Underscores don't emphasize inside words
+	if delimiter == '_' && isWordByte(prev) {
+		return "", 0
+	}
+	if (delimiter == '~' && run != 2) || run > 3 {
+		return "", 0
+	}
+	if run >= len(text) || isSpace(text[run]) {
+		return "", 0
+	}
+
+	for p := run; p < len(text); {
+		switch text[p] {
+		case '\\':
+			p += 2
+			continue
+		case '`':
+			if _, n := renderCodeSpan(text[p:]); n > 0 {
+				p += n
+				continue
+			}
+		case delimiter:
+			closing := len(text[p:]) - len(strings.TrimLeft(text[p:], string(delimiter)))
+			after := p + closing
+			closes := closing == run && !isSpace(text[p-1]) && (delimiter != '_' || !isWordAt(text, after))
+			if !closes {
+				p += closing
+				continue
+			}
+
+			content := s.renderInline(text[run:p], inLink)
+			switch {
+			case delimiter == '~':
+				return "<del>" + content + "</del>", after
+			case run == 1:
+				return "<em>" + content + "</em>", after
+			case run == 2:
+				return "<strong>" + content + "</strong>", after
+			default:
+				return "<em><strong>" + content + "</strong></em>", after
+			}
+		}
+		p++
+	}
+
+	return "", 0
+}
+
+// renderCodeSpan renders the code span opened at the start of text, returning how much of text it took.
+func renderCodeSpan(text string) (string, int) {
+	run := len(text) - len(strings.TrimLeft(text, "`"))
+	fence := text[:run]
+
+	for p := run; p < len(text); {
+		index := strings.Index(text[p:], fence)
+		if index < 0 {
+			return "", 0
+		}
+		p += index
+
+		// The closing run must be as long as the opening one
+		closing := len(text[p:]) - len(strings.TrimLeft(text[p:], "`"))
+		if closing != run {
+			p += closing
+			continue
+		}
+
+		code := strings.ReplaceAll(text[run:p], "\n", " ")
+		if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+			code = code[1 : len(code)-1]
+		}
+
+		return "<code>" + escapeHTML(code) + "</code>", p + run
+	}
+
+	return "", 0
+}
+
+// parseLink parses [label](destination "title") at the start of text, returning how much of text it took.
+func parseLink(text string) (label string, destination string, title string, n int) {
+	depth := 0
+	end := -1
+	for p := 0; p < len(text) && end < 0; p++ {
+		switch text[p] {
+		case '\\':
+			p++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				end = p
+			}
+		}
+	}
+	if end < 0 || end+1 >= len(text) || text[end+1] != '(' {
+		return "", "", "", 0
+	}
+	label = text[1:end]
+
+	p := skipSpaces(text, end+2)
+	if p < len(text) && text[p] == '<' {
+		closing := strings.IndexAny(text[p+1:], ">\n")
+		if closing < 0 || text[p+1+closing] != '>' {
+			return "", "", "", 0
+		}
+		destination = text[p+1 : p+1+closing]
+		p += closing + 2
+	} else {
+		start := p
+		parentheses := 0
+		for ; p < len(text) && !isSpace(text[p]); p++ {
+			if text[p] == '(' {
+				parentheses++
+			} else if text[p] == ')' {
+				if parentheses == 0 {
+					break
+				}
+				parentheses--
+			}
+		}
+		destination = text[start:p]
+	}
+
+	p = skipSpaces(text, p)
+	if p < len(text) && (text[p] == '"' || text[p] == '\'') {
+		closing := strings.IndexByte(text[p+1:], text[p])
+		if closing < 0 {
+			return "", "", "", 0
+		}
+		title = text[p+1 : p+1+closing]
+		p = skipSpaces(text, p+closing+2)
+	}
+
+	if p >= len(text) || text[p] != ')' {
+		return "", "", "", 0
+	}
+
+	return label, unescapePunctuation(destination), unescapePunctuation(title), p + 1
+}
+
+// bareURL returns the URL at the start of text, without the punctuation ending its sentence.
+func bareURL(text string) string {
+	url := bareURLPattern.FindString(text)
+	if url == "" {
+		return ""
+	}
+
+	for {
+		trimmed := urlTrailingPattern.ReplaceAllString(url, "")
+		if strings.HasSuffix(trimmed, ")") && strings.Count(trimmed, ")") > strings.Count(trimmed, "(") {
+			trimmed = trimmed[:len(trimmed)-1]
+		}
+		if trimmed == url {
+			break
+		}
+		url = trimmed
+	}
+	if url == "www." || strings.HasSuffix(url, "://") {
+		return ""
+	}
+
+	return url
+}
+
+// plainText returns the text of a label without its Markdown, for the alternative text of images.
+func plainText(label string) string {
+	return strings.NewReplacer("*", "", "_", "", "`", "", "~", "", "[", "", "]", "").Replace(label)
+}
+
+func unescapePunctuation(text string) string {
+	var out strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && isPunctuation(text[i+1]) {
+			i++
+		}
+		out.WriteByte(text[i])
+	}
+
+	return out.String()
+}
+
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if strings.EqualFold(existing, value) {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+func escapeHTML(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&#39;").Replace(text)
+}
+
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func skipSpaces(text string, p int) int {
+	for p < len(text) && isSpace(text[p]) {
+		p++
+	}
+
+	return p
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n'
+}
+
+func isPunctuation(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+// isWordByte tells whether the byte belongs to a word, bytes of non-ASCII characters do.
+func isWordByte(c byte) bool {
+	return c == '_' || c >= 0x80 || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isWordAt(text string, i int) bool {
+	return i < len(text) && isWordByte(text[i])
+}
@@ -0,0 +1,77 @@
+package markdown_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/infrastructures/markdown"
+)
+
+func TestSanitizedMarkdownRenderer(t *testing.T) {
+	renderer := markdown.NewSanitizedMarkdownRenderer()
+
+	t.Run("Should render blocks", func(t *testing.T) {
+		cases := map[string]string{
+			"# Release *notes*":                     "<h1>Release <em>notes</em></h1>\n",
+			"Line one\nline two  \nthree":           "<p>Line one\nline two<br>\nthree</p>\n",
+			"First\n\nSecond":                       "<p>First</p>\n<p>Second</p>\n",
+			"> Quoted\n> **twice**":                 "<blockquote>\n<p>Quoted\n<strong>twice</strong></p>\n</blockquote>\n",
+			"```go\nif a < b {}\n```":               "<pre><code class=\"language-go\">if a &lt; b {}\n</code></pre>\n",
+			"- one\n- two\n  - nested":              "<ul>\n<li>one</li>\n<li>two\n<ul>\n<li>nested</li>\n</ul></li>\n</ul>\n",
+			"3. three\n4. four":                     "<ol start=\"3\">\n<li>three</li>\n<li>four</li>\n</ol>\n",
+			"- [x] Tag\n- [ ] Announce":             "<ul>\n<li class=\"task-list-item\"><input type=\"checkbox\" checked disabled> Tag</li>\n<li class=\"task-list-item\"><input type=\"checkbox\" disabled> Announce</li>\n</ul>\n",
+			"Above\n\n---\n\nBelow":                 "<p>Above</p>\n<hr>\n<p>Below</p>\n",
+			"Release\n- [x] Tag\n\nLabels: bug, ui": "<p>Release</p>\n<ul>\n<li class=\"task-list-item\"><input type=\"checkbox\" checked disabled> Tag</li>\n</ul>\n<p>Labels: bug, ui</p>\n",
+		}
+
+		for source, expected := range cases {
+			assert.Equal(t, expected, renderer.Render(source).HTML, source)
+		}
+	})
+
+	t.Run("Should render inlines", func(t *testing.T) {
+		cases := map[string]string{
+			"**bold**, *em*, _em_ and ~~gone~~":        "<p><strong>bold</strong>, <em>em</em>, <em>em</em> and <del>gone</del></p>\n",
+			"snake_case_name stays":                    "<p>snake_case_name stays</p>\n",
+			"`<b>code</b>`":                            "<p><code>&lt;b&gt;code&lt;/b&gt;</code></p>\n",
+			`\*not emphasized\*`:                       "<p>*not emphasized*</p>\n",
+			"[docs](https://pixie.dev/a_(b) \"Docs\")": "<p><a href=\"https://pixie.dev/a_(b)\" title=\"Docs\" rel=\"nofollow noopener noreferrer\">docs</a></p>\n",
+			"See https://pixie.dev/docs.":              "<p>See <a href=\"https://pixie.dev/docs\" rel=\"nofollow noopener noreferrer\">https://pixie.dev/docs</a>.</p>\n",
+			"![logo](https://pixie.dev/logo.png)":      "<p><img src=\"https://pixie.dev/logo.png\" alt=\"logo\"></p>\n",
+		}
+
+		for source, expected := range cases {
+			assert.Equal(t, expected, renderer.Render(source).HTML, source)
+		}
+	})
+
+	t.Run("Should extract task links and mentions", func(t *testing.T) {
+		// Action
+		rendered := renderer.Render("Blocked by #550E8400-E29B-41D4-A716-446655440000, ask @pixie and @Pixie.\n" +
+			"Not a mention: pixie@mail.com, nor `@dixie` nor #550e8400")
+
+		// Assert
+		assert.Equal(t, []string{"550e8400-e29b-41d4-a716-446655440000"}, rendered.TaskLinks)
+		assert.Equal(t, []string{"pixie"}, rendered.Mentions)
+		assert.Contains(t, rendered.HTML, `<a href="/tasks/550e8400-e29b-41d4-a716-446655440000" class="task-link" rel="nofollow noopener noreferrer">#550e8400-e29b-41d4-a716-446655440000</a>`)
+		assert.Contains(t, rendered.HTML, `<span class="mention">@pixie</span>`)
+		assert.Contains(t, rendered.HTML, "pixie@mail.com")
+	})
+
+	t.Run("Should not let scripts through", func(t *testing.T) {
+		cases := map[string]string{
+			"<script>alert(1)</script>":                "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>\n",
+			`<img src=x onerror="alert(1)">`:           "<p>&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>\n",
+			"[click](javascript:alert(1))":             "<p><a rel=\"nofollow noopener noreferrer\">click</a></p>\n",
+			"[click](JaVaScRiPt:alert(1))":             "<p><a rel=\"nofollow noopener noreferrer\">click</a></p>\n",
+			"[click](java\u0001script:alert(1))":       "<p><a rel=\"nofollow noopener noreferrer\">click</a></p>\n",
+			"![x](data:text/html;base64,PHNjcmlwdD4=)": "<p></p>\n",
+			`[x](https://a.b/" onmouseover="alert(1))`: "<p>[x](<a href=\"https://a.b/\" rel=\"nofollow noopener noreferrer\">https://a.b/</a>&#34; onmouseover=&#34;alert(1))</p>\n",
+			"<https://a.b/\"onclick=alert(1)>":         "<p><a href=\"https://a.b/&#34;onclick=alert(1)\" rel=\"nofollow noopener noreferrer\">https://a.b/&#34;onclick=alert(1)</a></p>\n",
+		}
+
+		for source, expected := range cases {
+			assert.Equal(t, expected, renderer.Render(source).HTML, source)
+		}
+	})
+}
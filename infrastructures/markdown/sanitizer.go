@@ -0,0 +1,198 @@
+package markdown
+
+import (
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// allowedTags lists the tags kept by the sanitizer, with their allowed attributes.
+// Other tags are dropped but their content is kept, except the ones of droppedTags.
+var allowedTags = map[string][]string{
+	"p":          nil,
+	"br":         nil,
+	"hr":         nil,
+	"h1":         nil,
+	"h2":         nil,
+	"h3":         nil,
+	"h4":         nil,
+	"h5":         nil,
+	"h6":         nil,
+	"strong":     nil,
+	"em":         nil,
+	"del":        nil,
+	"code":       {"class"},
+	"pre":        nil,
+	"blockquote": nil,
+	"ul":         nil,
+	"ol":         {"start"},
+	"li":         {"class"},
+	"a":          {"href", "title", "class"},
+	"img":        {"src", "alt", "title"},
+	"input":      {"type", "checked", "disabled"},
+	"span":       {"class"},
+}
+
+// droppedTags lists the tags dropped along with their content.
+var droppedTags = map[string]bool{
+	"script":    true,
+	"style":     true,
+	"iframe":    true,
+	"object":    true,
+	"embed":     true,
+	"template":  true,
+	"noscript":  true,
+	"noembed":   true,
+	"noframes":  true,
+	"textarea":  true,
+	"title":     true,
+	"xmp":       true,
+	"plaintext": true,
+	"svg":       true,
+	"math":      true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true, "input": true}
+
+// Classes are limited to the ones set by the renderer, pages can't be restyled by users
+var allowedClassPattern = regexp.MustCompile(`^(?:task-link|mention|task-list-item|language-[\w+#-]+)$`)
+
+var allowedSchemes = map[string]bool{"http": true, "https": true, "mailto": true}
+
+// sanitizeHTML keeps only the allowed tags and attributes of the fragment, and links to safe URLs.
+func sanitizeHTML(fragment string) string {
+	context := &html.Node{Type: html.ElementNode, Data: "div", DataAtom: atom.Div}
+	nodes, err := html.ParseFragment(strings.NewReader(fragment), context)
+	if err != nil {
+		// Nothing is trusted when the fragment can't be read
+		return html.EscapeString(fragment)
+	}
+
+	var out strings.Builder
+	for _, node := range nodes {
+		writeSanitized(&out, node)
+	}
+
+	return out.String()
+}
+
+func writeSanitized(out *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		out.WriteString(html.EscapeString(node.Data))
+		return
+	case html.ElementNode:
+	default:
+		// Comments and doctypes are dropped
+		return
+	}
+
+	if droppedTags[node.Data] {
+		return
+	}
+
+	attributes, allowed := allowedTags[node.Data]
+	if !allowed {
+		writeChildren(out, node)
+		return
+	}
+
+	// Images must have a safe source, and inputs are only the checkboxes of task lists
+	if (node.Data == "img" && safeURL(attribute(node, "src")) == "") || (node.Data == "input" && attribute(node, "type") != "checkbox") {
+		return
+	}
+
+	out.WriteString("<" + node.Data)
+	for _, attr := range node.Attr {
+		if attr.Namespace != "" || !slices.Contains(attributes, attr.Key) {
+			continue
+		}
+
+		value := attr.Val
+		switch attr.Key {
+		case "href", "src":
+			value = safeURL(value)
+		case "class":
+			if !allowedClassPattern.MatchString(value) {
+				value = ""
+			}
+		case "start":
+			if strings.Trim(value, "0123456789") != "" || len(value) > 9 {
+				value = ""
+			}
+		case "checked", "disabled":
+			out.WriteString(" " + attr.Key)
+			continue
+		}
+
+		if value != "" {
+			out.WriteString(" " + attr.Key + `="` + html.EscapeString(value) + `"`)
+		}
+	}
+
+	switch node.Data {
+	case "a":
+		// Links of users aren't endorsed, nor given access to the page opening them
+		out.WriteString(` rel="nofollow noopener noreferrer"`)
+	case "input":
+		// Checkboxes only show the state of task lists
+		if !hasAttribute(node, "disabled") {
+			out.WriteString(" disabled")
+		}
+	}
+	out.WriteString(">")
+
+	if voidTags[node.Data] {
+		return
+	}
+
+	writeChildren(out, node)
+	out.WriteString("</" + node.Data + ">")
+}
+
+func writeChildren(out *strings.Builder, node *html.Node) {
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeSanitized(out, child)
+	}
+}
+
+// safeURL returns the URL when it's relative or of an allowed scheme, empty otherwise.
+func safeURL(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return ""
+	}
+
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return ""
+	}
+	if parsed.Scheme != "" && !allowedSchemes[strings.ToLower(parsed.Scheme)] {
+		return ""
+	}
+
+	return value
+}
+
+func attribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+
+	return ""
+}
+
+func hasAttribute(node *html.Node, key string) bool {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return true
+		}
+	}
+
+	return false
+}
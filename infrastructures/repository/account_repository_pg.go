@@ -0,0 +1,540 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"slices"
+	"time"
+)
+
+// Columns scanned by queryExports
+var exportColumns = `
+	id, user_id, status, error, file_name,
+	` + nullableUTCTimestamp("completed_at") + `, ` + nullableUTCTimestamp("expires_at") + `,
+	COALESCE(expires_at <= NOW(), FALSE), ` + utcTimestamp("created_at")
+
+type AccountRepositoryPG struct /* implements AccountRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewAccountRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.AccountRepository {
+	return &AccountRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *AccountRepositoryPG) AddExport(userId string) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	query := `INSERT INTO user_exports(id, user_id) VALUES ($1, $2)`
+	_, err := r.db.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: add export: %v", err))
+	}
+
+	return id
+}
+
+func (r *AccountRepositoryPG) GetLatestExport(userId string) *entity.AccountExport {
+	query := `
+		SELECT` + exportColumns + `
+		FROM user_exports
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1`
+
+	exports := r.queryExports(query, userId)
+	if len(exports) == 0 {
+		return nil
+	}
+
+	return &exports[0]
+}
+
+func (r *AccountRepositoryPG) ClaimPendingExports(limit int) []entity.AccountExport {
+	// The lease is long enough to build the biggest archives
+	query := `
+		UPDATE user_exports
+		SET leased_until = NOW() + INTERVAL '15 minutes'
+		WHERE id IN (
+			SELECT id FROM user_exports
+			WHERE status = 'pending' AND (leased_until IS NULL OR leased_until <= NOW())
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING` + exportColumns
+
+	return r.queryExports(query, limit)
+}
+
+func (r *AccountRepositoryPG) CompleteExport(id string, fileName string, ttl time.Duration) {
+	query := `
+		UPDATE user_exports
+		SET status = 'ready', file_name = $2, leased_until = NULL,
+			completed_at = NOW(), expires_at = NOW() + make_interval(secs => $3)
+		WHERE id = $1`
+
+	_, err := r.db.Exec(query, id, fileName, ttl.Seconds())
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: complete export: %v", err))
+	}
+}
+
+func (r *AccountRepositoryPG) FailExport(id string, message string) {
+	query := `
+		UPDATE user_exports
+		SET status = 'failed', error = $2, leased_until = NULL, completed_at = NOW()
+		WHERE id = $1`
+
+	_, err := r.db.Exec(query, id, message)
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: fail export: %v", err))
+	}
+}
+
+func (r *AccountRepositoryPG) DeleteExpiredExports() []string {
+	query := `DELETE FROM user_exports WHERE expires_at <= NOW() RETURNING file_name`
+
+	return r.queryStrings(query)
+}
+
+func (r *AccountRepositoryPG) GetExportFileNames(userId string) []string {
+	query := `SELECT file_name FROM user_exports WHERE user_id = $1 AND file_name <> ''`
+
+	return r.queryStrings(query, userId)
+}
+
+func (r *AccountRepositoryPG) GetAccountData(userId string) *entity.AccountData {
+	data := &entity.AccountData{
+		Organizations: []entity.AccountOrganization{},
+		Projects:      []entity.AccountProject{},
+		Tasks:         []entity.AccountTask{},
+		TimeEntries:   []entity.AccountTimeEntry{},
+		Views:         []entity.AccountView{},
+	}
+
+	query := `
+		SELECT o.id, o.name, om.role, ` + utcTimestamp("om.created_at") + `
+		FROM organization_members om
+		INNER JOIN organizations o ON o.id = om.organization_id
+		WHERE om.user_id = $1
+		ORDER BY om.created_at`
+	r.scanRows("organizations", query, func(rows *sql.Rows) error {
+		var organization entity.AccountOrganization
+		err := rows.Scan(&organization.Id, &organization.Name, &organization.Role, &organization.JoinedAt)
+		data.Organizations = append(data.Organizations, organization)
+
+		return err
+	}, userId)
+
+	query = `
+		SELECT p.id, o.name, p.title, COALESCE(p.detail, ''), COALESCE(p.priority, ''), COALESCE(p.status, ''),
+			CASE WHEN p.owner_id = $1 THEN 'owner' ELSE pm.role END, ` + utcTimestamp("p.created_at") + `
+		FROM projects p
+		INNER JOIN organizations o ON o.id = p.organization_id
+		LEFT JOIN project_members pm ON pm.project_id = p.id AND pm.user_id = $1
+		WHERE (p.owner_id = $1 OR pm.user_id IS NOT NULL) AND p.deleted_at IS NULL
+		ORDER BY p.created_at`
+	r.scanRows("projects", query, func(rows *sql.Rows) error {
+		var project entity.AccountProject
+		err := rows.Scan(
+			&project.Id,
+			&project.Organization,
+			&project.Title,
+			&project.Detail,
+			&project.Priority,
+			&project.Status,
+			&project.Role,
+			&project.CreatedAt,
+		)
+		data.Projects = append(data.Projects, project)
+
+		return err
+	}, userId)
+
+	query = `
+		SELECT t.id, COALESCE(p.title, ''), t.title, t.description, COALESCE(t.detail, ''), t.priority, t.status,
+			COALESCE(to_char(t.due_date, 'YYYY-MM-DD'), ''), t.owner_id = $1, ta.user_id IS NOT NULL,
+			` + utcTimestamp("t.created_at") + `, ` + utcTimestamp("t.updated_at") + `
+		FROM tasks t
+		LEFT JOIN projects p ON p.id = t.project_id
+		LEFT JOIN task_assignments ta ON ta.task_id = t.id AND ta.user_id = $1
+		WHERE (t.owner_id = $1 OR ta.user_id IS NOT NULL) AND t.deleted_at IS NULL
+		ORDER BY t.created_at`
+	r.scanRows("tasks", query, func(rows *sql.Rows) error {
+		var task entity.AccountTask
+		err := rows.Scan(
+			&task.Id,
+			&task.Project,
+			&task.Title,
+			&task.Description,
+			&task.Detail,
+			&task.Priority,
+			&task.Status,
+			&task.DueDate,
+			&task.Owned,
+			&task.Assigned,
+			&task.CreatedAt,
+			&task.UpdatedAt,
+		)
+		data.Tasks = append(data.Tasks, task)
+
+		return err
+	}, userId)
+
+	query = `
+		SELECT e.id, t.title, e.note, ` + utcTimestamp("e.started_at") + `, ` + nullableUTCTimestamp("e.ended_at") + `
+		FROM time_entries e
+		INNER JOIN tasks t ON t.id = e.task_id
+		WHERE e.user_id = $1
+		ORDER BY e.started_at`
+	r.scanRows("time entries", query, func(rows *sql.Rows) error {
+		var entry entity.AccountTimeEntry
+		err := rows.Scan(&entry.Id, &entry.Task, &entry.Note, &entry.StartedAt, &entry.EndedAt)
+		data.TimeEntries = append(data.TimeEntries, entry)
+
+		return err
+	}, userId)
+
+	query = `
+		SELECT v.id, v.name, v.query, COALESCE(p.title, ''), ` + utcTimestamp("v.created_at") + `
+		FROM task_views v
+		LEFT JOIN projects p ON p.id = v.project_id
+		WHERE v.owner_id = $1
+		ORDER BY v.created_at`
+	r.scanRows("views", query, func(rows *sql.Rows) error {
+		var view entity.AccountView
+		err := rows.Scan(&view.Id, &view.Name, &view.Query, &view.Project, &view.CreatedAt)
+		data.Views = append(data.Views, view)
+
+		return err
+	}, userId)
+
+	return data
+}
+
+func (r *AccountRepositoryPG) GetSharedOwnedProjects(userId string) []entity.PreviewProject {
+	query := `
+		SELECT p.id, p.title, p.organization_id
+		FROM projects p
+		WHERE p.owner_id = $1
+		  AND p.deleted_at IS NULL
+		  AND NOT p.is_template
+		  AND (
+			EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id <> $1)
+			OR EXISTS (SELECT 1 FROM project_teams pt WHERE pt.project_id = p.id)
+		  )
+		ORDER BY p.title`
+
+	projects := []entity.PreviewProject{}
+	r.scanRows("shared owned projects", query, func(rows *sql.Rows) error {
+		var project entity.PreviewProject
+		err := rows.Scan(&project.Id, &project.Title, &project.OrganizationId)
+		projects = append(projects, project)
+
+		return err
+	}, userId)
+
+	return projects
+}
+
+func (r *AccountRepositoryPG) ScheduleDeletion(userId string, gracePeriod time.Duration) string {
+	// Asking again keeps the first schedule
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = COALESCE(deletion_scheduled_at, NOW() + make_interval(secs => $2))
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + utcTimestamp("deletion_scheduled_at")
+
+	var scheduledAt string
+	err := r.db.QueryRow(query, userId, gracePeriod.Seconds()).Scan(&scheduledAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+
+		panic(fmt.Errorf("account_repo_pg_error: schedule deletion: %v", err))
+	}
+
+	return scheduledAt
+}
+
+func (r *AccountRepositoryPG) CancelDeletion(userId string) {
+	query := `
+		UPDATE users
+		SET deletion_scheduled_at = NULL
+		WHERE id = $1 AND deleted_at IS NULL AND deletion_scheduled_at IS NOT NULL`
+
+	result, err := r.db.Exec(query, userId)
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: cancel deletion: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Account deletion is not scheduled!"))
+	}
+}
+
+func (r *AccountRepositoryPG) GetAccountsDueForDeletion() []string {
+	query := `
+		SELECT id FROM users
+		WHERE deletion_scheduled_at <= NOW() AND deleted_at IS NULL
+		ORDER BY deletion_scheduled_at`
+
+	return r.queryStrings(query)
+}
+
+func (r *AccountRepositoryPG) AnonymizeAccount(userId string) []string {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	statements := []struct {
+		name  string
+		query string
+	}{
+		{
+			// The organizations nobody else is part of go with their projects
+			"delete own organizations",
+			`DELETE FROM organizations o
+			 WHERE o.id IN (SELECT organization_id FROM organization_members WHERE user_id = $1 AND role = 'owner')
+			   AND NOT EXISTS (SELECT 1 FROM organization_members om WHERE om.organization_id = o.id AND om.user_id <> $1)`,
+		},
+		{
+			// The other organizations are handed to an admin, or else to their oldest member
+			"hand over organizations",
+			`UPDATE organization_members om
+			 SET role = 'owner'
+			 FROM (
+				SELECT DISTINCT ON (other.organization_id) other.organization_id, other.user_id
+				FROM organization_members mine
+				INNER JOIN organization_members other
+					ON other.organization_id = mine.organization_id AND other.user_id <> $1
+				WHERE mine.user_id = $1 AND mine.role = 'owner'
+				ORDER BY other.organization_id, other.role = 'admin' DESC, other.created_at, other.user_id
+			 ) heir
+			 WHERE om.organization_id = heir.organization_id AND om.user_id = heir.user_id`,
+		},
+		{
+			// Projects shared again during the grace period are handed to one of their admins, or else to a member.
+			// The heir must still be in the organization, its owners and admins being the last resort
+			"hand over shared projects",
+			`WITH heir AS (
+				SELECT DISTINCT ON (candidate.project_id) candidate.project_id, candidate.user_id
+				FROM (
+					SELECT pm.project_id, pm.user_id, CASE WHEN pm.role = 'admin' THEN 0 ELSE 2 END AS rank
+					FROM project_members pm
+					UNION ALL
+					SELECT pt.project_id, tm.user_id, CASE WHEN pt.role = 'admin' THEN 1 ELSE 3 END
+					FROM project_teams pt
+					INNER JOIN team_members tm ON tm.team_id = pt.team_id
+					UNION ALL
+					SELECT p.id, om.user_id, 4
+					FROM projects p
+					INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.role IN ('owner', 'admin')
+				) candidate
+				INNER JOIN projects p ON p.id = candidate.project_id
+				INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = candidate.user_id
+				WHERE p.owner_id = $1 AND NOT p.is_template AND candidate.user_id <> $1
+				  AND (
+					EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id <> $1)
+					OR EXISTS (SELECT 1 FROM project_teams pt WHERE pt.project_id = p.id)
+				  )
+				ORDER BY candidate.project_id, candidate.rank, candidate.user_id
+			 ), handed AS (
+				UPDATE projects p
+				SET owner_id = heir.user_id, updated_at = NOW()
+				FROM heir
+				WHERE p.id = heir.project_id
+				RETURNING p.id, p.owner_id
+			 )
+			 DELETE FROM project_members pm
+			 USING handed
+			 WHERE pm.project_id = handed.id AND pm.user_id = handed.owner_id`,
+		},
+		{
+			// Projects nobody else uses are deleted, the shared ones stay for their team
+			"delete own projects",
+			`DELETE FROM projects p
+			 WHERE p.owner_id = $1
+			   AND NOT p.is_template
+			   AND NOT EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id <> $1)
+			   AND NOT EXISTS (SELECT 1 FROM project_teams pt WHERE pt.project_id = p.id)
+			   AND NOT EXISTS (SELECT 1 FROM tasks t WHERE t.project_id = p.id AND t.owner_id <> $1)`,
+		},
+		{"delete personal tasks", `DELETE FROM tasks WHERE owner_id = $1 AND project_id IS NULL`},
+		{"stop timer", `UPDATE time_entries SET ended_at = NOW() WHERE user_id = $1 AND ended_at IS NULL`},
+		{"delete private views", `DELETE FROM task_views WHERE owner_id = $1 AND project_id IS NULL`},
+		{"delete assignments", `DELETE FROM task_assignments WHERE user_id = $1`},
+		{"delete project memberships", `DELETE FROM project_members WHERE user_id = $1`},
+		{"delete team memberships", `DELETE FROM team_members WHERE user_id = $1`},
+		{"delete organization memberships", `DELETE FROM organization_members WHERE user_id = $1`},
+		{
+			"delete invitations",
+			`DELETE FROM project_invitations
+			 WHERE user_id = $1 OR email = (SELECT LOWER(email) FROM users WHERE id = $1)`,
+		},
+		{"delete digest", `DELETE FROM digest_subscriptions WHERE user_id = $1`},
+		{"delete digest deliveries", `DELETE FROM digest_deliveries WHERE user_id = $1`},
+		{"delete calendar feeds", `DELETE FROM calendar_feeds WHERE user_id = $1`},
+		{"delete exports", `DELETE FROM user_exports WHERE user_id = $1`},
+		{"delete avatars", `DELETE FROM user_avatars WHERE user_id = $1`},
+	}
+
+	// The variants are read before their rows are deleted, the avatar link names one of them or an older avatar
+	avatars := txStrings(tx, "avatars", `SELECT file_name FROM user_avatars WHERE user_id = $1`, userId)
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(statement.query, userId); err != nil {
+			panic(fmt.Errorf("account_repo_pg_error: %s: %v", statement.name, err))
+		}
+	}
+
+	// What's left of the user only tells it was someone
+	query := `
+		WITH old_data AS (
+			SELECT avatar_link
+			FROM users
+			WHERE id = $1
+		)
+		UPDATE users
+		SET username = 'deleted-' || replace(id::TEXT, '-', ''),
+			email = id::TEXT || '@deleted.invalid',
+			password = '',
+			avatar_link = '',
+			display_name = 'Deleted user',
+			bio = '',
+			email_invitations = FALSE,
+			email_digests = FALSE,
+			deletion_scheduled_at = NULL,
+			deleted_at = NOW()
+		FROM old_data
+		WHERE users.id = $1
+		RETURNING COALESCE(old_data.avatar_link, '')`
+
+	var avatarLink string
+	err = tx.QueryRow(query, userId).Scan(&avatarLink)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+
+		panic(fmt.Errorf("account_repo_pg_error: anonymize user: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: commit transaction: %v", err))
+	}
+
+	if avatarLink != "" && !slices.Contains(avatars, avatarLink) {
+		avatars = append(avatars, avatarLink)
+	}
+
+	return avatars
+}
+
+func (r *AccountRepositoryPG) queryExports(query string, args ...interface{}) []entity.AccountExport {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: query exports: %v", err))
+	}
+	defer rows.Close()
+
+	var exports []entity.AccountExport
+	for rows.Next() {
+		var export entity.AccountExport
+		err := rows.Scan(
+			&export.Id,
+			&export.UserId,
+			&export.Status,
+			&export.Error,
+			&export.FileName,
+			&export.CompletedAt,
+			&export.ExpiresAt,
+			&export.Expired,
+			&export.CreatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("account_repo_pg_error: scan export: %v", err))
+		}
+		exports = append(exports, export)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: rows error: %v", err))
+	}
+
+	return exports
+}
+
+func (r *AccountRepositoryPG) queryStrings(query string, args ...interface{}) []string {
+	var values []string
+	r.scanRows("values", query, func(rows *sql.Rows) error {
+		var value string
+		err := rows.Scan(&value)
+		values = append(values, value)
+
+		return err
+	}, args...)
+
+	return values
+}
+
+// txStrings returns the single column of the rows of the query, run inside the transaction.
+func txStrings(tx *sql.Tx, name string, query string, args ...interface{}) []string {
+	rows, err := tx.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: query %s: %v", name, err))
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			panic(fmt.Errorf("account_repo_pg_error: scan %s: %v", name, err))
+		}
+		values = append(values, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: rows error: %v", err))
+	}
+
+	return values
+}
+
+// scanRows calls scan for every row of the query.
+func (r *AccountRepositoryPG) scanRows(name string, query string, scan func(rows *sql.Rows) error, args ...interface{}) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: query %s: %v", name, err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			panic(fmt.Errorf("account_repo_pg_error: scan %s: %v", name, err))
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("account_repo_pg_error: rows error: %v", err))
+	}
+}
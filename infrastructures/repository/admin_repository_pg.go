@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// Columns scanned by queryAdminUsers, the total is counted over every row matching the filter
+var adminUserColumns = `
+	u.id, u.username, u.email, u.display_name, u.is_admin,
+	CASE
+		WHEN u.deleted_at IS NOT NULL THEN '` + entity.AccountStatusDeleted + `'
+		WHEN u.disabled_at IS NOT NULL THEN '` + entity.AccountStatusDisabled + `'
+		ELSE '` + entity.AccountStatusActive + `'
+	END AS status,
+	` + nullableUTCTimestamp("u.disabled_at") + `,
+	u.password_reset_token_hash IS NOT NULL,
+	` + nullableUTCTimestamp("u.deletion_scheduled_at") + `,
+	(SELECT COUNT(*) FROM projects p WHERE p.owner_id = u.id AND p.deleted_at IS NULL),
+	(SELECT COUNT(*) FROM tasks t WHERE t.owner_id = u.id AND t.deleted_at IS NULL),
+	COUNT(*) OVER ()`
+
+type AdminRepositoryPG struct /* implements AdminRepository */ {
+	db *sql.DB
+}
+
+func NewAdminRepositoryPG(db *sql.DB) repository.AdminRepository {
+	return &AdminRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *AdminRepositoryPG) IsAdmin(userId string) bool {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM users
+			WHERE id = $1 AND is_admin AND disabled_at IS NULL AND deleted_at IS NULL
+		)`
+
+	var isAdmin bool
+	if err := r.db.QueryRow(query, userId).Scan(&isAdmin); err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: is admin: %v", err))
+	}
+
+	return isAdmin
+}
+
+func (r *AdminRepositoryPG) GetUsers(filter *entity.AdminUserFilter) *entity.AdminUserList {
+	query := `
+		SELECT` + adminUserColumns + `
+		FROM users u
+		WHERE ($1 = ''
+			   OR u.username ILIKE '%' || $1 || '%'
+			   OR u.email ILIKE '%' || $1 || '%'
+			   OR u.display_name ILIKE '%' || $1 || '%')
+		  AND ($2 = ''
+			   OR ($2 = '` + entity.AccountStatusDeleted + `' AND u.deleted_at IS NOT NULL)
+			   OR ($2 = '` + entity.AccountStatusDisabled + `' AND u.deleted_at IS NULL AND u.disabled_at IS NOT NULL)
+			   OR ($2 = '` + entity.AccountStatusActive + `' AND u.deleted_at IS NULL AND u.disabled_at IS NULL))
+		ORDER BY u.username
+		LIMIT $3 OFFSET $4`
+
+	users, total := r.queryAdminUsers(query, filter.Query, filter.Status, filter.Limit, filter.Offset)
+
+	return &entity.AdminUserList{
+		Users: users,
+		Total: total,
+	}
+}
+
+func (r *AdminRepositoryPG) GetUser(id string) *entity.AdminUser {
+	query := `SELECT` + adminUserColumns + ` FROM users u WHERE u.id = $1`
+
+	users, _ := r.queryAdminUsers(query, id)
+	if len(users) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+	}
+
+	return &users[0]
+}
+
+func (r *AdminRepositoryPG) SetUserDisabled(id string, disabled bool) {
+	query := `
+		UPDATE users
+		SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, NOW()) END
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(query, id, disabled)
+	if err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: set user disabled: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+	}
+}
+
+func (r *AdminRepositoryPG) GetStats() *entity.SystemStats {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND disabled_at IS NOT NULL),
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NOT NULL),
+			(SELECT COUNT(*) FROM users WHERE deleted_at IS NULL AND is_admin),
+			(SELECT COUNT(*) FROM organizations),
+			(SELECT COUNT(*) FROM projects WHERE deleted_at IS NULL AND NOT is_template),
+			(SELECT COUNT(*) FROM tasks WHERE deleted_at IS NULL),
+			(SELECT COUNT(*) FROM tasks WHERE deleted_at IS NULL AND status IN ($1, $2)),
+			(SELECT COUNT(*) FROM time_entries),
+			(SELECT COUNT(*) FROM webhooks),
+			(SELECT COUNT(*) FROM webhook_deliveries WHERE status = 'pending'),
+			(SELECT COUNT(*) FROM user_exports WHERE status = $3)`
+
+	var stats entity.SystemStats
+	err := r.db.QueryRow(
+		query,
+		entity.TaskStatusToDo,
+		entity.TaskStatusInProgress,
+		entity.AccountExportPending,
+	).Scan(
+		&stats.Users,
+		&stats.DisabledUsers,
+		&stats.DeletedUsers,
+		&stats.Admins,
+		&stats.Organizations,
+		&stats.Projects,
+		&stats.Tasks,
+		&stats.OpenTasks,
+		&stats.TimeEntries,
+		&stats.Webhooks,
+		&stats.PendingWebhookDeliveries,
+		&stats.PendingExports,
+	)
+	if err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: get stats: %v", err))
+	}
+
+	return &stats
+}
+
+func (r *AdminRepositoryPG) GetReferencedFileNames() []string {
+	query := `
+		SELECT avatar_link FROM users WHERE avatar_link <> ''
+		UNION
+		SELECT file_name FROM user_avatars
+		UNION
+		SELECT file_name FROM user_exports WHERE file_name <> ''`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: get referenced file names: %v", err))
+	}
+	defer rows.Close()
+
+	var fileNames []string
+	for rows.Next() {
+		var fileName string
+		if err := rows.Scan(&fileName); err != nil {
+			panic(fmt.Errorf("admin_repo_pg_error: scan file name: %v", err))
+		}
+		fileNames = append(fileNames, fileName)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: rows error: %v", err))
+	}
+
+	return fileNames
+}
+
+func (r *AdminRepositoryPG) AddAuditLog(
+	actorId string,
+	action string,
+	targetType string,
+	targetId string,
+	detail map[string]interface{},
+) {
+	if detail == nil {
+		detail = map[string]interface{}{}
+	}
+
+	detailJSON, err := json.Marshal(detail)
+	if err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: marshal audit log detail: %v", err))
+	}
+
+	query := `
+		INSERT INTO audit_logs(actor_id, action, target_type, target_id, detail)
+		VALUES ($1, $2, $3, $4, $5)`
+
+	if _, err := r.db.Exec(query, actorId, action, targetType, targetId, detailJSON); err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: add audit log: %v", err))
+	}
+}
+
+func (r *AdminRepositoryPG) GetAuditLogs(filter *entity.AuditLogFilter) []entity.AuditLog {
+	query := `
+		SELECT
+			l.id, COALESCE(l.actor_id::text, ''), COALESCE(u.username, ''),
+			l.action, l.target_type, l.target_id, l.detail, ` + utcTimestamp("l.created_at") + `
+		FROM audit_logs l
+		LEFT JOIN users u ON u.id = l.actor_id
+		WHERE ($1 = '' OR l.actor_id::text = $1)
+		  AND ($2 = '' OR l.action = $2)
+		  AND ($3 = '' OR l.target_type = $3)
+		  AND ($4 = '' OR l.target_id = $4)
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT $5 OFFSET $6`
+
+	rows, err := r.db.Query(
+		query,
+		filter.ActorId,
+		filter.Action,
+		filter.TargetType,
+		filter.TargetId,
+		filter.Limit,
+		filter.Offset,
+	)
+	if err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: get audit logs: %v", err))
+	}
+	defer rows.Close()
+
+	logs := make([]entity.AuditLog, 0)
+	for rows.Next() {
+		var auditLog entity.AuditLog
+		var detailJSON []byte
+
+		err := rows.Scan(
+			&auditLog.Id,
+			&auditLog.ActorId,
+			&auditLog.Actor,
+			&auditLog.Action,
+			&auditLog.TargetType,
+			&auditLog.TargetId,
+			&detailJSON,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("admin_repo_pg_error: scan audit log: %v", err))
+		}
+		if err := json.Unmarshal(detailJSON, &auditLog.Detail); err != nil {
+			panic(fmt.Errorf("admin_repo_pg_error: unmarshal audit log detail: %v", err))
+		}
+
+		logs = append(logs, auditLog)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: rows error: %v", err))
+	}
+
+	return logs
+}
+
+// queryAdminUsers scans the adminUserColumns of the users of the query.
+// Returning them with the total counted by the window.
+func (r *AdminRepositoryPG) queryAdminUsers(query string, args ...interface{}) ([]entity.AdminUser, int) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: get users: %v", err))
+	}
+	defer rows.Close()
+
+	users := make([]entity.AdminUser, 0)
+	total := 0
+	for rows.Next() {
+		var user entity.AdminUser
+
+		err := rows.Scan(
+			&user.Id,
+			&user.Username,
+			&user.Email,
+			&user.DisplayName,
+			&user.IsAdmin,
+			&user.Status,
+			&user.DisabledAt,
+			&user.PasswordResetRequired,
+			&user.DeletionScheduledAt,
+			&user.Projects,
+			&user.Tasks,
+			&total,
+		)
+		if err != nil {
+			panic(fmt.Errorf("admin_repo_pg_error: scan user: %v", err))
+		}
+
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("admin_repo_pg_error: rows error: %v", err))
+	}
+
+	return users, total
+}
@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+// Columns scanned by queryCalendarFeeds, times are RFC 3339 in UTC
+const calendarFeedColumns = `
+	f.id, f.user_id, COALESCE(f.project_id::TEXT, ''), COALESCE(p.title, ''),
+	COALESCE(to_char(f.last_used_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), ''),
+	to_char(f.created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`
+
+// Columns scanned by queryCalendarTasks, tasks' times are stored in the time zone of the connection
+const calendarTaskColumns = `
+	t.id, t.title, COALESCE(t.description, ''), t.priority, t.status, COALESCE(p.title, ''),
+	to_char(t.due_date, 'YYYY-MM-DD'),
+	to_char(t.updated_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+	t.version`
+
+type CalendarFeedRepositoryPG struct /* implements CalendarFeedRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewCalendarFeedRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.CalendarFeedRepository {
+	return &CalendarFeedRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *CalendarFeedRepositoryPG) AddCalendarFeed(userId string, projectId string, tokenHash string) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	query := `DELETE FROM calendar_feeds WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM NULLIF($2, '')::UUID`
+	if _, err = tx.Exec(query, userId, projectId); err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: revoke previous feed: %v", err))
+	}
+
+	query = `
+		INSERT INTO calendar_feeds(id, user_id, project_id, token_hash)
+		VALUES ($1, $2, NULLIF($3, '')::UUID, $4)
+		RETURNING id`
+
+	var returnedId string
+	if err = tx.QueryRow(query, id, userId, projectId, tokenHash).Scan(&returnedId); err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: add calendar feed: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *CalendarFeedRepositoryPG) GetCalendarFeedsByUser(userId string) []entity.CalendarFeed {
+	query := `
+		SELECT` + calendarFeedColumns + `
+		FROM calendar_feeds f
+		LEFT JOIN projects p ON p.id = f.project_id
+		WHERE f.user_id = $1
+		ORDER BY f.created_at`
+
+	return r.queryCalendarFeeds(query, userId)
+}
+
+func (r *CalendarFeedRepositoryPG) GetCalendarFeedByToken(tokenHash string) *entity.CalendarFeed {
+	// Feeds of disabled users stop working until they're enabled
+	query := `
+		WITH f AS (
+			UPDATE calendar_feeds cf
+			SET last_used_at = NOW()
+			FROM users u
+			WHERE cf.token_hash = $1 AND u.id = cf.user_id AND u.disabled_at IS NULL AND u.deleted_at IS NULL
+			RETURNING cf.*
+		)
+		SELECT` + calendarFeedColumns + `
+		FROM f
+		LEFT JOIN projects p ON p.id = f.project_id`
+
+	feeds := r.queryCalendarFeeds(query, tokenHash)
+	if len(feeds) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Calendar feed not found!"))
+	}
+
+	return &feeds[0]
+}
+
+func (r *CalendarFeedRepositoryPG) DeleteCalendarFeed(id string, userId string) {
+	query := `DELETE FROM calendar_feeds WHERE id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: delete calendar feed: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Calendar feed not found!"))
+	}
+}
+
+func (r *CalendarFeedRepositoryPG) GetUserCalendarTasks(userId string) []entity.CalendarTask {
+	query := `
+		SELECT` + calendarTaskColumns + `
+		FROM tasks t
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE t.deleted_at IS NULL AND t.due_date IS NOT NULL
+		  AND (t.owner_id = $1 OR EXISTS (SELECT 1 FROM task_assignments a WHERE a.task_id = t.id AND a.user_id = $1))
+		  AND (t.project_id IS NULL OR t.project_id IN (SELECT project_id FROM accessible_project_ids($1)))
+		ORDER BY t.due_date, t.id`
+
+	return r.queryCalendarTasks(query, userId)
+}
+
+func (r *CalendarFeedRepositoryPG) GetProjectCalendarTasks(projectId string) []entity.CalendarTask {
+	query := `
+		SELECT` + calendarTaskColumns + `
+		FROM tasks t
+		INNER JOIN projects p ON p.id = t.project_id
+		WHERE t.project_id = $1 AND t.deleted_at IS NULL AND t.due_date IS NOT NULL
+		ORDER BY t.due_date, t.id`
+
+	return r.queryCalendarTasks(query, projectId)
+}
+
+func (r *CalendarFeedRepositoryPG) queryCalendarFeeds(query string, args ...interface{}) []entity.CalendarFeed {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: query calendar feeds: %v", err))
+	}
+	defer rows.Close()
+
+	var feeds []entity.CalendarFeed
+	for rows.Next() {
+		var feed entity.CalendarFeed
+		err := rows.Scan(&feed.Id, &feed.UserId, &feed.ProjectId, &feed.Project, &feed.LastUsedAt, &feed.CreatedAt)
+		if err != nil {
+			panic(fmt.Errorf("calendar_feed_repo_pg_error: scan calendar feed: %v", err))
+		}
+		feeds = append(feeds, feed)
+	}
+
+	return feeds
+}
+
+func (r *CalendarFeedRepositoryPG) queryCalendarTasks(query string, args ...interface{}) []entity.CalendarTask {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("calendar_feed_repo_pg_error: query calendar tasks: %v", err))
+	}
+	defer rows.Close()
+
+	var tasks []entity.CalendarTask
+	for rows.Next() {
+		var task entity.CalendarTask
+		err := rows.Scan(
+			&task.Id,
+			&task.Title,
+			&task.Description,
+			&task.Priority,
+			&task.Status,
+			&task.Project,
+			&task.DueDate,
+			&task.UpdatedAt,
+			&task.Version,
+		)
+		if err != nil {
+			panic(fmt.Errorf("calendar_feed_repo_pg_error: scan calendar task: %v", err))
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+type DigestRepositoryPG struct /* implements DigestRepository */ {
+	db *sql.DB
+}
+
+func NewDigestRepositoryPG(db *sql.DB) repository.DigestRepository {
+	return &DigestRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *DigestRepositoryPG) UpsertSubscription(userId string, payload *entity.DigestSubscriptionPayload) {
+	query := `
+		INSERT INTO digest_subscriptions(user_id, frequency, time_zone, send_hour)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET frequency = EXCLUDED.frequency,
+			time_zone = EXCLUDED.time_zone,
+			send_hour = EXCLUDED.send_hour,
+			updated_at = NOW()`
+
+	_, err := r.db.Exec(query, userId, payload.Frequency, payload.TimeZone, payload.SendHour)
+	if err != nil {
+		panic(fmt.Errorf("digest_repo_pg_error: upsert subscription: %v", err))
+	}
+}
+
+func (r *DigestRepositoryPG) GetSubscriptionByUser(userId string) *entity.DigestSubscription {
+	var subscription entity.DigestSubscription
+
+	query := `
+		SELECT u.id, u.username, u.email, ds.frequency, ds.time_zone, ds.send_hour
+		FROM digest_subscriptions ds
+		INNER JOIN users u ON u.id = ds.user_id
+		WHERE ds.user_id = $1`
+	err := r.db.QueryRow(query, userId).Scan(
+		&subscription.UserId,
+		&subscription.Username,
+		&subscription.Email,
+		&subscription.Frequency,
+		&subscription.TimeZone,
+		&subscription.SendHour,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "You haven't subscribed to the digest!"))
+		}
+		panic(fmt.Errorf("digest_repo_pg_error: get subscription by user: %v", err))
+	}
+
+	return &subscription
+}
+
+func (r *DigestRepositoryPG) DeleteSubscription(userId string) {
+	query := `DELETE FROM digest_subscriptions WHERE user_id = $1`
+
+	_, err := r.db.Exec(query, userId)
+	if err != nil {
+		panic(fmt.Errorf("digest_repo_pg_error: delete subscription: %v", err))
+	}
+}
+
+func (r *DigestRepositoryPG) GetSubscriptions() []entity.DigestSubscription {
+	query := `
+		SELECT u.id, u.username, u.email, ds.frequency, ds.time_zone, ds.send_hour
+		FROM digest_subscriptions ds
+		INNER JOIN users u ON u.id = ds.user_id
+		WHERE u.email_digests`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		panic(fmt.Errorf("digest_repo_pg_error: get subscriptions: %v", err))
+	}
+	defer rows.Close()
+
+	var subscriptions []entity.DigestSubscription
+	for rows.Next() {
+		var subscription entity.DigestSubscription
+		err := rows.Scan(
+			&subscription.UserId,
+			&subscription.Username,
+			&subscription.Email,
+			&subscription.Frequency,
+			&subscription.TimeZone,
+			&subscription.SendHour,
+		)
+		if err != nil {
+			panic(fmt.Errorf("digest_repo_pg_error: scan subscription: %v", err))
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	return subscriptions
+}
+
+func (r *DigestRepositoryPG) GetOpenAssignedTasks(userId string) []entity.DigestTask {
+	query := `
+		SELECT
+			t.id, t.title, t.priority, t.status,
+			COALESCE(p.title, ''),
+			COALESCE(to_char(t.due_date, 'YYYY-MM-DD'), '')
+		FROM tasks t
+		INNER JOIN task_assignments ta ON ta.task_id = t.id
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE ta.user_id = $1
+		  AND t.status NOT IN ('Completed', 'Canceled')
+		  AND t.deleted_at IS NULL
+		  AND (t.project_id IS NULL OR t.project_id IN (SELECT project_id FROM accessible_project_ids($1)))
+		ORDER BY t.due_date NULLS LAST, t.title`
+
+	rows, err := r.db.Query(query, userId)
+	if err != nil {
+		panic(fmt.Errorf("digest_repo_pg_error: get open assigned tasks: %v", err))
+	}
+	defer rows.Close()
+
+	var tasks []entity.DigestTask
+	for rows.Next() {
+		var task entity.DigestTask
+		err := rows.Scan(&task.Id, &task.Title, &task.Priority, &task.Status, &task.Project, &task.DueDate)
+		if err != nil {
+			panic(fmt.Errorf("digest_repo_pg_error: scan task: %v", err))
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func (r *DigestRepositoryPG) ClaimDigestPeriod(userId string, periodKey string) bool {
+	query := `
+		INSERT INTO digest_deliveries(user_id, period_key)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+	result, err := r.db.Exec(query, userId, periodKey)
+	if err != nil {
+		panic(fmt.Errorf("digest_repo_pg_error: claim digest period: %v", err))
+	}
+
+	affected, _ := result.RowsAffected()
+
+	return affected == 1
+}
+
+func (r *DigestRepositoryPG) ReleaseDigestPeriod(userId string, periodKey string) {
+	query := `DELETE FROM digest_deliveries WHERE user_id = $1 AND period_key = $2`
+
+	_, err := r.db.Exec(query, userId, periodKey)
+	if err != nil {
+		panic(fmt.Errorf("digest_repo_pg_error: release digest period: %v", err))
+	}
+}
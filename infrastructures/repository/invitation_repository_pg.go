@@ -0,0 +1,215 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+	"time"
+)
+
+// Columns scanned by queryInvitations
+var invitationColumns = `
+	i.id, i.project_id, p.title, i.email, COALESCE(i.user_id::TEXT, ''), COALESCE(u.username, ''),
+	i.role, i.status, COALESCE(inviter.username, ''), i.expires_at <= NOW(),
+	` + utcTimestamp("i.expires_at") + `, ` + utcTimestamp("i.created_at")
+
+type InvitationRepositoryPG struct /* implements InvitationRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewInvitationRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.InvitationRepository {
+	return &InvitationRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *InvitationRepositoryPG) AddInvitation(
+	projectId string,
+	email string,
+	userId string,
+	role string,
+	invitedBy string,
+	ttl time.Duration,
+) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	// Expired invitations mustn't block inviting the same person again
+	query := `
+		UPDATE project_invitations
+		SET status = 'expired'
+		WHERE project_id = $1 AND email = $2 AND status = 'pending' AND expires_at <= NOW()`
+	_, err := r.db.Exec(query, projectId, email)
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: expire invitations: %v", err))
+	}
+
+	query = `INSERT INTO
+				project_invitations(id, project_id, email, user_id, role, invited_by, expires_at)
+			 VALUES
+				($1, $2, $3, NULLIF($4, '')::UUID, $5, $6, NOW() + make_interval(secs => $7))
+			 RETURNING id`
+
+	var returnedId string
+	err = r.db.QueryRow(query, id, projectId, email, userId, role, invitedBy, ttl.Seconds()).Scan(&returnedId)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "There is already a pending invitation for this person!"))
+		}
+		panic(fmt.Errorf("invitation_repo_pg_error: add invitation: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *InvitationRepositoryPG) GetInvitationById(id string) *entity.Invitation {
+	query := `
+		SELECT` + invitationColumns + `
+		FROM project_invitations i
+		INNER JOIN projects p ON p.id = i.project_id
+		LEFT JOIN users u ON u.id = i.user_id
+		LEFT JOIN users inviter ON inviter.id = i.invited_by
+		WHERE i.id = $1 AND p.deleted_at IS NULL`
+
+	invitations := r.queryInvitations(query, id)
+	if len(invitations) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Invitation not found!"))
+	}
+
+	return &invitations[0]
+}
+
+func (r *InvitationRepositoryPG) GetPendingInvitationsByProject(projectId string) []entity.Invitation {
+	query := `
+		SELECT` + invitationColumns + `
+		FROM project_invitations i
+		INNER JOIN projects p ON p.id = i.project_id
+		LEFT JOIN users u ON u.id = i.user_id
+		LEFT JOIN users inviter ON inviter.id = i.invited_by
+		WHERE i.project_id = $1 AND i.status = 'pending' AND i.expires_at > NOW()
+		ORDER BY i.created_at DESC`
+
+	return r.queryInvitations(query, projectId)
+}
+
+func (r *InvitationRepositoryPG) GetPendingInvitationsByInvitee(userId string) []entity.Invitation {
+	query := `
+		SELECT` + invitationColumns + `
+		FROM project_invitations i
+		INNER JOIN projects p ON p.id = i.project_id
+		LEFT JOIN users u ON u.id = i.user_id
+		LEFT JOIN users inviter ON inviter.id = i.invited_by
+		WHERE i.user_id = $1
+		  AND i.status = 'pending'
+		  AND i.expires_at > NOW()
+		  AND p.deleted_at IS NULL
+		ORDER BY i.created_at DESC`
+
+	return r.queryInvitations(query, userId)
+}
+
+func (r *InvitationRepositoryPG) AcceptInvitation(id string, userId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	var projectId, role string
+	query := `
+		UPDATE project_invitations
+		SET status = 'accepted', user_id = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending' AND expires_at > NOW()
+		RETURNING project_id, role`
+	err = tx.QueryRow(query, id, userId).Scan(&projectId, &role)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusConflict, "Invitation is no longer pending!"))
+		}
+		panic(fmt.Errorf("invitation_repo_pg_error: accept invitation: %v", err))
+	}
+
+	// Joining a project means joining its organization
+	query = `
+		INSERT INTO organization_members(organization_id, user_id, role)
+		SELECT organization_id, $2, 'member' FROM projects WHERE id = $1
+		ON CONFLICT (organization_id, user_id) DO NOTHING`
+	_, err = tx.Exec(query, projectId, userId)
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: add organization member: %v", err))
+	}
+
+	query = `
+		INSERT INTO project_members(project_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (project_id, user_id) DO NOTHING`
+	_, err = tx.Exec(query, projectId, userId, role)
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: add project member: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *InvitationRepositoryPG) UpdateInvitationStatus(id string, status string) {
+	query := `
+		UPDATE project_invitations
+		SET status = $2, responded_at = NOW()
+		WHERE id = $1 AND status = 'pending'`
+
+	result, err := r.db.Exec(query, id, status)
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: update invitation status: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusConflict, "Invitation is no longer pending!"))
+	}
+}
+
+func (r *InvitationRepositoryPG) queryInvitations(query string, args ...interface{}) []entity.Invitation {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("invitation_repo_pg_error: query invitations: %v", err))
+	}
+	defer rows.Close()
+
+	var invitations []entity.Invitation
+	for rows.Next() {
+		var invitation entity.Invitation
+		err := rows.Scan(
+			&invitation.Id,
+			&invitation.ProjectId,
+			&invitation.Project,
+			&invitation.Email,
+			&invitation.UserId,
+			&invitation.Username,
+			&invitation.Role,
+			&invitation.Status,
+			&invitation.InvitedBy,
+			&invitation.Expired,
+			&invitation.ExpiresAt,
+			&invitation.CreatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("invitation_repo_pg_error: scan invitation: %v", err))
+		}
+		invitations = append(invitations, invitation)
+	}
+
+	return invitations
+}
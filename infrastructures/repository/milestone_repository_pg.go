@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+)
+
+// Format of the times of milestones, microseconds are kept since the history is replayed from them
+const milestoneTimeFormat = `'YYYY-MM-DD"T"HH24:MI:SS.US"Z"'`
+
+// Columns scanned by queryMilestones
+var milestoneColumns = `
+	m.id, m.project_id, m.name, m.goal, to_char(m.start_date, 'YYYY-MM-DD'), to_char(m.end_date, 'YYYY-MM-DD'), m.state,
+	COALESCE(to_char(m.activated_at AT TIME ZONE 'UTC', ` + milestoneTimeFormat + `), ''),
+	COALESCE(to_char(m.closed_at AT TIME ZONE 'UTC', ` + milestoneTimeFormat + `), ''),
+	(SELECT COUNT(*) FROM tasks t WHERE t.milestone_id = m.id AND t.deleted_at IS NULL),
+	(SELECT COUNT(*) FROM tasks t WHERE t.milestone_id = m.id AND t.deleted_at IS NULL AND t.status = 'Completed'),
+	` + utcTimestamp("m.created_at")
+
+type MilestoneRepositoryPG struct /* implements MilestoneRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewMilestoneRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.MilestoneRepository {
+	return &MilestoneRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *MilestoneRepositoryPG) AddMilestone(projectId string, payload *entity.MilestonePayload) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	query := `
+		INSERT INTO milestones(id, project_id, name, goal, start_date, end_date)
+		VALUES ($1, $2, $3, $4, $5::DATE, $6::DATE)
+		RETURNING id`
+
+	var returnedId string
+	err := r.db.QueryRow(query, id, projectId, payload.Name, payload.Goal, payload.StartDate, payload.EndDate).Scan(&returnedId)
+	if err != nil {
+		if strings.Contains(err.Error(), "check constraint") {
+			panic(fiber.NewError(fiber.StatusBadRequest, "Milestone must not end before it starts!"))
+		}
+		panic(fmt.Errorf("milestone_repo_pg_error: add milestone: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *MilestoneRepositoryPG) GetMilestoneById(id string) *entity.Milestone {
+	query := `
+		SELECT` + milestoneColumns + `
+		FROM milestones m
+		WHERE m.id = $1`
+
+	milestones := r.queryMilestones(query, id)
+	if len(milestones) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Milestone not found!"))
+	}
+
+	return &milestones[0]
+}
+
+func (r *MilestoneRepositoryPG) GetMilestonesByProject(projectId string) []entity.Milestone {
+	query := `
+		SELECT` + milestoneColumns + `
+		FROM milestones m
+		WHERE m.project_id = $1
+		ORDER BY m.start_date, m.created_at`
+
+	return r.queryMilestones(query, projectId)
+}
+
+func (r *MilestoneRepositoryPG) UpdateMilestoneById(id string, payload *entity.MilestonePayload) {
+	query := `
+		UPDATE milestones
+		SET name = $2, goal = $3, start_date = $4::DATE, end_date = $5::DATE, updated_at = NOW()
+		WHERE id = $1`
+
+	result, err := r.db.Exec(query, id, payload.Name, payload.Goal, payload.StartDate, payload.EndDate)
+	if err != nil {
+		if strings.Contains(err.Error(), "check constraint") {
+			panic(fiber.NewError(fiber.StatusBadRequest, "Milestone must not end before it starts!"))
+		}
+		panic(fmt.Errorf("milestone_repo_pg_error: update milestone: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Milestone not found!"))
+	}
+}
+
+func (r *MilestoneRepositoryPG) DeleteMilestoneById(id string) {
+	query := `DELETE FROM milestones WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: delete milestone: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Milestone not found!"))
+	}
+}
+
+func (r *MilestoneRepositoryPG) StartMilestone(id string) {
+	query := `
+		UPDATE milestones
+		SET state = 'active', activated_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND state = 'planned'`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "Project already has an active milestone, close it first!"))
+		}
+		panic(fmt.Errorf("milestone_repo_pg_error: start milestone: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusConflict, "Only planned milestones can be started!"))
+	}
+}
+
+func (r *MilestoneRepositoryPG) CloseMilestone(id string, nextMilestoneId string) int {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	query := `
+		UPDATE milestones
+		SET state = 'closed', closed_at = NOW(), updated_at = NOW()
+		WHERE id = $1 AND state = 'active'`
+	result, err := tx.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: close milestone: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusConflict, "Only active milestones can be closed!"))
+	}
+
+	// Deleted tasks are carried as well, so they're where they belong once restored
+	query = `
+		UPDATE tasks
+		SET milestone_id = NULLIF($2, '')::UUID, updated_at = NOW()
+		WHERE milestone_id = $1 AND status NOT IN ('Completed', 'Canceled')`
+	result, err = tx.Exec(query, id, nextMilestoneId)
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: carry tasks: %v", err))
+	}
+	carried, _ := result.RowsAffected()
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return int(carried)
+}
+
+func (r *MilestoneRepositoryPG) GetNextMilestone(id string) string {
+	query := `
+		SELECT next.id
+		FROM milestones m
+		INNER JOIN milestones next ON next.project_id = m.project_id AND next.id <> m.id
+		WHERE m.id = $1 AND next.state = 'planned'
+		ORDER BY next.start_date, next.created_at
+		LIMIT 1`
+
+	var nextId string
+	err := r.db.QueryRow(query, id).Scan(&nextId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		panic(fmt.Errorf("milestone_repo_pg_error: get next milestone: %v", err))
+	}
+
+	return nextId
+}
+
+func (r *MilestoneRepositoryPG) GetMilestoneTasksAt(id string, at string) []entity.MilestoneTask {
+	// The state of a task at a time is its latest activity before it
+	query := `
+		SELECT state.task_id, t.title, state.status, COALESCE(t.estimate, 0)
+		FROM (
+			SELECT DISTINCT ON (a.task_id) a.task_id, a.milestone_id, a.status, a.deleted
+			FROM task_activities a
+			WHERE a.task_id IN (SELECT task_id FROM task_activities WHERE milestone_id = $1)
+			  AND a.changed_at < $2::TIMESTAMPTZ
+			ORDER BY a.task_id, a.changed_at DESC, a.id DESC
+		) state
+		INNER JOIN tasks t ON t.id = state.task_id
+		WHERE state.milestone_id = $1 AND NOT state.deleted
+		ORDER BY t.title`
+
+	rows, err := r.db.Query(query, id, at)
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: get milestone tasks at: %v", err))
+	}
+	defer rows.Close()
+
+	var tasks []entity.MilestoneTask
+	for rows.Next() {
+		var task entity.MilestoneTask
+		err := rows.Scan(&task.TaskId, &task.Title, &task.Status, &task.Estimate)
+		if err != nil {
+			panic(fmt.Errorf("milestone_repo_pg_error: scan milestone task: %v", err))
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func (r *MilestoneRepositoryPG) GetScopeChanges(id string, from string, to string) []entity.ScopeChange {
+	// A task enters the scope when it joins the milestone or is restored, and leaves it the other way around
+	query := `
+		WITH history AS (
+			SELECT
+				a.task_id,
+				a.changed_at,
+				(a.milestone_id IS NOT DISTINCT FROM $1::UUID AND NOT a.deleted) AS inside,
+				LAG(a.milestone_id IS NOT DISTINCT FROM $1::UUID AND NOT a.deleted, 1, FALSE)
+					OVER (PARTITION BY a.task_id ORDER BY a.changed_at, a.id) AS was_inside
+			FROM task_activities a
+			WHERE a.task_id IN (SELECT task_id FROM task_activities WHERE milestone_id = $1)
+		)
+		SELECT
+			h.task_id,
+			t.title,
+			CASE WHEN h.inside THEN 'added' ELSE 'removed' END,
+			to_char(h.changed_at AT TIME ZONE 'UTC', ` + milestoneTimeFormat + `)
+		FROM history h
+		INNER JOIN tasks t ON t.id = h.task_id
+		WHERE h.inside <> h.was_inside
+		  AND h.changed_at >= $2::TIMESTAMPTZ
+		  AND h.changed_at < $3::TIMESTAMPTZ
+		ORDER BY h.changed_at`
+
+	rows, err := r.db.Query(query, id, from, to)
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: get scope changes: %v", err))
+	}
+	defer rows.Close()
+
+	var changes []entity.ScopeChange
+	for rows.Next() {
+		var change entity.ScopeChange
+		err := rows.Scan(&change.TaskId, &change.Title, &change.Change, &change.ChangedAt)
+		if err != nil {
+			panic(fmt.Errorf("milestone_repo_pg_error: scan scope change: %v", err))
+		}
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+func (r *MilestoneRepositoryPG) queryMilestones(query string, args ...interface{}) []entity.Milestone {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("milestone_repo_pg_error: query milestones: %v", err))
+	}
+	defer rows.Close()
+
+	var milestones []entity.Milestone
+	for rows.Next() {
+		var milestone entity.Milestone
+		err := rows.Scan(
+			&milestone.Id,
+			&milestone.ProjectId,
+			&milestone.Name,
+			&milestone.Goal,
+			&milestone.StartDate,
+			&milestone.EndDate,
+			&milestone.State,
+			&milestone.ActivatedAt,
+			&milestone.ClosedAt,
+			&milestone.Tasks,
+			&milestone.Completed,
+			&milestone.CreatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("milestone_repo_pg_error: scan milestone: %v", err))
+		}
+		milestones = append(milestones, milestone)
+	}
+
+	return milestones
+}
@@ -0,0 +1,97 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/infrastructures/generator"
+	"github.com/wisle25/task-pixie/infrastructures/repository"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+	"github.com/wisle25/task-pixie/tests/db_helper"
+)
+
+func TestMilestoneRepository(t *testing.T) {
+	// Arrange
+	config := commons.LoadConfig("../..")
+	db := services.ConnectDB(config)
+	projectHelperDb := &db_helper.ProjectHelperDB{
+		DB: db,
+	}
+	defer projectHelperDb.CleanProjectDB()
+
+	milestoneRepositoryPG := repository.NewMilestoneRepositoryPG(db, generator.NewUUIDGenerator())
+
+	organizationId := projectHelperDb.AddOrganizationDB("Acme")
+	ownerId := projectHelperDb.AddUserDB("owner")
+	projectHelperDb.AddOrganizationMemberDB(organizationId, ownerId, "owner")
+	projectId := projectHelperDb.AddProjectDB(organizationId, ownerId, "Website")
+
+	sprintId := projectHelperDb.AddMilestoneDB(projectId, "Sprint 1", "active")
+	nextSprintId := projectHelperDb.AddMilestoneDB(projectId, "Sprint 2", "planned")
+
+	tasks := make(map[string]string)
+	for title, status := range map[string]string{
+		"Design":  "To Do",
+		"Build":   "In Progress",
+		"Test":    "Completed",
+		"Dropped": "Canceled",
+		"Trashed": "To Do",
+	} {
+		tasks[title] = projectHelperDb.AddTaskDB(projectId, ownerId, title, status, "")
+		projectHelperDb.SetTaskDB(tasks[title], "milestone_id", sprintId)
+	}
+	projectHelperDb.SetTaskDB(tasks["Trashed"], "deleted_at", "2024-03-05")
+	backlogId := projectHelperDb.AddTaskDB(projectId, ownerId, "Backlog", "To Do", "")
+
+	t.Run("CloseMilestone", func(t *testing.T) {
+		t.Run("Should carry the unfinished tasks to the next milestone", func(t *testing.T) {
+			// Action
+			carried := milestoneRepositoryPG.CloseMilestone(sprintId, nextSprintId)
+
+			// Assert
+			assert.Equal(t, 3, carried)
+			assert.Equal(t, "closed", projectHelperDb.GetMilestoneStateDB(sprintId))
+			assert.Equal(t, "planned", projectHelperDb.GetMilestoneStateDB(nextSprintId))
+
+			expected := map[string]string{
+				"Design":  nextSprintId,
+				"Build":   nextSprintId,
+				"Trashed": nextSprintId,
+				"Test":    sprintId,
+				"Dropped": sprintId,
+			}
+			for title, milestoneId := range expected {
+				assert.Equal(t, milestoneId, projectHelperDb.GetTaskDB(tasks[title]).MilestoneId, title)
+			}
+			assert.Empty(t, projectHelperDb.GetTaskDB(backlogId).MilestoneId)
+		})
+
+		t.Run("Should raise panic if the milestone is not active", func(t *testing.T) {
+			for _, id := range []string{sprintId, nextSprintId} {
+				assert.PanicsWithError(t, "Only active milestones can be closed!", func() {
+					milestoneRepositoryPG.CloseMilestone(id, "")
+				})
+			}
+
+			// Nothing moved
+			assert.Equal(t, nextSprintId, projectHelperDb.GetTaskDB(tasks["Design"]).MilestoneId)
+		})
+
+		t.Run("Should take the unfinished tasks out of any milestone without a next one", func(t *testing.T) {
+			// Arrange
+			milestoneRepositoryPG.StartMilestone(nextSprintId)
+
+			// Action
+			carried := milestoneRepositoryPG.CloseMilestone(nextSprintId, "")
+
+			// Assert
+			assert.Equal(t, 3, carried)
+			assert.Equal(t, "closed", projectHelperDb.GetMilestoneStateDB(nextSprintId))
+			for _, title := range []string{"Design", "Build", "Trashed"} {
+				assert.Empty(t, projectHelperDb.GetTaskDB(tasks[title]).MilestoneId, title)
+			}
+			assert.Equal(t, sprintId, projectHelperDb.GetTaskDB(tasks["Test"]).MilestoneId)
+		})
+	})
+}
@@ -0,0 +1,355 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+)
+
+type OrganizationRepositoryPG struct /* implements OrganizationRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewOrganizationRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.OrganizationRepository {
+	return &OrganizationRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *OrganizationRepositoryPG) AddOrganization(payload *entity.OrganizationPayload, ownerId string) string {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	id := r.idGenerator.Generate()
+	query := `INSERT INTO organizations(id, name, created_by) VALUES ($1, $2, $3)`
+	_, err = tx.Exec(query, id, payload.Name, ownerId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: add organization: %v", err))
+	}
+
+	query = `INSERT INTO organization_members(organization_id, user_id, role) VALUES ($1, $2, 'owner')`
+	_, err = tx.Exec(query, id, ownerId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: add organization owner: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return id
+}
+
+func (r *OrganizationRepositoryPG) EnsurePersonalOrganization(userId string) string {
+	if id := r.getPersonalOrganization(userId); id != "" {
+		return id
+	}
+
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	// Concurrent requests may create it first, the unique index keeps a single one
+	var id string
+	query := `
+		INSERT INTO organizations(id, name, is_personal, created_by)
+		SELECT $1, username || '''s workspace', TRUE, id FROM users WHERE id = $2
+		ON CONFLICT (created_by) WHERE is_personal DO NOTHING
+		RETURNING id`
+	err = tx.QueryRow(query, r.idGenerator.Generate(), userId).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			if id = r.getPersonalOrganization(userId); id != "" {
+				return id
+			}
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+		panic(fmt.Errorf("organization_repo_pg_error: add personal organization: %v", err))
+	}
+
+	query = `INSERT INTO organization_members(organization_id, user_id, role) VALUES ($1, $2, 'owner')`
+	_, err = tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: add personal organization owner: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return id
+}
+
+// getPersonalOrganization returns the personal workspace of the user, empty if it's not created yet.
+func (r *OrganizationRepositoryPG) getPersonalOrganization(userId string) string {
+	var id string
+
+	query := `SELECT id FROM organizations WHERE is_personal AND created_by = $1`
+	err := r.db.QueryRow(query, userId).Scan(&id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		panic(fmt.Errorf("organization_repo_pg_error: get personal organization: %v", err))
+	}
+
+	return id
+}
+
+func (r *OrganizationRepositoryPG) GetOrganizationById(id string, userId string) *entity.Organization {
+	organizations := r.queryOrganizations(`
+		SELECT o.id, o.name, o.is_personal, om.role, `+utcTimestamp("o.created_at")+`, `+utcTimestamp("o.updated_at")+`
+		FROM organizations o
+		INNER JOIN organization_members om ON om.organization_id = o.id
+		WHERE o.id = $1 AND om.user_id = $2`, id, userId)
+
+	// Outsiders can't tell whether the organization exists
+	if len(organizations) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Organization not found!"))
+	}
+
+	return &organizations[0]
+}
+
+func (r *OrganizationRepositoryPG) GetOrganizationsByUser(userId string) []entity.Organization {
+	return r.queryOrganizations(`
+		SELECT o.id, o.name, o.is_personal, om.role, `+utcTimestamp("o.created_at")+`, `+utcTimestamp("o.updated_at")+`
+		FROM organizations o
+		INNER JOIN organization_members om ON om.organization_id = o.id
+		WHERE om.user_id = $1
+		ORDER BY o.is_personal DESC, o.name`, userId)
+}
+
+func (r *OrganizationRepositoryPG) UpdateOrganizationById(id string, payload *entity.OrganizationPayload) {
+	query := `UPDATE organizations SET name = $2, updated_at = NOW() WHERE id = $1`
+
+	result, err := r.db.Exec(query, id, payload.Name)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: update organization: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Organization not found!"))
+	}
+}
+
+func (r *OrganizationRepositoryPG) DeleteOrganizationById(id string) {
+	// Projects, their tasks and the member directory are removed by the cascade
+	query := `DELETE FROM organizations WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: delete organization: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Organization not found!"))
+	}
+}
+
+func (r *OrganizationRepositoryPG) CountProjects(id string) int {
+	var count int
+
+	query := `SELECT COUNT(*) FROM projects WHERE organization_id = $1`
+	err := r.db.QueryRow(query, id).Scan(&count)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: count projects: %v", err))
+	}
+
+	return count
+}
+
+func (r *OrganizationRepositoryPG) GetOrganizationRole(id string, userId string) string {
+	var role string
+
+	query := `
+		SELECT COALESCE(om.role, '')
+		FROM organizations o
+		LEFT JOIN organization_members om ON om.organization_id = o.id AND om.user_id = $2
+		WHERE o.id = $1`
+	err := r.db.QueryRow(query, id, userId).Scan(&role)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "Organization not found!"))
+		}
+		panic(fmt.Errorf("organization_repo_pg_error: get organization role: %v", err))
+	}
+
+	return role
+}
+
+func (r *OrganizationRepositoryPG) GetOrganizationMembers(id string) []entity.OrganizationMember {
+	query := `
+		SELECT u.id, u.username, u.email, om.role, ` + utcTimestamp("om.created_at") + `
+		FROM organization_members om
+		INNER JOIN users u ON u.id = om.user_id
+		WHERE om.organization_id = $1
+		ORDER BY u.username`
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: get organization members: %v", err))
+	}
+	defer rows.Close()
+
+	var members []entity.OrganizationMember
+	for rows.Next() {
+		var member entity.OrganizationMember
+		err := rows.Scan(&member.Id, &member.Username, &member.Email, &member.Role, &member.JoinedAt)
+		if err != nil {
+			panic(fmt.Errorf("organization_repo_pg_error: scan organization member: %v", err))
+		}
+		members = append(members, member)
+	}
+
+	return members
+}
+
+func (r *OrganizationRepositoryPG) AddOrganizationMember(id string, userId string, role string) {
+	query := `INSERT INTO organization_members(organization_id, user_id, role) VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(query, id, userId, role)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "User is already a member of the organization!"))
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+		panic(fmt.Errorf("organization_repo_pg_error: add organization member: %v", err))
+	}
+}
+
+func (r *OrganizationRepositoryPG) UpdateOrganizationMemberRole(id string, userId string, role string) {
+	query := `UPDATE organization_members SET role = $3 WHERE organization_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, id, userId, role)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: update organization member role: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User is not a member of the organization!"))
+	}
+}
+
+func (r *OrganizationRepositoryPG) RemoveOrganizationMember(id string, userId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	// Projects can't be left without an owner
+	var owned int
+	query := `SELECT COUNT(*) FROM projects WHERE organization_id = $1 AND owner_id = $2`
+	err = tx.QueryRow(query, id, userId).Scan(&owned)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: count owned projects: %v", err))
+	}
+	if owned > 0 {
+		panic(fiber.NewError(fiber.StatusConflict, "User still owns projects of the organization, transfer them first!"))
+	}
+
+	query = `DELETE FROM organization_members WHERE organization_id = $1 AND user_id = $2`
+	result, err := tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: remove organization member: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User is not a member of the organization!"))
+	}
+
+	// The user loses access to every project of the organization
+	query = `
+		DELETE FROM task_assignments
+		WHERE user_id = $2
+		  AND task_id IN (
+			SELECT t.id FROM tasks t INNER JOIN projects p ON p.id = t.project_id WHERE p.organization_id = $1
+		  )`
+	_, err = tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: remove member assignments: %v", err))
+	}
+
+	query = `
+		UPDATE tasks t
+		SET owner_id = p.owner_id, updated_at = NOW()
+		FROM projects p
+		WHERE p.id = t.project_id AND p.organization_id = $1 AND t.owner_id = $2`
+	_, err = tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: hand over member tasks: %v", err))
+	}
+
+	query = `
+		DELETE FROM project_members
+		WHERE user_id = $2 AND project_id IN (SELECT id FROM projects WHERE organization_id = $1)`
+	_, err = tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: remove project memberships: %v", err))
+	}
+
+	query = `DELETE FROM team_members WHERE user_id = $2 AND team_id IN (SELECT id FROM teams WHERE organization_id = $1)`
+	_, err = tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: remove team memberships: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *OrganizationRepositoryPG) queryOrganizations(query string, args ...interface{}) []entity.Organization {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("organization_repo_pg_error: query organizations: %v", err))
+	}
+	defer rows.Close()
+
+	var organizations []entity.Organization
+	for rows.Next() {
+		var organization entity.Organization
+		err := rows.Scan(
+			&organization.Id,
+			&organization.Name,
+			&organization.IsPersonal,
+			&organization.Role,
+			&organization.CreatedAt,
+			&organization.UpdatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("organization_repo_pg_error: scan organization: %v", err))
+		}
+		organizations = append(organizations, organization)
+	}
+
+	return organizations
+}
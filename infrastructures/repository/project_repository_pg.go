@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
 	"github.com/wisle25/task-pixie/applications/generator"
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+	"time"
 )
 
 type ProjectRepositoryPG struct {
@@ -28,9 +31,9 @@ func (r *ProjectRepositoryPG) AddProject(payload *entity.ProjectPayload, ownerId
 
 	// Insert project
 	query := `INSERT INTO 
-    			projects(id, title, detail, priority, status, owner_id) 
+    			projects(id, title, detail, priority, status, owner_id, organization_id) 
 			  VALUES
-			      ($1, $2, $3, $4, $5, $6)
+			      ($1, $2, $3, $4, $5, $6, $7)
 			  RETURNING id`
 
 	var returnedId string
@@ -42,39 +45,39 @@ func (r *ProjectRepositoryPG) AddProject(payload *entity.ProjectPayload, ownerId
 		payload.Priority,
 		payload.Status,
 		ownerId,
+		payload.OrganizationId,
 	).Scan(&returnedId)
 
 	if err != nil {
 		panic(fmt.Errorf("project_repo_pg_error: add project: %v", err))
 	}
 
-	// Insert members
-	for _, memberId := range payload.MembersId {
-		query := `INSERT INTO project_members(project_id, user_id) VALUES ($1, $2)`
-		_, err := r.db.Exec(query, returnedId, memberId)
-		if err != nil {
-			panic(fmt.Errorf("project_repo_pg_error: add project member: %v", err))
-		}
-	}
-
 	return returnedId
 }
 
 func (r *ProjectRepositoryPG) GetProjectById(id string) *entity.Project {
 	var project entity.Project
+	var archivedAt sql.NullString
 	var memberUsername string
 
 	// Query project details
-	query := `SELECT id, title, detail, priority, status, created_at, updated_at FROM projects WHERE id = $1`
+	query := `SELECT id, organization_id, title, detail, priority, status,
+			  	` + utcTimestamp("archived_at") + `, ` + utcTimestamp("created_at") + `, ` + utcTimestamp("updated_at") + `, version
+			  FROM projects
+			  WHERE id = $1 AND deleted_at IS NULL AND NOT is_template`
 	err := r.db.QueryRow(query, id).Scan(
 		&project.Id,
+		&project.OrganizationId,
 		&project.Title,
 		&project.Detail,
 		&project.Priority,
 		&project.Status,
+		&archivedAt,
 		&project.CreatedAt,
 		&project.UpdatedAt,
+		&project.Version,
 	)
+	project.ArchivedAt = archivedAt.String
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -102,14 +105,13 @@ func (r *ProjectRepositoryPG) GetProjectById(id string) *entity.Project {
 	return &project
 }
 
-func (r *ProjectRepositoryPG) GetProjectMembers(id string) []entity.User {
+func (r *ProjectRepositoryPG) GetProjectMembers(id string) []entity.ProjectMember {
+	// Roles come from effective_project_members, the same as GetMemberRole
 	query := `
-        SELECT
-            u.id,
-            u.username
-        FROM project_members pm
-        INNER JOIN users u ON pm.user_id = u.id
-        WHERE pm.project_id = $1`
+        SELECT u.id, u.username, e.role, e.teams
+        FROM effective_project_members($1) e
+        INNER JOIN users u ON e.user_id = u.id
+        ORDER BY e.role = 'owner' DESC, u.username`
 
 	rows, err := r.db.Query(query, id)
 	if err != nil {
@@ -117,84 +119,179 @@ func (r *ProjectRepositoryPG) GetProjectMembers(id string) []entity.User {
 	}
 	defer rows.Close()
 
-	var members []entity.User
+	var members []entity.ProjectMember
 	for rows.Next() {
-		var user entity.User
-		if err := rows.Scan(&user.Id, &user.Username); err != nil {
+		var member entity.ProjectMember
+		if err := rows.Scan(&member.Id, &member.Username, &member.Role, pq.Array(&member.Teams)); err != nil {
 			panic(fmt.Errorf("project_repo_pg_error: scan project member: %v", err))
 		}
-		members = append(members, user)
+		members = append(members, member)
 	}
 
 	return members
 }
 
-func (r *ProjectRepositoryPG) UpdateProjectById(id string, payload *entity.ProjectPayload) {
+func (r *ProjectRepositoryPG) UpdateProjectById(id string, payload *entity.ProjectPayload, versions []int) {
 	// Update project details
 	query := `
 		UPDATE projects 
 		SET title = $1, detail = $2, priority = $3, status = $4, updated_at = NOW()
-		WHERE id = $5`
-
-	_, err := r.db.Exec(query, payload.Title, payload.Detail, payload.Priority, payload.Status, id)
+		WHERE id = $5 AND deleted_at IS NULL` + versionCondition(6)
 
+	result, err := r.db.Exec(query, payload.Title, payload.Detail, payload.Priority, payload.Status, id, versionArg(versions))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			panic(fiber.NewError(fiber.StatusNotFound, "Project not found!"))
-		}
 		panic(fmt.Errorf("project_repo_pg_error: update project: %v", err))
 	}
 
-	// Remove existing members
-	query = `DELETE FROM project_members WHERE project_id = $1`
-	_, err = r.db.Exec(query, id)
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panicMissedWrite(r.db, "projects", id, "Project not found!")
+	}
+}
+
+func (r *ProjectRepositoryPG) DeleteProjectById(id string, versions []int) {
+	// Members are kept, so restoring brings the project back as it was
+	query := `UPDATE projects SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL` + versionCondition(2)
+	result, err := r.db.Exec(query, id, versionArg(versions))
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: delete project: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panicMissedWrite(r.db, "projects", id, "Project not found!")
+	}
+}
+
+func (r *ProjectRepositoryPG) GetTaskCounts(projectId string, today string) []entity.TaskCount {
+	query := `
+		SELECT
+			status,
+			priority,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE due_date < $2::DATE AND status NOT IN ('Completed', 'Canceled'))
+		FROM tasks
+		WHERE project_id = $1 AND deleted_at IS NULL
+		GROUP BY status, priority`
+
+	rows, err := r.db.Query(query, projectId, today)
 	if err != nil {
-		panic(fmt.Errorf("project_repo_pg_error: delete project members: %v", err))
+		panic(fmt.Errorf("project_repo_pg_error: get task counts: %v", err))
 	}
+	defer rows.Close()
 
-	// Insert new members
-	for _, memberId := range payload.MembersId {
-		query := `INSERT INTO project_members(project_id, user_id) VALUES ($1, $2)`
-		_, err := r.db.Exec(query, id, memberId)
+	var counts []entity.TaskCount
+	for rows.Next() {
+		var count entity.TaskCount
+		err := rows.Scan(&count.Status, &count.Priority, &count.Count, &count.Overdue)
 		if err != nil {
-			panic(fmt.Errorf("project_repo_pg_error: add project member: %v", err))
+			panic(fmt.Errorf("project_repo_pg_error: scan task count: %v", err))
 		}
+		counts = append(counts, count)
 	}
-}
 
-func (r *ProjectRepositoryPG) DeleteProjectById(id string) {
-	query := `DELETE FROM projects WHERE id = $1`
-	_, err := r.db.Exec(query, id)
+	return counts
+}
 
+func (r *ProjectRepositoryPG) GetAssigneeWorkload(projectId string, today string) []entity.AssigneeWorkload {
+	query := `
+		SELECT
+			u.id,
+			u.username,
+			COUNT(*) FILTER (WHERE t.status NOT IN ('Completed', 'Canceled')),
+			COUNT(*) FILTER (WHERE t.status = 'Completed'),
+			COUNT(*) FILTER (WHERE t.due_date < $2::DATE AND t.status NOT IN ('Completed', 'Canceled')),
+			COALESCE(SUM(t.estimate) FILTER (WHERE t.status NOT IN ('Completed', 'Canceled')), 0)
+		FROM task_assignments ta
+		INNER JOIN tasks t ON t.id = ta.task_id
+		INNER JOIN users u ON u.id = ta.user_id
+		WHERE t.project_id = $1 AND t.deleted_at IS NULL
+		GROUP BY u.id, u.username
+		ORDER BY 3 DESC, u.username`
+
+	rows, err := r.db.Query(query, projectId, today)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			panic(fiber.NewError(fiber.StatusNotFound, "Project not found!"))
+		panic(fmt.Errorf("project_repo_pg_error: get assignee workload: %v", err))
+	}
+	defer rows.Close()
+
+	var workload []entity.AssigneeWorkload
+	for rows.Next() {
+		var assignee entity.AssigneeWorkload
+		err := rows.Scan(
+			&assignee.UserId,
+			&assignee.Username,
+			&assignee.Open,
+			&assignee.Completed,
+			&assignee.Overdue,
+			&assignee.Estimate,
+		)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: scan assignee workload: %v", err))
 		}
-		panic(fmt.Errorf("project_repo_pg_error: delete project: %v", err))
+		workload = append(workload, assignee)
 	}
 
-	// Delete project members
-	query = `DELETE FROM project_members WHERE project_id = $1`
-	_, err = r.db.Exec(query, id)
+	return workload
+}
+
+func (r *ProjectRepositoryPG) GetDailyStatusCounts(projectId string, from string, to string) []entity.DailyStatusCount {
+	// The state of a task at the end of a day is its latest activity before the next day starts
+	query := `
+		WITH days AS (
+			SELECT day::DATE FROM generate_series($2::DATE, $3::DATE, INTERVAL '1 day') AS day
+		),
+		project_tasks AS (
+			SELECT DISTINCT task_id FROM task_activities WHERE project_id = $1
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'), state.status, COUNT(*)
+		FROM days d
+		CROSS JOIN LATERAL (
+			SELECT DISTINCT ON (a.task_id) a.project_id, a.status, a.deleted
+			FROM task_activities a
+			WHERE a.task_id IN (SELECT task_id FROM project_tasks)
+			  AND a.changed_at < (d.day + 1)::TIMESTAMP AT TIME ZONE 'UTC'
+			ORDER BY a.task_id, a.changed_at DESC, a.id DESC
+		) state
+		WHERE state.project_id = $1 AND NOT state.deleted
+		GROUP BY d.day, state.status
+		ORDER BY d.day`
+
+	rows, err := r.db.Query(query, projectId, from, to)
 	if err != nil {
-		panic(fmt.Errorf("project_repo_pg_error: delete project members: %v", err))
+		panic(fmt.Errorf("project_repo_pg_error: get daily status counts: %v", err))
 	}
+	defer rows.Close()
+
+	var counts []entity.DailyStatusCount
+	for rows.Next() {
+		var count entity.DailyStatusCount
+		err := rows.Scan(&count.Date, &count.Status, &count.Count)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: scan daily status count: %v", err))
+		}
+		counts = append(counts, count)
+	}
+
+	return counts
 }
 
-func (r *ProjectRepositoryPG) GetProjectsByOwner(ownerId string) []entity.PreviewProject {
+func (r *ProjectRepositoryPG) GetAccessibleProjects(userId string, organizationId string) []entity.PreviewProject {
 	var projects []entity.PreviewProject
 
-	// Query projects by owner
-	query := `SELECT id, title FROM projects WHERE owner_id = $1`
-	rows, err := r.db.Query(query, ownerId)
+	query := `
+		SELECT p.id, p.title, p.organization_id
+		FROM projects p
+		WHERE p.id IN (SELECT project_id FROM accessible_project_ids($1))
+		  AND ($2 = '' OR p.organization_id::TEXT = $2)
+		ORDER BY p.title`
+	rows, err := r.db.Query(query, userId, organizationId)
 	if err != nil {
-		panic(fmt.Errorf("project_repo_pg_error: get projects by owner: %v", err))
+		panic(fmt.Errorf("project_repo_pg_error: get accessible projects: %v", err))
 	}
 	defer rows.Close()
 
 	for rows.Next() {
 		var project entity.PreviewProject
-		err := rows.Scan(&project.Id, &project.Title)
+		err := rows.Scan(&project.Id, &project.Title, &project.OrganizationId)
 		if err != nil {
 			panic(fmt.Errorf("project_repo_pg_error: scan project: %v", err))
 		}
@@ -204,29 +301,478 @@ func (r *ProjectRepositoryPG) GetProjectsByOwner(ownerId string) []entity.Previe
 	return projects
 }
 
-func (r *ProjectRepositoryPG) GetProjectsByMember(memberId string) []entity.PreviewProject {
-	var projects []entity.PreviewProject
+func (r *ProjectRepositoryPG) GetMemberRole(projectId string, userId string) string {
+	var role string
 
-	// Query projects by member
+	// Roles come from effective_project_members, the same as GetProjectMembers.
+	// Templates aren't projects anybody works in, they're looked up by GetTemplateById
 	query := `
-		SELECT p.id, p.title
+		SELECT COALESCE((SELECT e.role FROM effective_project_members(p.id) e WHERE e.user_id = $2), '')
 		FROM projects p
-		JOIN project_members pm ON p.id = pm.project_id
-		WHERE pm.user_id = $1`
-	rows, err := r.db.Query(query, memberId)
+		WHERE p.id = $1 AND p.deleted_at IS NULL AND NOT p.is_template`
+	err := r.db.QueryRow(query, projectId, userId).Scan(&role)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "Project not found!"))
+		}
+		panic(fmt.Errorf("project_repo_pg_error: get member role: %v", err))
+	}
+
+	return role
+}
+
+func (r *ProjectRepositoryPG) SetProjectArchived(id string, archived bool) {
+	query := `
+		UPDATE projects
+		SET archived_at = CASE WHEN $2 THEN COALESCE(archived_at, NOW()) END, updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(query, id, archived)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: set project archived: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Project not found!"))
+	}
+}
+
+func (r *ProjectRepositoryPG) GetDeletedProjectsByOwner(ownerId string) []entity.TrashedProject {
+	query := `
+		SELECT id, title, ` + utcTimestamp("deleted_at") + `
+		FROM projects
+		WHERE owner_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC`
+
+	rows, err := r.db.Query(query, ownerId)
 	if err != nil {
-		panic(fmt.Errorf("project_repo_pg_error: get projects by member: %v", err))
+		panic(fmt.Errorf("project_repo_pg_error: get deleted projects by owner: %v", err))
 	}
 	defer rows.Close()
 
+	var projects []entity.TrashedProject
 	for rows.Next() {
-		var project entity.PreviewProject
-		err := rows.Scan(&project.Id, &project.Title)
-		if err != nil {
-			panic(fmt.Errorf("project_repo_pg_error: scan project: %v", err))
+		var project entity.TrashedProject
+		if err := rows.Scan(&project.Id, &project.Title, &project.DeletedAt); err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: scan deleted project: %v", err))
 		}
 		projects = append(projects, project)
 	}
 
 	return projects
 }
+
+func (r *ProjectRepositoryPG) RestoreProjectById(id string, ownerId string) {
+	query := `
+		UPDATE projects
+		SET deleted_at = NULL, updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2 AND deleted_at IS NOT NULL`
+
+	result, err := r.db.Exec(query, id, ownerId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: restore project: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Project not found in the trash!"))
+	}
+}
+
+func (r *ProjectRepositoryPG) PurgeDeletedProjects(retention time.Duration) int64 {
+	// Tasks and members are removed by the cascade
+	query := `DELETE FROM projects WHERE deleted_at < NOW() - make_interval(secs => $1)`
+
+	result, err := r.db.Exec(query, retention.Seconds())
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: purge deleted projects: %v", err))
+	}
+
+	affected, _ := result.RowsAffected()
+
+	return affected
+}
+
+func (r *ProjectRepositoryPG) AddMember(projectId string, userId string, role string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	// Joining a project means joining its organization
+	query := `
+		INSERT INTO organization_members(organization_id, user_id, role)
+		SELECT organization_id, $2, 'member' FROM projects WHERE id = $1
+		ON CONFLICT (organization_id, user_id) DO NOTHING`
+	_, err = tx.Exec(query, projectId, userId)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+		panic(fmt.Errorf("project_repo_pg_error: add organization member: %v", err))
+	}
+
+	query = `INSERT INTO project_members(project_id, user_id, role) VALUES ($1, $2, $3)`
+	_, err = tx.Exec(query, projectId, userId, role)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "User is already a member of the project!"))
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+		panic(fmt.Errorf("project_repo_pg_error: add member: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *ProjectRepositoryPG) UpdateMemberRole(projectId string, userId string, role string) {
+	query := `UPDATE project_members SET role = $3 WHERE project_id = $1 AND user_id = $2`
+
+	result, err := r.db.Exec(query, projectId, userId, role)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: update member role: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User is not a member of the project!"))
+	}
+}
+
+func (r *ProjectRepositoryPG) RemoveMember(projectId string, userId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`
+	result, err := tx.Exec(query, projectId, userId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: remove member: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User is not a member of the project!"))
+	}
+
+	// The user loses access to the project, so the tasks mustn't stay with the user.
+	// Access kept through a team is checked on the membership removed above.
+	query = `
+		DELETE FROM task_assignments
+		WHERE user_id = $2 AND task_id IN (SELECT id FROM tasks WHERE project_id = $1)
+		  AND NOT EXISTS (SELECT 1 FROM accessible_project_ids($2) a WHERE a.project_id = $1)`
+	_, err = tx.Exec(query, projectId, userId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: remove member assignments: %v", err))
+	}
+
+	query = `
+		UPDATE tasks
+		SET owner_id = (SELECT owner_id FROM projects WHERE id = $1), updated_at = NOW()
+		WHERE project_id = $1 AND owner_id = $2
+		  AND NOT EXISTS (SELECT 1 FROM accessible_project_ids($2) a WHERE a.project_id = $1)`
+	_, err = tx.Exec(query, projectId, userId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: hand over member tasks: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *ProjectRepositoryPG) TransferOwnership(projectId string, newOwnerId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	query := `DELETE FROM project_members WHERE project_id = $1 AND user_id = $2`
+	result, err := tx.Exec(query, projectId, newOwnerId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: remove new owner membership: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User is not a member of the project!"))
+	}
+
+	// The previous owner stays as admin
+	query = `
+		INSERT INTO project_members(project_id, user_id, role)
+		SELECT id, owner_id, 'admin' FROM projects WHERE id = $1`
+	_, err = tx.Exec(query, projectId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: keep previous owner: %v", err))
+	}
+
+	query = `UPDATE projects SET owner_id = $2, updated_at = NOW() WHERE id = $1`
+	_, err = tx.Exec(query, projectId, newOwnerId)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: transfer ownership: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *ProjectRepositoryPG) CloneProject(
+	sourceId string,
+	payload *entity.CloneProjectPayload,
+	ownerId string,
+	isTemplate bool,
+) string {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	id := r.idGenerator.Generate()
+	query := `
+		INSERT INTO projects(id, title, detail, priority, status, owner_id, organization_id, is_template)
+		SELECT $2, $3, detail, priority, status, $4, $5, $6
+		FROM projects
+		WHERE id = $1 AND deleted_at IS NULL`
+	result, err := tx.Exec(query, sourceId, id, payload.Title, ownerId, payload.OrganizationId, isTemplate)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: clone project: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Project not found!"))
+	}
+
+	if payload.IncludeMembers {
+		// The previous owner stays as admin, like a transfer of ownership.
+		// MIN keeps "admin" over "member" for anyone listed twice.
+		query = `
+			INSERT INTO project_members(project_id, user_id, role)
+			SELECT $2, m.user_id, MIN(m.role)
+			FROM (
+				SELECT user_id, role FROM project_members WHERE project_id = $1
+				UNION ALL
+				SELECT owner_id, 'admin' FROM projects WHERE id = $1
+			) m
+			INNER JOIN organization_members om ON om.user_id = m.user_id AND om.organization_id = $4
+			WHERE m.user_id <> $3
+			GROUP BY m.user_id`
+		_, err = tx.Exec(query, sourceId, id, ownerId, payload.OrganizationId)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: clone project members: %v", err))
+		}
+
+		query = `
+			INSERT INTO project_teams(project_id, team_id, role)
+			SELECT $2, pt.team_id, pt.role
+			FROM project_teams pt
+			INNER JOIN teams t ON t.id = pt.team_id
+			WHERE pt.project_id = $1 AND t.organization_id = $3`
+		_, err = tx.Exec(query, sourceId, id, payload.OrganizationId)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: clone project teams: %v", err))
+		}
+	}
+
+	if payload.IncludeTasks {
+		r.cloneTasks(tx, sourceId, id, payload, ownerId)
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return id
+}
+
+// cloneTasks copies the tasks of the source project into the new one, mapping the assignees along.
+func (r *ProjectRepositoryPG) cloneTasks(
+	tx *sql.Tx,
+	sourceId string,
+	id string,
+	payload *entity.CloneProjectPayload,
+	ownerId string,
+) {
+	// Due dates keep their spacing, the earliest one falls on the start date
+	query := `
+		SELECT
+			t.id,
+			t.title,
+			t.description,
+			COALESCE(t.detail, ''),
+			t.priority,
+			CASE WHEN $3 THEN 'To Do' ELSE t.status END,
+			COALESCE(to_char(t.due_date + COALESCE(
+				NULLIF($2, '')::DATE - (SELECT MIN(due_date) FROM tasks WHERE project_id = $1 AND deleted_at IS NULL),
+				0
+			), 'YYYY-MM-DD'), ''),
+			COALESCE(t.estimate, 0)
+		FROM tasks t
+		WHERE t.project_id = $1 AND t.deleted_at IS NULL
+		ORDER BY t.created_at`
+	rows, err := tx.Query(query, sourceId, payload.StartDate, payload.ResetStatus)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: get tasks to clone: %v", err))
+	}
+
+	type sourceTask struct {
+		id          string
+		title       string
+		description string
+		detail      string
+		priority    string
+		status      string
+		dueDate     string
+		estimate    int
+	}
+
+	// Read everything first, a transaction can't run queries while rows are open
+	var tasks []sourceTask
+	for rows.Next() {
+		var task sourceTask
+		err := rows.Scan(&task.id, &task.title, &task.description, &task.detail, &task.priority, &task.status, &task.dueDate, &task.estimate)
+		if err != nil {
+			_ = rows.Close()
+			panic(fmt.Errorf("project_repo_pg_error: scan task to clone: %v", err))
+		}
+		tasks = append(tasks, task)
+	}
+	_ = rows.Close()
+
+	for _, task := range tasks {
+		taskId := r.idGenerator.Generate()
+
+		query = `
+			INSERT INTO tasks(id, title, description, detail, priority, status, project_id, due_date, owner_id, estimate)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::DATE, $9, NULLIF($10, 0))`
+		_, err = tx.Exec(
+			query,
+			taskId,
+			task.title,
+			task.description,
+			task.detail,
+			task.priority,
+			task.status,
+			id,
+			task.dueDate,
+			ownerId,
+			task.estimate,
+		)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: clone task: %v", err))
+		}
+
+		if !payload.IncludeMembers {
+			continue
+		}
+
+		// Only people of the new project keep their assignments
+		query = `
+			INSERT INTO task_assignments(task_id, user_id)
+			SELECT $2, ta.user_id
+			FROM task_assignments ta
+			WHERE ta.task_id = $1
+			  AND (ta.user_id = $4 OR ta.user_id IN (SELECT user_id FROM project_members WHERE project_id = $3))`
+		_, err = tx.Exec(query, task.id, taskId, id, ownerId)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: clone task assignments: %v", err))
+		}
+	}
+}
+
+func (r *ProjectRepositoryPG) GetTemplateById(id string) *entity.ProjectTemplate {
+	templates := r.queryTemplates(`
+		SELECT p.id, p.organization_id, p.title, COALESCE(p.detail, ''), COUNT(t.id), p.owner_id, u.username, `+utcTimestamp("p.created_at")+`
+		FROM projects p
+		INNER JOIN users u ON u.id = p.owner_id
+		LEFT JOIN tasks t ON t.project_id = p.id AND t.deleted_at IS NULL
+		WHERE p.id = $1 AND p.is_template
+		GROUP BY p.id, u.username`, id)
+
+	if len(templates) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Template not found!"))
+	}
+
+	return &templates[0]
+}
+
+func (r *ProjectRepositoryPG) GetTemplatesByOrganization(organizationId string) []entity.ProjectTemplate {
+	return r.queryTemplates(`
+		SELECT p.id, p.organization_id, p.title, COALESCE(p.detail, ''), COUNT(t.id), p.owner_id, u.username, `+utcTimestamp("p.created_at")+`
+		FROM projects p
+		INNER JOIN users u ON u.id = p.owner_id
+		LEFT JOIN tasks t ON t.project_id = p.id AND t.deleted_at IS NULL
+		WHERE p.organization_id = $1 AND p.is_template
+		GROUP BY p.id, u.username
+		ORDER BY p.title`, organizationId)
+}
+
+func (r *ProjectRepositoryPG) DeleteTemplateById(id string) {
+	// Templates skip the trash, tasks are removed by the cascade
+	query := `DELETE FROM projects WHERE id = $1 AND is_template`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: delete template: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Template not found!"))
+	}
+}
+
+func (r *ProjectRepositoryPG) queryTemplates(query string, args ...interface{}) []entity.ProjectTemplate {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("project_repo_pg_error: query templates: %v", err))
+	}
+	defer rows.Close()
+
+	var templates []entity.ProjectTemplate
+	for rows.Next() {
+		var template entity.ProjectTemplate
+		err := rows.Scan(
+			&template.Id,
+			&template.OrganizationId,
+			&template.Title,
+			&template.Detail,
+			&template.TasksCount,
+			&template.OwnerId,
+			&template.CreatedBy,
+			&template.CreatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("project_repo_pg_error: scan template: %v", err))
+		}
+		templates = append(templates, template)
+	}
+
+	return templates
+}
@@ -0,0 +1,258 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/generator"
+	"github.com/wisle25/task-pixie/infrastructures/repository"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+	"github.com/wisle25/task-pixie/tests/db_helper"
+)
+
+func TestProjectRepositoryMemberRole(t *testing.T) {
+	// Arrange
+	config := commons.LoadConfig("../..")
+	db := services.ConnectDB(config)
+	projectHelperDb := &db_helper.ProjectHelperDB{
+		DB: db,
+	}
+	defer projectHelperDb.CleanProjectDB()
+
+	projectRepositoryPG := repository.NewProjectRepositoryPG(db, generator.NewUUIDGenerator())
+
+	organizationId := projectHelperDb.AddOrganizationDB("Acme")
+	users := make(map[string]string)
+	for _, name := range []string{"owner", "orgOwner", "orgAdmin", "admin", "member", "teamAdmin", "teamMember", "both", "bystander", "outsider"} {
+		users[name] = projectHelperDb.AddUserDB(name)
+	}
+	for name, role := range map[string]string{
+		"owner":      "member",
+		"orgOwner":   "owner",
+		"orgAdmin":   "admin",
+		"admin":      "member",
+		"member":     "member",
+		"teamAdmin":  "member",
+		"teamMember": "member",
+		"both":       "member",
+		"bystander":  "member",
+	} {
+		projectHelperDb.AddOrganizationMemberDB(organizationId, users[name], role)
+	}
+
+	projectId := projectHelperDb.AddProjectDB(organizationId, users["owner"], "Website")
+	projectHelperDb.AddProjectMemberDB(projectId, users["admin"], "admin")
+	projectHelperDb.AddProjectMemberDB(projectId, users["member"], "member")
+	projectHelperDb.AddProjectMemberDB(projectId, users["both"], "member")
+	projectHelperDb.AddProjectMemberDB(projectId, users["outsider"], "admin")
+	designers := projectHelperDb.AddTeamDB(organizationId, "Designers", users["teamAdmin"], users["both"])
+	writers := projectHelperDb.AddTeamDB(organizationId, "Writers", users["teamMember"], users["both"], users["outsider"])
+	projectHelperDb.AddProjectTeamDB(projectId, designers, "admin")
+	projectHelperDb.AddProjectTeamDB(projectId, writers, "member")
+
+	expected := map[string]string{
+		"owner":      "owner",
+		"orgOwner":   "admin",
+		"orgAdmin":   "admin",
+		"admin":      "admin",
+		"member":     "member",
+		"teamAdmin":  "admin",
+		"teamMember": "member",
+		"both":       "admin",
+		"bystander":  "",
+		"outsider":   "",
+	}
+
+	t.Run("GetMemberRole", func(t *testing.T) {
+		t.Run("Should take the highest of the organization, direct and team roles", func(t *testing.T) {
+			for name, role := range expected {
+				assert.Equal(t, role, projectRepositoryPG.GetMemberRole(projectId, users[name]), name)
+			}
+		})
+
+		t.Run("Should keep the roles of archived projects", func(t *testing.T) {
+			// Arrange
+			archivedId := projectHelperDb.AddProjectDB(organizationId, users["owner"], "Archived")
+			projectHelperDb.AddProjectMemberDB(archivedId, users["member"], "member")
+			projectHelperDb.SetProjectDB(archivedId, "archived_at", "2024-01-01")
+
+			// Action and Assert
+			assert.Equal(t, "member", projectRepositoryPG.GetMemberRole(archivedId, users["member"]))
+		})
+
+		t.Run("Should raise panic if project is deleted or a template", func(t *testing.T) {
+			// Arrange
+			deletedId := projectHelperDb.AddProjectDB(organizationId, users["owner"], "Deleted")
+			projectHelperDb.SetProjectDB(deletedId, "deleted_at", "2024-01-01")
+			templateId := projectHelperDb.AddProjectDB(organizationId, users["owner"], "Template")
+			projectHelperDb.SetProjectDB(templateId, "is_template", true)
+
+			// Action and Assert
+			for _, id := range []string{deletedId, templateId} {
+				assert.PanicsWithError(t, "Project not found!", func() {
+					projectRepositoryPG.GetMemberRole(id, users["owner"])
+				})
+			}
+		})
+	})
+
+	t.Run("GetProjectMembers", func(t *testing.T) {
+		t.Run("Should list everyone having a role, with the role GetMemberRole gives them", func(t *testing.T) {
+			// Action
+			members := projectRepositoryPG.GetProjectMembers(projectId)
+
+			// Assert
+			roles := make(map[string]string)
+			teams := make(map[string][]string)
+			for _, member := range members {
+				roles[member.Username] = member.Role
+				teams[member.Username] = member.Teams
+			}
+
+			for name, role := range expected {
+				if role == "" {
+					assert.NotContains(t, roles, name)
+					continue
+				}
+				assert.Equal(t, role, roles[name], name)
+			}
+			assert.Equal(t, "owner", members[0].Username)
+			assert.Equal(t, []string{"Designers", "Writers"}, teams["both"])
+			assert.Empty(t, teams["orgAdmin"])
+		})
+	})
+}
+
+func TestProjectRepositoryClone(t *testing.T) {
+	// Arrange
+	config := commons.LoadConfig("../..")
+	db := services.ConnectDB(config)
+	projectHelperDb := &db_helper.ProjectHelperDB{
+		DB: db,
+	}
+	defer projectHelperDb.CleanProjectDB()
+
+	projectRepositoryPG := repository.NewProjectRepositoryPG(db, generator.NewUUIDGenerator())
+
+	sourceOrganizationId := projectHelperDb.AddOrganizationDB("Acme")
+	targetOrganizationId := projectHelperDb.AddOrganizationDB("Acme Labs")
+	ownerId := projectHelperDb.AddUserDB("owner")
+	memberId := projectHelperDb.AddUserDB("member")
+	outsiderId := projectHelperDb.AddUserDB("outsider")
+	projectHelperDb.AddOrganizationMemberDB(sourceOrganizationId, ownerId, "owner")
+	projectHelperDb.AddOrganizationMemberDB(sourceOrganizationId, memberId, "member")
+	projectHelperDb.AddOrganizationMemberDB(sourceOrganizationId, outsiderId, "member")
+	projectHelperDb.AddOrganizationMemberDB(targetOrganizationId, ownerId, "member")
+	projectHelperDb.AddOrganizationMemberDB(targetOrganizationId, memberId, "owner")
+
+	sourceId := projectHelperDb.AddProjectDB(sourceOrganizationId, ownerId, "Website")
+	projectHelperDb.AddProjectMemberDB(sourceId, memberId, "member")
+	projectHelperDb.AddProjectMemberDB(sourceId, outsiderId, "admin")
+
+	designId := projectHelperDb.AddTaskDB(sourceId, ownerId, "Design", "Completed", "2024-01-10")
+	projectHelperDb.AssignTaskDB(designId, memberId)
+	projectHelperDb.AssignTaskDB(designId, outsiderId)
+	buildId := projectHelperDb.AddTaskDB(sourceId, ownerId, "Build", "In Progress", "2024-01-15")
+	projectHelperDb.AssignTaskDB(buildId, ownerId)
+	projectHelperDb.AddTaskDB(sourceId, ownerId, "Someday", "To Do", "")
+	oldId := projectHelperDb.AddTaskDB(sourceId, ownerId, "Old", "To Do", "2023-12-01")
+	projectHelperDb.SetTaskDB(oldId, "deleted_at", "2024-01-01")
+
+	t.Run("CloneProject", func(t *testing.T) {
+		t.Run("Should copy the members and tasks into the organization", func(t *testing.T) {
+			// Arrange
+			payload := &entity.CloneProjectPayload{
+				Title:          "Website copy",
+				OrganizationId: targetOrganizationId,
+				StartDate:      "2024-03-01",
+				IncludeTasks:   true,
+				IncludeMembers: true,
+				ResetStatus:    true,
+			}
+
+			// Action
+			id := projectRepositoryPG.CloneProject(sourceId, payload, memberId, false)
+
+			// Assert
+			assert.Equal(t, db_helper.ProjectDB{
+				Title:          "Website copy",
+				OwnerId:        memberId,
+				OrganizationId: targetOrganizationId,
+			}, projectHelperDb.GetProjectDB(id))
+
+			// The previous owner stays as admin, the outsider isn't part of the organization
+			assert.Equal(t, map[string]string{ownerId: "admin"}, projectHelperDb.GetProjectMembersDB(id))
+
+			tasks := projectHelperDb.GetTasksDB(id)
+			assert.Len(t, tasks, 3)
+			assert.Equal(t, "Build", tasks[0].Title)
+			assert.Equal(t, "2024-03-06", tasks[0].DueDate)
+			assert.Equal(t, []string{ownerId}, tasks[0].AssigneeIds)
+			assert.Equal(t, "Design", tasks[1].Title)
+			assert.Equal(t, "2024-03-01", tasks[1].DueDate)
+			assert.Equal(t, []string{memberId}, tasks[1].AssigneeIds)
+			assert.Equal(t, "Someday", tasks[2].Title)
+			assert.Equal(t, "", tasks[2].DueDate)
+			assert.Empty(t, tasks[2].AssigneeIds)
+			for _, task := range tasks {
+				assert.Equal(t, "To Do", task.Status)
+				assert.False(t, task.Deleted)
+			}
+		})
+
+		t.Run("Should copy only the project into a template", func(t *testing.T) {
+			// Arrange
+			payload := &entity.CloneProjectPayload{
+				Title:          "Website template",
+				OrganizationId: sourceOrganizationId,
+			}
+
+			// Action
+			id := projectRepositoryPG.CloneProject(sourceId, payload, ownerId, true)
+
+			// Assert
+			assert.Equal(t, db_helper.ProjectDB{
+				Title:          "Website template",
+				OwnerId:        ownerId,
+				OrganizationId: sourceOrganizationId,
+				IsTemplate:     true,
+			}, projectHelperDb.GetProjectDB(id))
+			assert.Empty(t, projectHelperDb.GetProjectMembersDB(id))
+			assert.Empty(t, projectHelperDb.GetTasksDB(id))
+		})
+
+		t.Run("Should keep the statuses of the tasks unless reset", func(t *testing.T) {
+			// Arrange
+			payload := &entity.CloneProjectPayload{
+				Title:          "Website again",
+				OrganizationId: sourceOrganizationId,
+				IncludeTasks:   true,
+			}
+
+			// Action
+			id := projectRepositoryPG.CloneProject(sourceId, payload, ownerId, false)
+
+			// Assert
+			tasks := projectHelperDb.GetTasksDB(id)
+			assert.Len(t, tasks, 3)
+			assert.Equal(t, "In Progress", tasks[0].Status)
+			assert.Equal(t, "2024-01-15", tasks[0].DueDate)
+			assert.Empty(t, tasks[0].AssigneeIds)
+			assert.Equal(t, "Completed", tasks[1].Status)
+		})
+
+		t.Run("Should raise panic if project is not existed", func(t *testing.T) {
+			// Arrange
+			deletedId := projectHelperDb.AddProjectDB(sourceOrganizationId, ownerId, "Deleted")
+			projectHelperDb.SetProjectDB(deletedId, "deleted_at", "2024-01-01")
+			payload := &entity.CloneProjectPayload{Title: "Copy", OrganizationId: sourceOrganizationId}
+
+			// Action and Assert
+			assert.PanicsWithError(t, "Project not found!", func() {
+				projectRepositoryPG.CloneProject(deletedId, payload, ownerId, false)
+			})
+		})
+	})
+}
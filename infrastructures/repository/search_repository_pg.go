@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"html"
+	"strings"
+)
+
+// Markers placed by ts_headline around matched terms, replaced by <mark> after the content is escaped.
+// Private use characters are used, and removed from the content beforehand so the user's content can't place them.
+const (
+	highlightStart = "\uE000"
+	highlightStop  = "\uE001"
+)
+
+type SearchRepositoryPG struct /* implements SearchRepository */ {
+	db *sql.DB
+}
+
+func NewSearchRepositoryPG(db *sql.DB) repository.SearchRepository {
+	return &SearchRepositoryPG{
+		db: db,
+	}
+}
+
+func (r *SearchRepositoryPG) Search(userId string, payload *entity.SearchPayload) []entity.SearchResult {
+	titleOptions := fmt.Sprintf("StartSel=%s, StopSel=%s, HighlightAll=true", highlightStart, highlightStop)
+	snippetOptions := fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=2, MaxWords=25, MinWords=8", highlightStart, highlightStop)
+
+	query := `
+		WITH search AS (
+			SELECT websearch_to_tsquery('english', $1) AS query
+		),
+		accessible_projects AS (
+			SELECT project_id AS id FROM accessible_project_ids($2)
+		)
+		SELECT
+			'task',
+			t.id,
+			ts_headline('english', translate(t.title, $8, ''), s.query, $5),
+			ts_headline('english', translate(concat_ws(' ', t.description, t.detail), $8, ''), s.query, $6),
+			COALESCE(t.project_id::TEXT, ''),
+			ts_rank(t.search_vector, s.query) AS rank
+		FROM tasks t, search s
+		WHERE ($3 = '' OR $3 = 'task')
+		  AND t.search_vector @@ s.query
+		  AND t.deleted_at IS NULL
+		  AND (
+			t.project_id IN (SELECT id FROM accessible_projects)
+			OR (t.project_id IS NULL AND (
+				t.owner_id = $2
+				OR EXISTS (SELECT 1 FROM task_assignments ta WHERE ta.task_id = t.id AND ta.user_id = $2)
+			))
+		  )
+		UNION ALL
+		SELECT
+			'project',
+			p.id,
+			ts_headline('english', translate(p.title, $8, ''), s.query, $5),
+			ts_headline('english', translate(COALESCE(p.detail, ''), $8, ''), s.query, $6),
+			p.id::TEXT,
+			ts_rank(p.search_vector, s.query) AS rank
+		FROM projects p, search s
+		WHERE ($3 = '' OR $3 = 'project')
+		  AND p.search_vector @@ s.query
+		  AND p.id IN (SELECT id FROM accessible_projects)
+		ORDER BY rank DESC
+		LIMIT $4 OFFSET $7`
+
+	rows, err := r.db.Query(
+		query,
+		payload.Query,
+		userId,
+		payload.Type,
+		payload.Limit,
+		titleOptions,
+		snippetOptions,
+		payload.Offset,
+		highlightStart+highlightStop,
+	)
+	if err != nil {
+		panic(fmt.Errorf("search_repo_pg_error: search: %v", err))
+	}
+	defer rows.Close()
+
+	var results []entity.SearchResult
+	for rows.Next() {
+		var result entity.SearchResult
+		err := rows.Scan(
+			&result.Type,
+			&result.Id,
+			&result.Title,
+			&result.Snippet,
+			&result.ProjectId,
+			&result.Rank,
+		)
+		if err != nil {
+			panic(fmt.Errorf("search_repo_pg_error: scan result: %v", err))
+		}
+
+		result.Title = highlight(result.Title)
+		result.Snippet = highlight(result.Snippet)
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// highlight escapes the headline and turns the markers into <mark> tags.
+// The tags are always balanced, a marker that doesn't open or close a highlight is dropped.
+func highlight(headline string) string {
+	var highlighted strings.Builder
+	open := false
+
+	for headline != "" {
+		i := strings.IndexAny(headline, highlightStart+highlightStop)
+		if i < 0 {
+			highlighted.WriteString(html.EscapeString(headline))
+			break
+		}
+		highlighted.WriteString(html.EscapeString(headline[:i]))
+
+		marker := headline[i : i+len(highlightStart)]
+		switch {
+		case marker == highlightStart && !open:
+			highlighted.WriteString("<mark>")
+			open = true
+		case marker == highlightStop && open:
+			highlighted.WriteString("</mark>")
+			open = false
+		}
+		headline = headline[i+len(marker):]
+	}
+
+	if open {
+		highlighted.WriteString("</mark>")
+	}
+
+	return highlighted.String()
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlight(t *testing.T) {
+	t.Run("Should turn the markers into mark tags", func(t *testing.T) {
+		// Action
+		highlighted := highlight("Fix the " + highlightStart + "login" + highlightStop + " page")
+
+		// Assert
+		assert.Equal(t, "Fix the <mark>login</mark> page", highlighted)
+	})
+
+	t.Run("Should escape the content of the task", func(t *testing.T) {
+		// Action
+		highlighted := highlight(`<script>alert("` + highlightStart + "login" + highlightStop + `")</script> & more`)
+
+		// Assert
+		assert.Equal(t, "&lt;script&gt;alert(&#34;<mark>login</mark>&#34;)&lt;/script&gt; &amp; more", highlighted)
+	})
+
+	t.Run("Should escape the content between the markers", func(t *testing.T) {
+		// Action
+		highlighted := highlight(highlightStart + "<script>" + highlightStop)
+
+		// Assert
+		assert.Equal(t, "<mark>&lt;script&gt;</mark>", highlighted)
+	})
+
+	t.Run("Should keep the tags balanced whatever markers the task holds", func(t *testing.T) {
+		tests := map[string]string{
+			highlightStop + "login":                                          "login",
+			highlightStart + "login":                                         "<mark>login</mark>",
+			highlightStart + highlightStart + "login" + highlightStop:        "<mark>login</mark>",
+			highlightStart + "login" + highlightStop + highlightStop + "<b>": "<mark>login</mark>&lt;b&gt;",
+			"a" + highlightStop + highlightStart + "b" + highlightStop + "c": "a<mark>b</mark>c",
+			"<mark>" + highlightStart + "login" + highlightStop + "</mark>":  "&lt;mark&gt;<mark>login</mark>&lt;/mark&gt;",
+		}
+
+		for headline, expected := range tests {
+			assert.Equal(t, expected, highlight(headline), headline)
+		}
+	})
+}
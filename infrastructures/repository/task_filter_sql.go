@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"strings"
+)
+
+// taskFilterCompiler compiles a TaskFilter into a parameterized SQL condition over `tasks t` and `projects p`.
+// User input is only ever passed as arguments, never concatenated into the query.
+type taskFilterCompiler struct {
+	args   []interface{}
+	userId string
+	today  string
+}
+
+// compileTaskFilter returns the WHERE condition of the filter and its arguments, numbered after the given offset.
+func compileTaskFilter(filter *entity.TaskFilter, userId string, today string, offset int) (string, []interface{}) {
+	c := &taskFilterCompiler{
+		args:   make([]interface{}, offset),
+		userId: userId,
+		today:  today,
+	}
+
+	conditions := []string{"TRUE"}
+	for _, condition := range filter.Conditions {
+		conditions = append(conditions, c.compile(condition))
+	}
+
+	return strings.Join(conditions, " AND "), c.args[offset:]
+}
+
+// arg adds the argument, returning its placeholder.
+func (c *taskFilterCompiler) arg(value interface{}) string {
+	c.args = append(c.args, value)
+
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *taskFilterCompiler) compile(condition entity.TaskFilterCondition) string {
+	switch condition := condition.(type) {
+	case entity.TaskFilterNot:
+		// NULL (e.g. comparing a missing due date) must not match either side
+		return fmt.Sprintf("NOT COALESCE((%s), FALSE)", c.compile(condition.Condition))
+	case entity.TaskFilterStatus:
+		return fmt.Sprintf("t.status = ANY(%s)", c.arg(pq.Array(condition.Statuses)))
+	case entity.TaskFilterPriority:
+		return fmt.Sprintf("t.priority = ANY(%s)", c.arg(pq.Array(condition.Priorities)))
+	case entity.TaskFilterProject:
+		lowered := make([]string, len(condition.Projects))
+		for i, project := range condition.Projects {
+			lowered[i] = strings.ToLower(project)
+		}
+
+		return fmt.Sprintf(
+			"(t.project_id::TEXT = ANY(%s) OR LOWER(p.title) = ANY(%s))",
+			c.arg(pq.Array(condition.Projects)),
+			c.arg(pq.Array(lowered)),
+		)
+	case entity.TaskFilterUser:
+		return c.compileUser(condition)
+	case entity.TaskFilterDue:
+		return c.compileDue(condition)
+	case entity.TaskFilterText:
+		pattern := c.arg("%" + escapeLike(condition.Text) + "%")
+
+		return fmt.Sprintf("(t.title ILIKE %s OR t.description ILIKE %s)", pattern, pattern)
+	}
+
+	panic(fmt.Errorf("task_filter_sql_error: unknown condition %T", condition))
+}
+
+func (c *taskFilterCompiler) compileUser(condition entity.TaskFilterUser) string {
+	var users []string
+	if condition.Me {
+		users = append(users, fmt.Sprintf("u.id = %s", c.arg(c.userId)))
+	}
+	if len(condition.Usernames) > 0 {
+		users = append(users, fmt.Sprintf("u.username = ANY(%s)", c.arg(pq.Array(condition.Usernames))))
+	}
+
+	if condition.Role == entity.TaskFilterOwner {
+		return fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM users u WHERE u.id = t.owner_id AND (%s))",
+			strings.Join(users, " OR "),
+		)
+	}
+
+	return fmt.Sprintf(
+		"EXISTS (SELECT 1 FROM task_assignments ta INNER JOIN users u ON u.id = ta.user_id WHERE ta.task_id = t.id AND (%s))",
+		strings.Join(users, " OR "),
+	)
+}
+
+func (c *taskFilterCompiler) compileDue(condition entity.TaskFilterDue) string {
+	if condition.None {
+		return "t.due_date IS NULL"
+	}
+
+	// The operator is written into the query, only the known ones are allowed
+	switch condition.Operator {
+	case entity.TaskFilterEqual, entity.TaskFilterLess, entity.TaskFilterLessEqual,
+		entity.TaskFilterGreater, entity.TaskFilterGreaterEqual:
+	default:
+		panic(fmt.Errorf("task_filter_sql_error: unknown operator %q", condition.Operator))
+	}
+
+	if condition.Date != "" {
+		return fmt.Sprintf("t.due_date %s %s::DATE", condition.Operator, c.arg(condition.Date))
+	}
+
+	return fmt.Sprintf(
+		"t.due_date %s %s::DATE + %s::INT",
+		condition.Operator,
+		c.arg(c.today),
+		c.arg(condition.Days),
+	)
+}
+
+// escapeLike escapes the wildcards of LIKE, so the text is matched literally.
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
@@ -5,13 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
 	"github.com/wisle25/task-pixie/applications/generator"
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/domains/repository"
 	"github.com/wisle25/task-pixie/infrastructures/services"
 	"log"
+	"strings"
+	"time"
 )
 
+// Maximum tasks returned by a filter
+const maxFilteredTasks = 500
+
 type TaskRepositoryPG struct {
 	idGenerator generator.IdGenerator
 	db          *sql.DB
@@ -38,8 +44,19 @@ func (r *TaskRepositoryPG) AddTask(payload *entity.TaskPayload, ownerId string)
 	defer tx.Rollback()
 
 	// Base query for inserting task
-	query := `INSERT INTO tasks (id, title, description, detail, priority, status, due_date, owner_id`
-	args := []interface{}{id, payload.Title, payload.Description, payload.Detail, payload.Priority, payload.Status, payload.DueDate, ownerId}
+	query := `INSERT INTO tasks (id, title, description, detail, priority, status, due_date, owner_id, estimate, milestone_id`
+	args := []interface{}{
+		id,
+		payload.Title,
+		payload.Description,
+		payload.Detail,
+		payload.Priority,
+		payload.Status,
+		payload.DueDate,
+		ownerId,
+		payload.Estimate,
+		payload.MilestoneId,
+	}
 
 	// Handle optional project_id
 	if payload.ProjectId != "" {
@@ -47,9 +64,9 @@ func (r *TaskRepositoryPG) AddTask(payload *entity.TaskPayload, ownerId string)
 		args = append(args, payload.ProjectId)
 	}
 
-	query += `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8`
+	query += `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, 0), NULLIF($10, '')::UUID`
 	if payload.ProjectId != "" {
-		query += `, $9`
+		query += `, $11`
 	}
 	query += `) RETURNING id`
 
@@ -86,10 +103,13 @@ func (r *TaskRepositoryPG) GetTaskById(id string) *entity.Task {
 	var assignedToUsernames []string
 
 	// Query to get task details
-	taskQuery := `SELECT t.id, t.title, t.description, t.detail, t.priority, t.status, p.id AS projectId, p.title as project, t.due_date, t.created_at, t.updated_at 
+	taskQuery := `SELECT t.id, t.title, t.description, COALESCE(t.detail, ''), t.priority, t.status, p.id AS projectId, p.title as project,
+					COALESCE(to_char(t.due_date, 'YYYY-MM-DD'), ''), ` + utcTimestamp("t.created_at") + `, ` + utcTimestamp("t.updated_at") + `,
+					t.owner_id, t.version,
+					COALESCE(t.estimate, 0), COALESCE(t.milestone_id::TEXT, '')
 				  FROM tasks t
 				  LEFT JOIN projects p ON t.project_id = p.id
-				  WHERE t.id = $1`
+				  WHERE t.id = $1 AND t.deleted_at IS NULL AND p.deleted_at IS NULL`
 	err := r.db.QueryRow(taskQuery, id).Scan(
 		&task.ID,
 		&task.Title,
@@ -102,6 +122,10 @@ func (r *TaskRepositoryPG) GetTaskById(id string) *entity.Task {
 		&task.DueDate,
 		&task.CreatedAt,
 		&task.UpdatedAt,
+		&task.OwnerId,
+		&task.Version,
+		&task.Estimate,
+		&task.MilestoneId,
 	)
 	task.ProjectId = projectId.String
 	task.Project = project.String
@@ -114,7 +138,7 @@ func (r *TaskRepositoryPG) GetTaskById(id string) *entity.Task {
 	}
 
 	// Query to get assigned usernames
-	assignmentsQuery := `SELECT u.username 
+	assignmentsQuery := `SELECT u.id, u.username 
 						 FROM users u
 						 JOIN task_assignments ta ON u.id = ta.user_id
 						 WHERE ta.task_id = $1`
@@ -125,10 +149,11 @@ func (r *TaskRepositoryPG) GetTaskById(id string) *entity.Task {
 	defer rows.Close()
 
 	for rows.Next() {
-		var username string
-		if err := rows.Scan(&username); err != nil {
+		var userId, username string
+		if err := rows.Scan(&userId, &username); err != nil {
 			panic(fmt.Errorf("task_repo_pg_error: scan username: %v", err))
 		}
+		task.AssignedToIds = append(task.AssignedToIds, userId)
 		assignedToUsernames = append(assignedToUsernames, username)
 	}
 
@@ -144,7 +169,7 @@ func (r *TaskRepositoryPG) GetTasksByProjects(projectId string) []entity.Preview
 				t.id, t.title, t.description, t.priority, t.status, p.title as project
 			FROM tasks t
 			INNER JOIN projects p ON p.id = t.project_id
-			WHERE t.project_id = $1`
+			WHERE t.project_id = $1 AND t.deleted_at IS NULL AND p.deleted_at IS NULL`
 	rows, err := r.db.Query(query, projectId)
 
 	if err != nil {
@@ -154,12 +179,32 @@ func (r *TaskRepositoryPG) GetTasksByProjects(projectId string) []entity.Preview
 	return services.GetTableDB[entity.PreviewTask](rows)
 }
 
-func (r *TaskRepositoryPG) UpdateTaskById(id string, payload *entity.TaskPayload) {
-	// Query to update task
-	query := `UPDATE tasks SET title = $1, description = $2, detail = $3, priority = $4, status = $5, project_id = $6, due_date = $7, updated_at = NOW() 
-			  WHERE id = $8`
+func (r *TaskRepositoryPG) UpdateTaskById(id string, payload *entity.TaskPayload, versions []int) {
+	r.updateTask(id, payload, versions, true)
+}
+
+func (r *TaskRepositoryPG) UpdateTaskFields(id string, payload *entity.TaskPayload, versions []int) {
+	r.updateTask(id, payload, versions, false)
+}
+
+// updateTask writes the fields of the task, its assignments are replaced only if asked to.
+func (r *TaskRepositoryPG) updateTask(id string, payload *entity.TaskPayload, versions []int, replaceAssignments bool) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
 
-	_, err := r.db.Exec(
+	// Empty project and due date are stored as NULL, not as an invalid UUID or date
+	query := `UPDATE tasks SET title = $1, description = $2, detail = $3, priority = $4, status = $5,
+				project_id = NULLIF($6, '')::UUID, due_date = NULLIF($7, '')::DATE, estimate = NULLIF($9, 0),
+				milestone_id = NULLIF($10, '')::UUID, updated_at = NOW() 
+			  WHERE id = $8 AND deleted_at IS NULL` + versionCondition(11)
+
+	result, err := tx.Exec(
 		query,
 		payload.Title,
 		payload.Description,
@@ -169,43 +214,167 @@ func (r *TaskRepositoryPG) UpdateTaskById(id string, payload *entity.TaskPayload
 		payload.ProjectId,
 		payload.DueDate,
 		id,
+		payload.Estimate,
+		payload.MilestoneId,
+		versionArg(versions),
 	)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			panic(fiber.NewError(fiber.StatusNotFound, "Task not found!"))
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "Project not found!"))
 		}
 		panic(fmt.Errorf("task_repo_pg_error: update task: %v", err))
 	}
 
-	// Delete existing task assignments
-	deleteQuery := `DELETE FROM task_assignments WHERE task_id = $1`
-	_, err = r.db.Exec(deleteQuery, id)
-	if err != nil {
-		panic(fmt.Errorf("task_repo_pg_error: delete task assignments: %v", err))
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panicMissedWrite(tx, "tasks", id, "Task not found!")
 	}
 
-	// Insert new task assignments
-	if len(payload.AssignedToId) > 0 {
+	if replaceAssignments {
+		// Delete existing task assignments
+		deleteQuery := `DELETE FROM task_assignments WHERE task_id = $1`
+		_, err = tx.Exec(deleteQuery, id)
+		if err != nil {
+			panic(fmt.Errorf("task_repo_pg_error: delete task assignments: %v", err))
+		}
+
+		// Insert new task assignments
 		for _, userId := range payload.AssignedToId {
-			assignmentQuery := `INSERT INTO task_assignments (task_id, user_id) VALUES ($1, $2)`
-			_, err := r.db.Exec(assignmentQuery, id, userId)
+			assignmentQuery := `INSERT INTO task_assignments (task_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+			_, err := tx.Exec(assignmentQuery, id, userId)
 			if err != nil {
 				panic(fmt.Errorf("task_repo_pg_error: add task assignments: %v", err))
 			}
 		}
 	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: commit transaction: %v", err))
+	}
 }
 
-func (r *TaskRepositoryPG) DeleteTaskById(id string) {
-	// Query to delete task
-	query := `DELETE FROM tasks WHERE id = $1`
-	_, err := r.db.Exec(query, id)
+func (r *TaskRepositoryPG) DeleteTaskById(id string, versions []int) {
+	// Assignments are kept, so restoring brings the task back as it was
+	query := `UPDATE tasks SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL` + versionCondition(2)
+	result, err := r.db.Exec(query, id, versionArg(versions))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			panic(fiber.NewError(fiber.StatusNotFound, "Task not found!"))
-		}
 		panic(fmt.Errorf("task_repo_pg_error: delete task: %v", err))
 	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panicMissedWrite(r.db, "tasks", id, "Task not found!")
+	}
+}
+
+func (r *TaskRepositoryPG) ApplyBulkOperation(ids []string, operation string, value string, userId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	// The tasks can't move and their projects can't be archived until the operation is applied
+	query := `SELECT id FROM tasks WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE`
+	if _, err = tx.Exec(query, pq.Array(ids)); err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: lock bulk tasks: %v", err))
+	}
+
+	// Along with the project the tasks move to
+	target := ""
+	if operation == entity.TaskBulkMoveToProject {
+		target = value
+	}
+	query = `
+		SELECT id FROM projects
+		WHERE id IN (SELECT project_id FROM tasks WHERE id = ANY($1)) OR id::TEXT = $2
+		ORDER BY id
+		FOR SHARE`
+	if _, err = tx.Exec(query, pq.Array(ids), target); err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: lock bulk projects: %v", err))
+	}
+
+	r.checkBulkTasks(tx, ids, operation, value, userId)
+
+	args := []interface{}{pq.Array(ids), value}
+	switch operation {
+	case entity.TaskBulkSetStatus:
+		query = `UPDATE tasks SET status = $2, updated_at = NOW() WHERE id = ANY($1) AND deleted_at IS NULL`
+	case entity.TaskBulkSetPriority:
+		query = `UPDATE tasks SET priority = $2, updated_at = NOW() WHERE id = ANY($1) AND deleted_at IS NULL`
+	case entity.TaskBulkAddAssignee:
+		query = `
+			INSERT INTO task_assignments(task_id, user_id)
+			SELECT id, $2 FROM tasks WHERE id = ANY($1) AND deleted_at IS NULL
+			ON CONFLICT (task_id, user_id) DO NOTHING`
+	case entity.TaskBulkRemoveAssignee:
+		query = `DELETE FROM task_assignments WHERE task_id = ANY($1) AND user_id = $2`
+	case entity.TaskBulkMoveToProject:
+		// Milestones belong to the previous project
+		query = `UPDATE tasks SET project_id = $2, milestone_id = NULL, updated_at = NOW() WHERE id = ANY($1) AND deleted_at IS NULL`
+	case entity.TaskBulkDelete:
+		query = `UPDATE tasks SET deleted_at = NOW() WHERE id = ANY($1) AND deleted_at IS NULL`
+		args = args[:1]
+	default:
+		panic(fmt.Errorf("task_repo_pg_error: unknown bulk operation %q", operation))
+	}
+
+	_, err = tx.Exec(query, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "User or project not found!"))
+		}
+		panic(fmt.Errorf("task_repo_pg_error: apply bulk operation: %v", err))
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+// checkBulkTasks checks the locked tasks again, the same way TaskUseCase checks each of them beforehand.
+// Should raise panic (409) if any of them changed since.
+func (r *TaskRepositoryPG) checkBulkTasks(tx *sql.Tx, ids []string, operation string, value string, userId string) {
+	query := `
+		SELECT COUNT(*)
+		FROM tasks t
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE t.id = ANY($1) AND t.deleted_at IS NULL
+		  AND (
+			(t.project_id IS NULL AND (
+				t.owner_id = $2
+				OR EXISTS (SELECT 1 FROM task_assignments ta WHERE ta.task_id = t.id AND ta.user_id = $2)
+			))
+			OR (p.archived_at IS NULL AND t.project_id IN (SELECT project_id FROM accessible_project_ids($2)))
+		  )`
+	args := []interface{}{pq.Array(ids), userId}
+
+	switch operation {
+	case entity.TaskBulkAddAssignee:
+		query += ` AND (t.project_id IS NULL OR t.project_id IN (SELECT project_id FROM accessible_project_ids($3)))`
+		args = append(args, value)
+	case entity.TaskBulkMoveToProject:
+		query += ` AND EXISTS (
+			SELECT 1 FROM projects target
+			WHERE target.id = $3 AND target.archived_at IS NULL
+			  AND target.id IN (SELECT project_id FROM accessible_project_ids($2))
+		)`
+		args = append(args, value)
+	}
+
+	var allowed int
+	if err := tx.QueryRow(query, args...).Scan(&allowed); err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: check bulk tasks: %v", err))
+	}
+
+	if allowed != len(ids) {
+		panic(fiber.NewError(fiber.StatusConflict, "Some tasks changed while being updated, nothing was applied, try again!"))
+	}
 }
 
 func (r *TaskRepositoryPG) GetTasksByOwner(ownerId string) []entity.PreviewTask {
@@ -215,7 +384,8 @@ func (r *TaskRepositoryPG) GetTasksByOwner(ownerId string) []entity.PreviewTask
 	query := `SELECT t.id, t.title, t.description, t.priority, t.status, p.title as project 
 			  FROM tasks t 
 			  LEFT JOIN projects p ON t.project_id = p.id 
-			  WHERE t.owner_id = $1`
+			  WHERE t.owner_id = $1 AND t.deleted_at IS NULL
+			    AND (t.project_id IS NULL OR t.project_id IN (SELECT project_id FROM accessible_project_ids($1)))`
 
 	rows, err := r.db.Query(query, ownerId)
 	if err != nil {
@@ -243,7 +413,8 @@ func (r *TaskRepositoryPG) GetTasksByAssignedUser(userId string) []entity.Previe
 			  FROM tasks t 
 			  JOIN task_assignments ta ON t.id = ta.task_id 
 			  JOIN projects p ON t.project_id = p.id 
-			  WHERE ta.user_id = $1`
+			  WHERE ta.user_id = $1 AND t.deleted_at IS NULL
+			    AND t.project_id IN (SELECT project_id FROM accessible_project_ids($1))`
 
 	rows, err := r.db.Query(query, userId)
 	if err != nil {
@@ -261,3 +432,202 @@ func (r *TaskRepositoryPG) GetTasksByAssignedUser(userId string) []entity.Previe
 
 	return tasks
 }
+
+func (r *TaskRepositoryPG) GetTasksByFilter(filter *entity.TaskFilter, userId string, today string) []entity.PreviewTask {
+	var tasks []entity.PreviewTask
+
+	// $1 is the user, the filter's arguments come after it
+	condition, args := compileTaskFilter(filter, userId, today, 1)
+
+	query := `SELECT t.id, t.title, t.description, t.priority, t.status, COALESCE(p.title, '') as project
+			  FROM tasks t
+			  LEFT JOIN projects p ON t.project_id = p.id
+			  WHERE (
+				t.project_id IN (SELECT project_id FROM accessible_project_ids($1))
+				OR (t.project_id IS NULL AND (
+					t.owner_id = $1
+					OR EXISTS (SELECT 1 FROM task_assignments a WHERE a.task_id = t.id AND a.user_id = $1)
+				))
+			  ) AND t.deleted_at IS NULL AND ` + condition + `
+			  ORDER BY t.due_date NULLS LAST, t.created_at
+			  LIMIT ` + fmt.Sprint(maxFilteredTasks)
+
+	rows, err := r.db.Query(query, append([]interface{}{userId}, args...)...)
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: get tasks by filter: %v", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task entity.PreviewTask
+		if err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Priority, &task.Status, &task.Project); err != nil {
+			panic(fmt.Errorf("task_repo_pg_error: scan task: %v", err))
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func (r *TaskRepositoryPG) GetDeletedTasksByUser(userId string) []entity.TrashedTask {
+	var tasks []entity.TrashedTask
+
+	// Tasks of a deleted project come back along with the project, so they aren't listed
+	query := `SELECT t.id, t.title, COALESCE(p.title, ''), ` + utcTimestamp("t.deleted_at") + `
+			  FROM tasks t
+			  LEFT JOIN projects p ON t.project_id = p.id
+			  WHERE t.deleted_at IS NOT NULL
+			    AND p.deleted_at IS NULL
+			    AND (t.owner_id = $1 OR p.owner_id = $1)
+			  ORDER BY t.deleted_at DESC`
+
+	rows, err := r.db.Query(query, userId)
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: get deleted tasks by user: %v", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task entity.TrashedTask
+		if err := rows.Scan(&task.Id, &task.Title, &task.Project, &task.DeletedAt); err != nil {
+			panic(fmt.Errorf("task_repo_pg_error: scan deleted task: %v", err))
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func (r *TaskRepositoryPG) RestoreTaskById(id string, userId string) {
+	query := `UPDATE tasks t
+			  SET deleted_at = NULL, updated_at = NOW()
+			  WHERE t.id = $1
+			    AND t.deleted_at IS NOT NULL
+			    AND (t.owner_id = $2 OR EXISTS (SELECT 1 FROM projects p WHERE p.id = t.project_id AND p.owner_id = $2))`
+
+	result, err := r.db.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: restore task: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Task not found in the trash!"))
+	}
+}
+
+func (r *TaskRepositoryPG) PurgeDeletedTasks(retention time.Duration) int64 {
+	query := `DELETE FROM tasks WHERE deleted_at < NOW() - make_interval(secs => $1)`
+
+	result, err := r.db.Exec(query, retention.Seconds())
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: purge deleted tasks: %v", err))
+	}
+
+	affected, _ := result.RowsAffected()
+
+	return affected
+}
+
+func (r *TaskRepositoryPG) ImportTasks(payloads []entity.TaskPayload, ownerId string) []string {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	taskQuery := `
+		INSERT INTO tasks (id, title, description, detail, priority, status, due_date, owner_id, estimate, project_id)
+		VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::DATE, $8, NULLIF($9, 0), NULLIF($10, '')::UUID)`
+	assignmentQuery := `INSERT INTO task_assignments (task_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+	ids := make([]string, 0, len(payloads))
+	for _, payload := range payloads {
+		// Create ID
+		id := r.idGenerator.Generate()
+
+		_, err = tx.Exec(
+			taskQuery,
+			id,
+			payload.Title,
+			payload.Description,
+			payload.Detail,
+			payload.Priority,
+			payload.Status,
+			payload.DueDate,
+			ownerId,
+			payload.Estimate,
+			payload.ProjectId,
+		)
+		if err != nil {
+			panic(fmt.Errorf("task_repo_pg_error: import task: %v", err))
+		}
+
+		for _, userId := range payload.AssignedToId {
+			if _, err = tx.Exec(assignmentQuery, id, userId); err != nil {
+				panic(fmt.Errorf("task_repo_pg_error: import task assignments: %v", err))
+			}
+		}
+
+		ids = append(ids, id)
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return ids
+}
+
+func (r *TaskRepositoryPG) ExportTasksByProject(projectId string, each func(task *entity.ExportedTask)) {
+	query := `
+		SELECT
+			t.id, t.title, COALESCE(t.description, ''), COALESCE(t.detail, ''), t.priority, t.status,
+			COALESCE(to_char(t.due_date, 'YYYY-MM-DD'), ''), COALESCE(t.estimate, 0), COALESCE(m.name, ''),
+			ARRAY(
+				SELECT u.username FROM task_assignments a INNER JOIN users u ON u.id = a.user_id
+				WHERE a.task_id = t.id ORDER BY u.username
+			),
+			` + utcTimestamp("t.created_at") + `, ` + utcTimestamp("t.updated_at") + `
+		FROM tasks t
+		LEFT JOIN milestones m ON m.id = t.milestone_id
+		WHERE t.project_id = $1 AND t.deleted_at IS NULL
+		ORDER BY t.created_at, t.id`
+
+	rows, err := r.db.Query(query, projectId)
+	if err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: export tasks: %v", err))
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var task entity.ExportedTask
+		err := rows.Scan(
+			&task.Id,
+			&task.Title,
+			&task.Description,
+			&task.Detail,
+			&task.Priority,
+			&task.Status,
+			&task.DueDate,
+			&task.Estimate,
+			&task.Milestone,
+			pq.Array(&task.Assignees),
+			&task.CreatedAt,
+			&task.UpdatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("task_repo_pg_error: scan exported task: %v", err))
+		}
+
+		each(&task)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("task_repo_pg_error: export tasks: %v", err))
+	}
+}
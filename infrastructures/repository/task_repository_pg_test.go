@@ -0,0 +1,133 @@
+package repository_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/commons"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/generator"
+	"github.com/wisle25/task-pixie/infrastructures/repository"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+	"github.com/wisle25/task-pixie/tests/db_helper"
+)
+
+func TestTaskRepositoryBulk(t *testing.T) {
+	// Arrange
+	config := commons.LoadConfig("../..")
+	db := services.ConnectDB(config)
+	projectHelperDb := &db_helper.ProjectHelperDB{
+		DB: db,
+	}
+	defer projectHelperDb.CleanProjectDB()
+
+	taskRepositoryPG := repository.NewTaskRepositoryPG(generator.NewUUIDGenerator(), db)
+
+	organizationId := projectHelperDb.AddOrganizationDB("Acme")
+	userId := projectHelperDb.AddUserDB("pixie")
+	assigneeId := projectHelperDb.AddUserDB("assignee")
+	strangerId := projectHelperDb.AddUserDB("stranger")
+	projectHelperDb.AddOrganizationMemberDB(organizationId, userId, "member")
+	projectHelperDb.AddOrganizationMemberDB(organizationId, assigneeId, "member")
+	projectHelperDb.AddOrganizationMemberDB(organizationId, strangerId, "member")
+
+	projectId := projectHelperDb.AddProjectDB(organizationId, userId, "Website")
+	projectHelperDb.AddProjectMemberDB(projectId, assigneeId, "member")
+	targetId := projectHelperDb.AddProjectDB(organizationId, userId, "Mobile")
+	archivedId := projectHelperDb.AddProjectDB(organizationId, userId, "Legacy")
+	projectHelperDb.SetProjectDB(archivedId, "archived_at", "2024-01-01")
+
+	designId := projectHelperDb.AddTaskDB(projectId, userId, "Design", "To Do", "")
+	buildId := projectHelperDb.AddTaskDB(projectId, userId, "Build", "To Do", "")
+	personalId := projectHelperDb.AddTaskDB("", userId, "Groceries", "To Do", "")
+	legacyId := projectHelperDb.AddTaskDB(archivedId, userId, "Migrate", "To Do", "")
+	trashedId := projectHelperDb.AddTaskDB(projectId, userId, "Trashed", "To Do", "")
+	projectHelperDb.SetTaskDB(trashedId, "deleted_at", "2024-01-01")
+	milestoneId := projectHelperDb.AddMilestoneDB(projectId, "Sprint 1", "active")
+	projectHelperDb.SetTaskDB(buildId, "milestone_id", milestoneId)
+
+	conflict := "Some tasks changed while being updated, nothing was applied, try again!"
+
+	t.Run("ApplyBulkOperation", func(t *testing.T) {
+		t.Run("Should set the status and priority of every task", func(t *testing.T) {
+			// Action
+			taskRepositoryPG.ApplyBulkOperation([]string{designId, buildId, personalId}, entity.TaskBulkSetStatus, "In Progress", userId)
+			taskRepositoryPG.ApplyBulkOperation([]string{designId, buildId}, entity.TaskBulkSetPriority, "Urgent", userId)
+
+			// Assert
+			for _, id := range []string{designId, buildId, personalId} {
+				assert.Equal(t, "In Progress", projectHelperDb.GetTaskDB(id).Status)
+			}
+			assert.Equal(t, "Urgent", projectHelperDb.GetTaskDB(designId).Priority)
+			assert.Equal(t, "Low", projectHelperDb.GetTaskDB(personalId).Priority)
+		})
+
+		t.Run("Should add and remove an assignee", func(t *testing.T) {
+			// Action
+			taskRepositoryPG.ApplyBulkOperation([]string{designId, buildId}, entity.TaskBulkAddAssignee, assigneeId, userId)
+
+			// Assert
+			assert.Equal(t, []string{assigneeId}, projectHelperDb.GetTaskDB(designId).AssigneeIds)
+			assert.Equal(t, []string{assigneeId}, projectHelperDb.GetTaskDB(buildId).AssigneeIds)
+
+			// Action
+			taskRepositoryPG.ApplyBulkOperation([]string{designId}, entity.TaskBulkRemoveAssignee, assigneeId, userId)
+
+			// Assert
+			assert.Empty(t, projectHelperDb.GetTaskDB(designId).AssigneeIds)
+			assert.Equal(t, []string{assigneeId}, projectHelperDb.GetTaskDB(buildId).AssigneeIds)
+		})
+
+		t.Run("Should raise panic and apply nothing if any task doesn't pass anymore", func(t *testing.T) {
+			cases := []struct {
+				name      string
+				ids       []string
+				operation string
+				value     string
+				userId    string
+			}{
+				{"archived project", []string{designId, legacyId}, entity.TaskBulkSetStatus, "Completed", userId},
+				{"deleted task", []string{designId, trashedId}, entity.TaskBulkSetStatus, "Completed", userId},
+				{"no access", []string{designId}, entity.TaskBulkSetStatus, "Completed", strangerId},
+				{"assignee without access", []string{designId, personalId}, entity.TaskBulkAddAssignee, strangerId, userId},
+				{"archived target", []string{designId}, entity.TaskBulkMoveToProject, archivedId, userId},
+				{"target without access", []string{designId}, entity.TaskBulkMoveToProject, targetId, assigneeId},
+			}
+
+			for _, c := range cases {
+				assert.PanicsWithError(t, conflict, func() {
+					taskRepositoryPG.ApplyBulkOperation(c.ids, c.operation, c.value, c.userId)
+				}, c.name)
+			}
+
+			design := projectHelperDb.GetTaskDB(designId)
+			assert.Equal(t, "In Progress", design.Status)
+			assert.Equal(t, projectId, design.ProjectId)
+			assert.Empty(t, design.AssigneeIds)
+			assert.Equal(t, "To Do", projectHelperDb.GetTaskDB(legacyId).Status)
+			assert.Empty(t, projectHelperDb.GetTaskDB(personalId).AssigneeIds)
+		})
+
+		t.Run("Should move the tasks out of their milestone", func(t *testing.T) {
+			// Action
+			taskRepositoryPG.ApplyBulkOperation([]string{designId, buildId}, entity.TaskBulkMoveToProject, targetId, userId)
+
+			// Assert
+			for _, id := range []string{designId, buildId} {
+				task := projectHelperDb.GetTaskDB(id)
+				assert.Equal(t, targetId, task.ProjectId)
+				assert.Empty(t, task.MilestoneId)
+			}
+		})
+
+		t.Run("Should move the tasks to the trash", func(t *testing.T) {
+			// Action
+			taskRepositoryPG.ApplyBulkOperation([]string{designId, personalId}, entity.TaskBulkDelete, "", userId)
+
+			// Assert
+			assert.True(t, projectHelperDb.GetTaskDB(designId).Deleted)
+			assert.True(t, projectHelperDb.GetTaskDB(personalId).Deleted)
+			assert.False(t, projectHelperDb.GetTaskDB(buildId).Deleted)
+		})
+	})
+}
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+)
+
+type TaskViewRepositoryPG struct /* implements TaskViewRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewTaskViewRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.TaskViewRepository {
+	return &TaskViewRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *TaskViewRepositoryPG) AddView(payload *entity.TaskViewPayload, ownerId string) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	// Query
+	query := `INSERT INTO
+				task_views(id, name, query, owner_id, project_id)
+			  VALUES
+				($1, $2, $3, $4, NULLIF($5, '')::UUID)
+			  RETURNING id`
+
+	var returnedId string
+	err := r.db.QueryRow(query, id, payload.Name, payload.Query, ownerId, payload.ProjectId).Scan(&returnedId)
+	if err != nil {
+		panic(fmt.Errorf("task_view_repo_pg_error: add view: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *TaskViewRepositoryPG) GetViewById(id string) *entity.TaskView {
+	var view entity.TaskView
+
+	query := `
+		SELECT id, name, query, owner_id, COALESCE(project_id::TEXT, ''), ` + utcTimestamp("created_at") + `, ` + utcTimestamp("updated_at") + `
+		FROM task_views
+		WHERE id = $1`
+	err := r.db.QueryRow(query, id).Scan(
+		&view.Id,
+		&view.Name,
+		&view.Query,
+		&view.OwnerId,
+		&view.ProjectId,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "View not found!"))
+		}
+		panic(fmt.Errorf("task_view_repo_pg_error: get view by id: %v", err))
+	}
+
+	return &view
+}
+
+func (r *TaskViewRepositoryPG) GetViewsByUser(userId string) []entity.TaskView {
+	query := `
+		SELECT v.id, v.name, v.query, v.owner_id, COALESCE(v.project_id::TEXT, ''),
+			` + utcTimestamp("v.created_at") + `, ` + utcTimestamp("v.updated_at") + `
+		FROM task_views v
+		WHERE (v.owner_id = $1 AND v.project_id IS NULL)
+		   OR v.project_id IN (SELECT project_id FROM accessible_project_ids($1))
+		ORDER BY v.name`
+
+	rows, err := r.db.Query(query, userId)
+	if err != nil {
+		panic(fmt.Errorf("task_view_repo_pg_error: get views by user: %v", err))
+	}
+	defer rows.Close()
+
+	var views []entity.TaskView
+	for rows.Next() {
+		var view entity.TaskView
+		err := rows.Scan(
+			&view.Id,
+			&view.Name,
+			&view.Query,
+			&view.OwnerId,
+			&view.ProjectId,
+			&view.CreatedAt,
+			&view.UpdatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("task_view_repo_pg_error: scan view: %v", err))
+		}
+		views = append(views, view)
+	}
+
+	return views
+}
+
+func (r *TaskViewRepositoryPG) UpdateViewById(id string, payload *entity.TaskViewPayload) {
+	query := `
+		UPDATE task_views
+		SET name = $1, query = $2, project_id = NULLIF($3, '')::UUID, updated_at = NOW()
+		WHERE id = $4`
+
+	result, err := r.db.Exec(query, payload.Name, payload.Query, payload.ProjectId, id)
+	if err != nil {
+		panic(fmt.Errorf("task_view_repo_pg_error: update view: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "View not found!"))
+	}
+}
+
+func (r *TaskViewRepositoryPG) DeleteViewById(id string) {
+	query := `DELETE FROM task_views WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("task_view_repo_pg_error: delete view: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "View not found!"))
+	}
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+)
+
+type TeamRepositoryPG struct /* implements TeamRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewTeamRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.TeamRepository {
+	return &TeamRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *TeamRepositoryPG) AddTeam(organizationId string, payload *entity.TeamPayload) string {
+	id := r.idGenerator.Generate()
+	query := `INSERT INTO teams(id, organization_id, name) VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(query, id, organizationId, payload.Name)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "Team name is already taken in the organization!"))
+		}
+		panic(fmt.Errorf("team_repo_pg_error: add team: %v", err))
+	}
+
+	return id
+}
+
+func (r *TeamRepositoryPG) GetTeamById(id string) *entity.Team {
+	teams := r.queryTeams(`
+		SELECT t.id, t.organization_id, t.name, COUNT(tm.user_id), `+utcTimestamp("t.created_at")+`, `+utcTimestamp("t.updated_at")+`
+		FROM teams t
+		LEFT JOIN team_members tm ON tm.team_id = t.id
+		WHERE t.id = $1
+		GROUP BY t.id`, id)
+
+	if len(teams) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Team not found!"))
+	}
+
+	return &teams[0]
+}
+
+func (r *TeamRepositoryPG) GetTeamsByOrganization(organizationId string) []entity.Team {
+	return r.queryTeams(`
+		SELECT t.id, t.organization_id, t.name, COUNT(tm.user_id), `+utcTimestamp("t.created_at")+`, `+utcTimestamp("t.updated_at")+`
+		FROM teams t
+		LEFT JOIN team_members tm ON tm.team_id = t.id
+		WHERE t.organization_id = $1
+		GROUP BY t.id
+		ORDER BY t.name`, organizationId)
+}
+
+func (r *TeamRepositoryPG) UpdateTeamById(id string, payload *entity.TeamPayload) {
+	query := `UPDATE teams SET name = $2, updated_at = NOW() WHERE id = $1`
+
+	result, err := r.db.Exec(query, id, payload.Name)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "Team name is already taken in the organization!"))
+		}
+		panic(fmt.Errorf("team_repo_pg_error: update team: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Team not found!"))
+	}
+}
+
+func (r *TeamRepositoryPG) DeleteTeamById(id string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	projectIds, userIds := teamGrants(tx, id)
+
+	// Members and project grants are removed by the cascade
+	query := `DELETE FROM teams WHERE id = $1`
+	result, err := tx.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: delete team: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Team not found!"))
+	}
+
+	releaseLostTasks(tx, projectIds, userIds)
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *TeamRepositoryPG) GetTeamMembers(id string) []entity.TeamMember {
+	query := `
+		SELECT u.id, u.username
+		FROM team_members tm
+		INNER JOIN users u ON u.id = tm.user_id
+		WHERE tm.team_id = $1
+		ORDER BY u.username`
+
+	rows, err := r.db.Query(query, id)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: get team members: %v", err))
+	}
+	defer rows.Close()
+
+	var members []entity.TeamMember
+	for rows.Next() {
+		var member entity.TeamMember
+		if err := rows.Scan(&member.Id, &member.Username); err != nil {
+			panic(fmt.Errorf("team_repo_pg_error: scan team member: %v", err))
+		}
+		members = append(members, member)
+	}
+
+	return members
+}
+
+func (r *TeamRepositoryPG) AddTeamMember(id string, userId string) {
+	query := `INSERT INTO team_members(team_id, user_id) VALUES ($1, $2)`
+
+	_, err := r.db.Exec(query, id, userId)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "User is already a member of the team!"))
+		}
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+		panic(fmt.Errorf("team_repo_pg_error: add team member: %v", err))
+	}
+}
+
+func (r *TeamRepositoryPG) RemoveTeamMember(id string, userId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	query := `DELETE FROM team_members WHERE team_id = $1 AND user_id = $2`
+	result, err := tx.Exec(query, id, userId)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: remove team member: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User is not a member of the team!"))
+	}
+
+	projectIds, _ := teamGrants(tx, id)
+	releaseLostTasks(tx, projectIds, []string{userId})
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+func (r *TeamRepositoryPG) GetProjectTeams(projectId string) []entity.ProjectTeam {
+	query := `
+		SELECT t.id, t.name, pt.role
+		FROM project_teams pt
+		INNER JOIN teams t ON t.id = pt.team_id
+		WHERE pt.project_id = $1
+		ORDER BY t.name`
+
+	rows, err := r.db.Query(query, projectId)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: get project teams: %v", err))
+	}
+	defer rows.Close()
+
+	var teams []entity.ProjectTeam
+	for rows.Next() {
+		var team entity.ProjectTeam
+		if err := rows.Scan(&team.Id, &team.Name, &team.Role); err != nil {
+			panic(fmt.Errorf("team_repo_pg_error: scan project team: %v", err))
+		}
+		teams = append(teams, team)
+	}
+
+	return teams
+}
+
+func (r *TeamRepositoryPG) AddProjectTeam(projectId string, teamId string, role string) {
+	query := `INSERT INTO project_teams(project_id, team_id, role) VALUES ($1, $2, $3)`
+
+	_, err := r.db.Exec(query, projectId, teamId, role)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "Team is already granted on the project!"))
+		}
+		panic(fmt.Errorf("team_repo_pg_error: add project team: %v", err))
+	}
+}
+
+func (r *TeamRepositoryPG) UpdateProjectTeamRole(projectId string, teamId string, role string) {
+	query := `UPDATE project_teams SET role = $3 WHERE project_id = $1 AND team_id = $2`
+
+	result, err := r.db.Exec(query, projectId, teamId, role)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: update project team role: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Team is not granted on the project!"))
+	}
+}
+
+func (r *TeamRepositoryPG) RemoveProjectTeam(projectId string, teamId string) {
+	// Start transaction
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: begin transaction: %v", err))
+	}
+
+	// Defer a rollback in case anything fails
+	defer tx.Rollback()
+
+	query := `DELETE FROM project_teams WHERE project_id = $1 AND team_id = $2`
+	result, err := tx.Exec(query, projectId, teamId)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: remove project team: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Team is not granted on the project!"))
+	}
+
+	_, userIds := teamGrants(tx, teamId)
+	releaseLostTasks(tx, []string{projectId}, userIds)
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: commit transaction: %v", err))
+	}
+}
+
+// teamGrants returns the projects the team is granted on and its members.
+func teamGrants(tx *sql.Tx, teamId string) ([]string, []string) {
+	var projectIds, userIds []string
+
+	query := `
+		SELECT
+			ARRAY(SELECT project_id::TEXT FROM project_teams WHERE team_id = $1),
+			ARRAY(SELECT user_id::TEXT FROM team_members WHERE team_id = $1)`
+	err := tx.QueryRow(query, teamId).Scan(pq.Array(&projectIds), pq.Array(&userIds))
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: get team grants: %v", err))
+	}
+
+	return projectIds, userIds
+}
+
+// releaseLostTasks unassigns the users from the tasks of the projects they can't access anymore,
+// and hands the tasks they own over to the project owner, like removing a member does.
+// Users still reaching the project otherwise, as a member, through another team or the organization, keep their tasks.
+func releaseLostTasks(tx *sql.Tx, projectIds []string, userIds []string) {
+	if len(projectIds) == 0 || len(userIds) == 0 {
+		return
+	}
+
+	query := `
+		DELETE FROM task_assignments ta
+		USING tasks t
+		WHERE t.id = ta.task_id AND t.project_id = ANY($1::UUID[]) AND ta.user_id = ANY($2::UUID[])
+		  AND NOT EXISTS (SELECT 1 FROM accessible_project_ids(ta.user_id) a WHERE a.project_id = t.project_id)`
+	_, err := tx.Exec(query, pq.Array(projectIds), pq.Array(userIds))
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: remove member assignments: %v", err))
+	}
+
+	query = `
+		UPDATE tasks t
+		SET owner_id = p.owner_id, updated_at = NOW()
+		FROM projects p
+		WHERE p.id = t.project_id AND t.project_id = ANY($1::UUID[]) AND t.owner_id = ANY($2::UUID[])
+		  AND NOT EXISTS (SELECT 1 FROM accessible_project_ids(t.owner_id) a WHERE a.project_id = t.project_id)`
+	_, err = tx.Exec(query, pq.Array(projectIds), pq.Array(userIds))
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: hand over member tasks: %v", err))
+	}
+}
+
+func (r *TeamRepositoryPG) queryTeams(query string, args ...interface{}) []entity.Team {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("team_repo_pg_error: query teams: %v", err))
+	}
+	defer rows.Close()
+
+	var teams []entity.Team
+	for rows.Next() {
+		var team entity.Team
+		err := rows.Scan(
+			&team.Id,
+			&team.OrganizationId,
+			&team.Name,
+			&team.MembersCount,
+			&team.CreatedAt,
+			&team.UpdatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("team_repo_pg_error: scan team: %v", err))
+		}
+		teams = append(teams, team)
+	}
+
+	return teams
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"strings"
+)
+
+// Columns scanned by queryTimeEntries, times are RFC 3339 in UTC
+const timeEntryColumns = `
+	te.id, te.task_id, t.title, COALESCE(t.project_id::TEXT, ''), te.user_id, u.username, te.note,
+	to_char(te.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'),
+	COALESCE(to_char(te.ended_at AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"'), ''),
+	(EXTRACT(EPOCH FROM COALESCE(te.ended_at, NOW()) - te.started_at)::BIGINT / 60),
+	te.ended_at IS NULL`
+
+type TimeEntryRepositoryPG struct /* implements TimeEntryRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewTimeEntryRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.TimeEntryRepository {
+	return &TimeEntryRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *TimeEntryRepositoryPG) StartTimer(taskId string, userId string, payload *entity.TimerPayload) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	query := `
+		INSERT INTO time_entries(id, task_id, user_id, note, started_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id`
+
+	var returnedId string
+	err := r.db.QueryRow(query, id, taskId, userId, payload.Note).Scan(&returnedId)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "You already have a running timer, stop it first!"))
+		}
+		panic(fmt.Errorf("time_entry_repo_pg_error: start timer: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *TimeEntryRepositoryPG) StopTimer(userId string) string {
+	// Timers stopped within the same second still last a moment
+	query := `
+		UPDATE time_entries
+		SET ended_at = GREATEST(NOW(), started_at + INTERVAL '1 second')
+		WHERE user_id = $1 AND ended_at IS NULL
+		RETURNING id`
+
+	var id string
+	err := r.db.QueryRow(query, userId).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "You have no running timer!"))
+		}
+		panic(fmt.Errorf("time_entry_repo_pg_error: stop timer: %v", err))
+	}
+
+	return id
+}
+
+func (r *TimeEntryRepositoryPG) GetRunningTimer(userId string) *entity.TimeEntry {
+	query := `
+		SELECT` + timeEntryColumns + `
+		FROM time_entries te
+		INNER JOIN tasks t ON t.id = te.task_id
+		INNER JOIN users u ON u.id = te.user_id
+		WHERE te.user_id = $1 AND te.ended_at IS NULL`
+
+	entries := r.queryTimeEntries(query, userId)
+	if len(entries) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "You have no running timer!"))
+	}
+
+	return &entries[0]
+}
+
+func (r *TimeEntryRepositoryPG) AddTimeEntry(taskId string, userId string, payload *entity.TimeEntryPayload) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	query := `
+		INSERT INTO time_entries(id, task_id, user_id, note, started_at, ended_at)
+		VALUES ($1, $2, $3, $4, $5::TIMESTAMPTZ, $6::TIMESTAMPTZ)
+		RETURNING id`
+
+	var returnedId string
+	err := r.db.QueryRow(query, id, taskId, userId, payload.Note, payload.StartedAt, payload.EndedAt).Scan(&returnedId)
+	if err != nil {
+		if strings.Contains(err.Error(), "foreign key constraint") {
+			panic(fiber.NewError(fiber.StatusNotFound, "Task not found!"))
+		}
+		if strings.Contains(err.Error(), "check constraint") {
+			panic(fiber.NewError(fiber.StatusBadRequest, "Time entry must end after it starts!"))
+		}
+		panic(fmt.Errorf("time_entry_repo_pg_error: add time entry: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *TimeEntryRepositoryPG) GetTimeEntryById(id string) *entity.TimeEntry {
+	query := `
+		SELECT` + timeEntryColumns + `
+		FROM time_entries te
+		INNER JOIN tasks t ON t.id = te.task_id
+		INNER JOIN users u ON u.id = te.user_id
+		WHERE te.id = $1 AND t.deleted_at IS NULL`
+
+	entries := r.queryTimeEntries(query, id)
+	if len(entries) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Time entry not found!"))
+	}
+
+	return &entries[0]
+}
+
+func (r *TimeEntryRepositoryPG) GetTimeEntriesByTask(taskId string) []entity.TimeEntry {
+	query := `
+		SELECT` + timeEntryColumns + `
+		FROM time_entries te
+		INNER JOIN tasks t ON t.id = te.task_id
+		INNER JOIN users u ON u.id = te.user_id
+		WHERE te.task_id = $1
+		ORDER BY te.started_at DESC`
+
+	return r.queryTimeEntries(query, taskId)
+}
+
+func (r *TimeEntryRepositoryPG) GetTimeSpentByTask(taskId string) int {
+	query := `
+		SELECT COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)), 0)::BIGINT / 60
+		FROM time_entries
+		WHERE task_id = $1`
+
+	var minutes int
+	if err := r.db.QueryRow(query, taskId).Scan(&minutes); err != nil {
+		panic(fmt.Errorf("time_entry_repo_pg_error: get time spent: %v", err))
+	}
+
+	return minutes
+}
+
+func (r *TimeEntryRepositoryPG) DeleteTimeEntryById(id string) {
+	query := `DELETE FROM time_entries WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("time_entry_repo_pg_error: delete time entry: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Time entry not found!"))
+	}
+}
+
+func (r *TimeEntryRepositoryPG) GetTimeReport(viewerId string, filter *entity.TimeReportFilter) []entity.TimeReportRow {
+	// Days are UTC, so the report doesn't depend on the time zone of the database
+	query := `
+		SELECT
+			to_char(te.started_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day,
+			COALESCE(p.id::TEXT, ''),
+			COALESCE(p.title, ''),
+			u.id,
+			u.username,
+			(SUM(EXTRACT(EPOCH FROM COALESCE(te.ended_at, NOW()) - te.started_at))::BIGINT / 60)
+		FROM time_entries te
+		INNER JOIN tasks t ON t.id = te.task_id
+		INNER JOIN users u ON u.id = te.user_id
+		LEFT JOIN projects p ON p.id = t.project_id
+		WHERE t.deleted_at IS NULL
+		  AND (te.user_id = $1 OR t.project_id IN (SELECT project_id FROM accessible_project_ids($1)))
+		  AND ($2 = '' OR t.project_id::TEXT = $2)
+		  AND ($3 = '' OR te.user_id::TEXT = $3)
+		  AND ($4 = '' OR (te.started_at AT TIME ZONE 'UTC')::DATE >= $4::DATE)
+		  AND ($5 = '' OR (te.started_at AT TIME ZONE 'UTC')::DATE <= $5::DATE)
+		GROUP BY day, p.id, p.title, u.id, u.username
+		ORDER BY day, p.title, u.username`
+
+	rows, err := r.db.Query(query, viewerId, filter.ProjectId, filter.UserId, filter.From, filter.To)
+	if err != nil {
+		panic(fmt.Errorf("time_entry_repo_pg_error: get time report: %v", err))
+	}
+	defer rows.Close()
+
+	var report []entity.TimeReportRow
+	for rows.Next() {
+		var row entity.TimeReportRow
+		err := rows.Scan(&row.Date, &row.ProjectId, &row.Project, &row.UserId, &row.Username, &row.Minutes)
+		if err != nil {
+			panic(fmt.Errorf("time_entry_repo_pg_error: scan time report: %v", err))
+		}
+		report = append(report, row)
+	}
+
+	return report
+}
+
+func (r *TimeEntryRepositoryPG) queryTimeEntries(query string, args ...interface{}) []entity.TimeEntry {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("time_entry_repo_pg_error: query time entries: %v", err))
+	}
+	defer rows.Close()
+
+	var entries []entity.TimeEntry
+	for rows.Next() {
+		var entry entity.TimeEntry
+		err := rows.Scan(
+			&entry.Id,
+			&entry.TaskId,
+			&entry.Task,
+			&entry.ProjectId,
+			&entry.UserId,
+			&entry.Username,
+			&entry.Note,
+			&entry.StartedAt,
+			&entry.EndedAt,
+			&entry.Minutes,
+			&entry.Running,
+		)
+		if err != nil {
+			panic(fmt.Errorf("time_entry_repo_pg_error: scan time entry: %v", err))
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
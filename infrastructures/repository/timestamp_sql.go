@@ -0,0 +1,11 @@
+package repository
+
+// utcTimestamp formats the TIMESTAMPTZ column as RFC 3339 in UTC, the format of every time of the API.
+func utcTimestamp(column string) string {
+	return `to_char(` + column + ` AT TIME ZONE 'UTC', 'YYYY-MM-DD"T"HH24:MI:SS"Z"')`
+}
+
+// nullableUTCTimestamp formats the column like utcTimestamp, empty when it's NULL.
+func nullableUTCTimestamp(column string) string {
+	return `COALESCE(` + utcTimestamp(column) + `, '')`
+}
@@ -9,9 +9,16 @@ import (
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/domains/repository"
 	"log"
+	"slices"
 	"strings"
+	"time"
 )
 
+// Columns scanned by queryUser
+var userColumns = `
+	id, username, email, avatar_link, display_name, bio, time_zone, locale, date_format,
+	email_invitations, email_digests, ` + nullableUTCTimestamp("deletion_scheduled_at")
+
 type UserRepositoryPG struct /* implements UserRepository */ {
 	db          *sql.DB
 	idGenerator generator.IdGenerator
@@ -59,6 +66,7 @@ func (r *UserRepositoryPG) AddUser(payload *entity.RegisterUserPayload) string {
 func (r *UserRepositoryPG) GetUserForLogin(identity string) (*entity.User, string) {
 	var userToken entity.User
 	var encryptedPassword string
+	var disabled, resetRequired bool
 
 	// Query
 	query := `
@@ -67,15 +75,19 @@ func (r *UserRepositoryPG) GetUserForLogin(identity string) (*entity.User, strin
 		    username,
 		    email,
 		    avatar_link,
-		    password 
+		    password,
+		    disabled_at IS NOT NULL,
+		    password_reset_token_hash IS NOT NULL
 		FROM users 
-		WHERE email = $1 OR username = $1`
+		WHERE (email = $1 OR username = $1) AND deleted_at IS NULL`
 	err := r.db.QueryRow(query, identity).Scan(
 		&userToken.Id,
 		&userToken.Username,
 		&userToken.Email,
 		&userToken.AvatarLink,
 		&encryptedPassword,
+		&disabled,
+		&resetRequired,
 	)
 
 	// Evaluate
@@ -87,73 +99,191 @@ func (r *UserRepositoryPG) GetUserForLogin(identity string) (*entity.User, strin
 		}
 	}
 
+	if disabled {
+		panic(fiber.NewError(fiber.StatusForbidden, "Your account is disabled!"))
+	}
+	if resetRequired {
+		panic(fiber.NewError(fiber.StatusForbidden, "You must reset your password, follow the link sent to your email!"))
+	}
+
 	return &userToken, encryptedPassword
 }
 
 func (r *UserRepositoryPG) GetUserById(id string) *entity.User {
-	var result entity.User
+	query := `SELECT` + userColumns + ` FROM users WHERE id = $1`
 
-	// Query
-	query := `SELECT id, username, email, avatar_link FROM users WHERE id = $1`
-	err := r.db.QueryRow(query, id).Scan(
+	user := r.queryUser(query, id)
+	if user == nil {
+		panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+	}
+
+	return user
+}
+
+func (r *UserRepositoryPG) FindUserByIdentity(identity string) *entity.User {
+	query := `SELECT` + userColumns + ` FROM users WHERE (LOWER(email) = LOWER($1) OR username = $1) AND deleted_at IS NULL`
+
+	return r.queryUser(query, identity)
+}
+
+// queryUser scans the userColumns of the single user of the query, nil when there's none.
+func (r *UserRepositoryPG) queryUser(query string, args ...interface{}) *entity.User {
+	result := entity.User{Notifications: &entity.NotificationPreferences{}}
+
+	err := r.db.QueryRow(query, args...).Scan(
 		&result.Id,
 		&result.Username,
 		&result.Email,
 		&result.AvatarLink,
+		&result.DisplayName,
+		&result.Bio,
+		&result.TimeZone,
+		&result.Locale,
+		&result.DateFormat,
+		&result.Notifications.EmailInvitations,
+		&result.Notifications.EmailDigests,
+		&result.DeletionScheduledAt,
 	)
 
 	// Evaluate
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+			return nil
 		}
 
-		panic(fmt.Errorf("user_repo_pg_error: get userId by id %v", err))
+		panic(fmt.Errorf("user_repo_pg_error: get user: %v", err))
 	}
 
 	return &result
 }
 
-func (r *UserRepositoryPG) UpdateUserById(id string, payload *entity.UpdateUserPayload, newAvatarLink string) string {
+func (r *UserRepositoryPG) UpdateUserById(id string, payload *entity.UpdateUserPayload, avatars map[int]string) []string {
+	tx, err := r.db.Begin()
+	if err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: begin transaction: %v", err))
+	}
+	defer tx.Rollback()
+
+	// Locking the user, concurrent updates don't mix their avatars
+	oldFileNames := r.lockAvatarFileNames(tx, id)
+
 	// Base query and arguments (Only updating the password if it's not empty)
 	query := `
-		WITH old_data AS (
-			SELECT avatar_link
-			FROM users
-			WHERE id = $1
-		)
 		UPDATE users 
-		SET username = $2, email = $3, avatar_link = $4`
+		SET username = $2, email = $3, avatar_link = $4,
+			display_name = $5, bio = $6, time_zone = $7, locale = $8, date_format = $9,
+			email_invitations = COALESCE($10, email_invitations),
+			email_digests = COALESCE($11, email_digests)`
 
-	args := []interface{}{id, payload.Username, payload.Email, newAvatarLink}
+	args := []interface{}{
+		id,
+		payload.Username,
+		payload.Email,
+		avatars[entity.AvatarSizes[len(entity.AvatarSizes)-1]],
+		payload.DisplayName,
+		payload.Bio,
+		payload.TimeZone,
+		payload.Locale,
+		payload.DateFormat,
+		payload.EmailInvitations,
+		payload.EmailDigests,
+	}
 
 	// Conditionally add password update
 	if payload.Password != "" {
-		query += `, password = $5`
+		query += `, password = $12`
 		args = append(args, payload.Password)
 	}
 
 	query += `
-		FROM old_data
-		WHERE users.id = $1
-		RETURNING old_data.avatar_link`
+		WHERE id = $1`
 
 	// Execute the query
-	var oldAvatarLink string
-	err := r.db.QueryRow(query, args...).Scan(&oldAvatarLink)
+	_, err = tx.Exec(query, args...)
+	if err != nil {
+		if strings.Contains(err.Error(), "unique constraint") {
+			panic(fiber.NewError(fiber.StatusConflict, "Username or Email already exists!"))
+		}
+		panic(fmt.Errorf("user_repo_pg_error: update user: %v", err))
+	}
 
-	// Evaluate
+	// The variants are replaced all at once
+	if _, err = tx.Exec(`DELETE FROM user_avatars WHERE user_id = $1`, id); err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: delete avatars: %v", err))
+	}
+	for size, fileName := range avatars {
+		_, err = tx.Exec(`INSERT INTO user_avatars(user_id, size, file_name) VALUES ($1, $2, $3)`, id, size, fileName)
+		if err != nil {
+			panic(fmt.Errorf("user_repo_pg_error: add avatar: %v", err))
+		}
+	}
+
+	// Commit transaction
+	err = tx.Commit()
+	if err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: commit transaction: %v", err))
+	}
+
+	return oldFileNames
+}
+
+// lockAvatarFileNames locks the user until the end of the transaction.
+// Returning the files of their avatar, the legacy single avatar included.
+func (r *UserRepositoryPG) lockAvatarFileNames(tx *sql.Tx, id string) []string {
+	var avatarLink string
+	err := tx.QueryRow(`SELECT COALESCE(avatar_link, '') FROM users WHERE id = $1 FOR UPDATE`, id).Scan(&avatarLink)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
 		}
-		if strings.Contains(err.Error(), "unique constraint") {
-			panic(fiber.NewError(fiber.StatusConflict, "Username or Email already exists!"))
+		panic(fmt.Errorf("user_repo_pg_error: lock user: %v", err))
+	}
+
+	rows, err := tx.Query(`SELECT file_name FROM user_avatars WHERE user_id = $1`, id)
+	if err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: get avatars: %v", err))
+	}
+	defer rows.Close()
+
+	fileNames := make([]string, 0)
+	for rows.Next() {
+		var fileName string
+		if err := rows.Scan(&fileName); err != nil {
+			panic(fmt.Errorf("user_repo_pg_error: scan avatar: %v", err))
 		}
-		panic(fmt.Errorf("user_repo_pg_error: update user: %v", err))
+		fileNames = append(fileNames, fileName)
+	}
+
+	if err := rows.Err(); err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: rows error: %v", err))
+	}
+
+	// The avatar link names one of the variants, unless it was uploaded before them
+	if avatarLink != "" && !slices.Contains(fileNames, avatarLink) {
+		fileNames = append(fileNames, avatarLink)
+	}
+
+	return fileNames
+}
+
+func (r *UserRepositoryPG) GetAvatarFileName(id string, size int) string {
+	// Avatars uploaded before the variants only have the avatar link
+	query := `
+		SELECT COALESCE(a.file_name, u.avatar_link, '')
+		FROM users u
+		LEFT JOIN user_avatars a ON a.user_id = u.id AND a.size = $2
+		WHERE u.id = $1`
+
+	var fileName string
+	err := r.db.QueryRow(query, id, size).Scan(&fileName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+		}
+		panic(fmt.Errorf("user_repo_pg_error: get avatar: %v", err))
 	}
 
-	return oldAvatarLink
+	return fileName
 }
 
 func (r *UserRepositoryPG) SearchUsersByUsername(username string) []entity.User {
@@ -163,7 +293,7 @@ func (r *UserRepositoryPG) SearchUsersByUsername(username string) []entity.User
 		SELECT
 		 id, username 
 		FROM users 
-		WHERE username ILIKE '%' || $1 || '%' 
+		WHERE username ILIKE '%' || $1 || '%' AND deleted_at IS NULL
 		FETCH FIRST 5 ROWS ONLY`
 	rows, err := r.db.Query(query, username)
 	if err != nil {
@@ -185,3 +315,49 @@ func (r *UserRepositoryPG) SearchUsersByUsername(username string) []entity.User
 
 	return users
 }
+
+func (r *UserRepositoryPG) RequirePasswordReset(id string, tokenHash string, ttl time.Duration) {
+	// Forcing it again replaces the previous link
+	query := `
+		UPDATE users
+		SET password_reset_token_hash = $2, password_reset_expires_at = NOW() + make_interval(secs => $3)
+		WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(query, id, tokenHash, ttl.Seconds())
+	if err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: require password reset: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "User not found!"))
+	}
+}
+
+func (r *UserRepositoryPG) RenewExpiredPasswordReset(identity string, tokenHash string, ttl time.Duration) *entity.User {
+	// Disabled accounts can't log in anyway, they're not sent anything
+	query := `
+		UPDATE users
+		SET password_reset_token_hash = $2, password_reset_expires_at = NOW() + make_interval(secs => $3)
+		WHERE (email = $1 OR username = $1)
+		  AND password_reset_token_hash IS NOT NULL AND password_reset_expires_at <= NOW()
+		  AND disabled_at IS NULL AND deleted_at IS NULL
+		RETURNING` + userColumns
+
+	return r.queryUser(query, identity, tokenHash, ttl.Seconds())
+}
+
+func (r *UserRepositoryPG) ResetPassword(tokenHash string, encryptedPassword string) {
+	query := `
+		UPDATE users
+		SET password = $2, password_reset_token_hash = NULL, password_reset_expires_at = NULL
+		WHERE password_reset_token_hash = $1 AND password_reset_expires_at > NOW() AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(query, tokenHash, encryptedPassword)
+	if err != nil {
+		panic(fmt.Errorf("user_repo_pg_error: reset password: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Password reset link is invalid or has expired!"))
+	}
+}
@@ -97,27 +97,40 @@ func TestUserRepository(t *testing.T) {
 			Password:        "newpassword",
 			ConfirmPassword: "newpassword",
 		}
-		newAvatarLink := "http://new.avatar/link"
+		newAvatars := map[int]string{32: "new-32.webp", 64: "new-64.webp", 128: "new-128.webp", 256: "new-256.webp"}
 
 		t.Run("Should update user successfully", func(t *testing.T) {
-			var oldAvatarLink string
+			var oldFileNames []string
 
 			// Action
 			assert.NotPanics(t, func() {
-				oldAvatarLink = userRepositoryPG.UpdateUserById(initialUser.Id, updatePayload, newAvatarLink)
+				oldFileNames = userRepositoryPG.UpdateUserById(initialUser.Id, updatePayload, newAvatars)
 			})
 
 			// Assert
 			updatedUser := userRepositoryPG.GetUserById(initialUser.Id)
 			assert.Equal(t, updatePayload.Username, updatedUser.Username)
 			assert.Equal(t, updatePayload.Email, updatedUser.Email)
-			assert.Equal(t, newAvatarLink, updatedUser.AvatarLink)
-			assert.Equal(t, initialUser.AvatarLink, oldAvatarLink)
+			assert.Equal(t, "new-256.webp", updatedUser.AvatarLink)
+			assert.Equal(t, "new-64.webp", userRepositoryPG.GetAvatarFileName(initialUser.Id, 64))
+			if initialUser.AvatarLink != "" {
+				assert.Equal(t, []string{initialUser.AvatarLink}, oldFileNames)
+			}
+		})
+
+		t.Run("Should return the replaced variants", func(t *testing.T) {
+			// Action
+			oldFileNames := userRepositoryPG.UpdateUserById(initialUser.Id, updatePayload, nil)
+
+			// Assert
+			assert.ElementsMatch(t, []string{"new-32.webp", "new-64.webp", "new-128.webp", "new-256.webp"}, oldFileNames)
+			assert.Equal(t, "", userRepositoryPG.GetUserById(initialUser.Id).AvatarLink)
+			assert.Equal(t, "", userRepositoryPG.GetAvatarFileName(initialUser.Id, 64))
 		})
 
 		t.Run("Should raise panic if user is not existed", func(t *testing.T) {
 			assert.PanicsWithError(t, "User not found!", func() {
-				userRepositoryPG.UpdateUserById(uuidGenerator.Generate(), updatePayload, newAvatarLink)
+				userRepositoryPG.UpdateUserById(uuidGenerator.Generate(), updatePayload, newAvatars)
 			})
 		})
 	})
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+)
+
+// versionCondition restricts a write to the versions expected by the client (If-Match), any version when there are none.
+// The versions are passed as the param-th argument, see versionArg.
+func versionCondition(param int) string {
+	return fmt.Sprintf(
+		` AND (COALESCE(cardinality($%d::INTEGER[]), 0) = 0 OR version = ANY($%d::INTEGER[]))`,
+		param,
+		param,
+	)
+}
+
+func versionArg(versions []int) interface{} {
+	return pq.Array(versions)
+}
+
+// panicMissedWrite tells why a conditional write on the row of the table affected nothing.
+// Should raise panic (412) if the row is still there with another version, (404) with notFound otherwise.
+func panicMissedWrite(q interface {
+	QueryRow(query string, args ...interface{}) *sql.Row
+}, table string, id string, notFound string) {
+	var exists bool
+	query := `SELECT EXISTS(SELECT 1 FROM ` + table + ` WHERE id = $1 AND deleted_at IS NULL)`
+	err := q.QueryRow(query, id).Scan(&exists)
+	if err != nil {
+		panic(fmt.Errorf("repo_pg_error: check %s version: %v", table, err))
+	}
+
+	if exists {
+		panic(fiber.NewError(fiber.StatusPreconditionFailed, "It has been changed by someone else, get it again before changing it!"))
+	}
+	panic(fiber.NewError(fiber.StatusNotFound, notFound))
+}
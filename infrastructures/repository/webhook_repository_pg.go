@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/domains/repository"
+	"time"
+)
+
+type WebhookRepositoryPG struct /* implements WebhookRepository */ {
+	db          *sql.DB
+	idGenerator generator.IdGenerator
+}
+
+func NewWebhookRepositoryPG(db *sql.DB, idGenerator generator.IdGenerator) repository.WebhookRepository {
+	return &WebhookRepositoryPG{
+		db:          db,
+		idGenerator: idGenerator,
+	}
+}
+
+func (r *WebhookRepositoryPG) AddWebhook(
+	projectId string,
+	payload *entity.WebhookPayload,
+	secret string,
+	createdBy string,
+) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	isActive := true
+	if payload.IsActive != nil {
+		isActive = *payload.IsActive
+	}
+
+	// Query
+	query := `INSERT INTO
+				webhooks(id, project_id, url, secret, events, is_active, created_by)
+			  VALUES
+				($1, $2, $3, $4, $5, $6, $7)
+			  RETURNING id`
+
+	var returnedId string
+	err := r.db.QueryRow(
+		query,
+		id,
+		projectId,
+		payload.Url,
+		secret,
+		pq.Array(payload.Events),
+		isActive,
+		createdBy,
+	).Scan(&returnedId)
+
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: add webhook: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *WebhookRepositoryPG) GetWebhookById(id string) *entity.Webhook {
+	var webhook entity.Webhook
+
+	query := `
+		SELECT id, project_id, url, secret, events, is_active, ` + utcTimestamp("created_at") + `, ` + utcTimestamp("updated_at") + `
+		FROM webhooks
+		WHERE id = $1`
+	err := r.db.QueryRow(query, id).Scan(
+		&webhook.Id,
+		&webhook.ProjectId,
+		&webhook.Url,
+		&webhook.Secret,
+		pq.Array(&webhook.Events),
+		&webhook.IsActive,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			panic(fiber.NewError(fiber.StatusNotFound, "Webhook not found!"))
+		}
+		panic(fmt.Errorf("webhook_repo_pg_error: get webhook by id: %v", err))
+	}
+
+	return &webhook
+}
+
+func (r *WebhookRepositoryPG) GetWebhooksByProject(projectId string) []entity.Webhook {
+	query := `
+		SELECT id, project_id, url, secret, events, is_active, ` + utcTimestamp("created_at") + `, ` + utcTimestamp("updated_at") + `
+		FROM webhooks
+		WHERE project_id = $1
+		ORDER BY created_at`
+
+	return r.queryWebhooks(query, projectId)
+}
+
+func (r *WebhookRepositoryPG) GetActiveWebhooksByEvent(projectId string, event string) []entity.Webhook {
+	query := `
+		SELECT id, project_id, url, secret, events, is_active, ` + utcTimestamp("created_at") + `, ` + utcTimestamp("updated_at") + `
+		FROM webhooks
+		WHERE project_id = $1 AND is_active AND $2 = ANY(events)`
+
+	return r.queryWebhooks(query, projectId, event)
+}
+
+func (r *WebhookRepositoryPG) UpdateWebhookById(id string, payload *entity.WebhookPayload) {
+	query := `
+		UPDATE webhooks
+		SET url = $1, events = $2, is_active = COALESCE($3, is_active), updated_at = NOW()
+		WHERE id = $4`
+
+	result, err := r.db.Exec(query, payload.Url, pq.Array(payload.Events), payload.IsActive, id)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: update webhook: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Webhook not found!"))
+	}
+}
+
+func (r *WebhookRepositoryPG) DeleteWebhookById(id string) {
+	query := `DELETE FROM webhooks WHERE id = $1`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: delete webhook: %v", err))
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Webhook not found!"))
+	}
+}
+
+func (r *WebhookRepositoryPG) AddDelivery(webhookId string, event string, payload []byte) string {
+	// Create ID
+	id := r.idGenerator.Generate()
+
+	query := `INSERT INTO
+				webhook_deliveries(id, webhook_id, event, payload)
+			  VALUES
+				($1, $2, $3, $4)
+			  RETURNING id`
+
+	var returnedId string
+	err := r.db.QueryRow(query, id, webhookId, event, string(payload)).Scan(&returnedId)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: add delivery: %v", err))
+	}
+
+	return returnedId
+}
+
+func (r *WebhookRepositoryPG) GetDeliveryById(id string) *entity.WebhookDelivery {
+	query := `
+		SELECT
+			id, webhook_id, event, payload, status, attempts, response_code,
+			error, ` + utcTimestamp("next_attempt_at") + `, ` + utcTimestamp("delivered_at") + `,
+			` + utcTimestamp("created_at") + `
+		FROM webhook_deliveries
+		WHERE id = $1`
+
+	deliveries := r.queryDeliveries(query, id)
+	if len(deliveries) == 0 {
+		panic(fiber.NewError(fiber.StatusNotFound, "Webhook delivery not found!"))
+	}
+
+	return &deliveries[0]
+}
+
+func (r *WebhookRepositoryPG) GetDeliveriesByWebhook(webhookId string) []entity.WebhookDelivery {
+	query := `
+		SELECT
+			id, webhook_id, event, payload, status, attempts, response_code,
+			error, ` + utcTimestamp("next_attempt_at") + `, ` + utcTimestamp("delivered_at") + `,
+			` + utcTimestamp("created_at") + `
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC
+		FETCH FIRST 100 ROWS ONLY`
+
+	return r.queryDeliveries(query, webhookId)
+}
+
+func (r *WebhookRepositoryPG) ClaimDueDeliveries(limit int) []entity.WebhookDelivery {
+	// Pushing next_attempt_at forward works as a lease, a crashed worker won't lose the delivery.
+	// Deliveries of inactive webhooks wait until they're active again
+	query := `
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + INTERVAL '5 minutes'
+		FROM webhooks w
+		WHERE w.id = d.webhook_id
+		  AND d.id IN (
+			SELECT due.id
+			FROM webhook_deliveries due
+			INNER JOIN webhooks hook ON hook.id = due.webhook_id
+			WHERE due.status = 'pending' AND due.next_attempt_at <= NOW() AND hook.is_active
+			ORDER BY due.next_attempt_at
+			LIMIT $1
+			FOR UPDATE OF due SKIP LOCKED
+		  )
+		RETURNING d.id, d.webhook_id, d.event, d.payload, d.attempts, w.url, w.secret`
+
+	rows, err := r.db.Query(query, limit)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: claim due deliveries: %v", err))
+	}
+	defer rows.Close()
+
+	var deliveries []entity.WebhookDelivery
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.Url,
+			&delivery.Secret,
+		)
+		if err != nil {
+			panic(fmt.Errorf("webhook_repo_pg_error: scan claimed delivery: %v", err))
+		}
+		delivery.Status = entity.WebhookDeliveryPending
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
+
+func (r *WebhookRepositoryPG) UpdateDeliveryResult(delivery *entity.WebhookDelivery, retryIn time.Duration) {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $1,
+			attempts = $2,
+			response_code = NULLIF($3, 0),
+			error = $4,
+			next_attempt_at = NOW() + make_interval(secs => $5),
+			delivered_at = CASE WHEN $1 = 'succeeded' THEN NOW() ELSE delivered_at END
+		WHERE id = $6`
+
+	_, err := r.db.Exec(
+		query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.ResponseCode,
+		delivery.Error,
+		retryIn.Seconds(),
+		delivery.Id,
+	)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: update delivery result: %v", err))
+	}
+}
+
+func (r *WebhookRepositoryPG) queryWebhooks(query string, args ...interface{}) []entity.Webhook {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: get webhooks: %v", err))
+	}
+	defer rows.Close()
+
+	var webhooks []entity.Webhook
+	for rows.Next() {
+		var webhook entity.Webhook
+		err := rows.Scan(
+			&webhook.Id,
+			&webhook.ProjectId,
+			&webhook.Url,
+			&webhook.Secret,
+			pq.Array(&webhook.Events),
+			&webhook.IsActive,
+			&webhook.CreatedAt,
+			&webhook.UpdatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("webhook_repo_pg_error: scan webhook: %v", err))
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks
+}
+
+func (r *WebhookRepositoryPG) queryDeliveries(query string, args ...interface{}) []entity.WebhookDelivery {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		panic(fmt.Errorf("webhook_repo_pg_error: get deliveries: %v", err))
+	}
+	defer rows.Close()
+
+	var deliveries []entity.WebhookDelivery
+	for rows.Next() {
+		var delivery entity.WebhookDelivery
+		var responseCode sql.NullInt64
+		var errorMessage, deliveredAt sql.NullString
+
+		err := rows.Scan(
+			&delivery.Id,
+			&delivery.WebhookId,
+			&delivery.Event,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&responseCode,
+			&errorMessage,
+			&delivery.NextAttemptAt,
+			&deliveredAt,
+			&delivery.CreatedAt,
+		)
+		if err != nil {
+			panic(fmt.Errorf("webhook_repo_pg_error: scan delivery: %v", err))
+		}
+
+		delivery.ResponseCode = int(responseCode.Int64)
+		delivery.Error = errorMessage.String
+		delivery.DeliveredAt = deliveredAt.String
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries
+}
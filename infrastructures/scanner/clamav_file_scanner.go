@@ -0,0 +1,93 @@
+package scanner
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/scanner"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// Bytes sent to clamd per INSTREAM chunk, well below its default StreamMaxLength
+const clamAVChunkSize = 64 << 10
+
+// ClamAVFileScanner implements FileScanner by streaming the content to clamd with the INSTREAM command.
+type ClamAVFileScanner struct /* implements FileScanner */ {
+	address string // TCP address of clamd, host:port
+	timeout time.Duration
+}
+
+func NewClamAVFileScanner(address string, timeout time.Duration) scanner.FileScanner {
+	return &ClamAVFileScanner{
+		address: address,
+		timeout: timeout,
+	}
+}
+
+func (s *ClamAVFileScanner) Scan(content []byte) string {
+	conn, err := net.DialTimeout("tcp", s.address, s.timeout)
+	if err != nil {
+		panic(fmt.Errorf("clamav_file_scanner_err: connect: %v", err))
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(s.timeout)); err != nil {
+		panic(fmt.Errorf("clamav_file_scanner_err: set deadline: %v", err))
+	}
+
+	if err := writeInstream(conn, content); err != nil {
+		panic(fmt.Errorf("clamav_file_scanner_err: send content: %v", err))
+	}
+
+	// The "z" prefix makes clamd end the reply with a null byte too
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		panic(fmt.Errorf("clamav_file_scanner_err: read reply: %v", err))
+	}
+
+	return parseReply(strings.TrimSuffix(reply, "\x00"))
+}
+
+// writeInstream sends the content as length-prefixed chunks, a zero length ends the stream.
+func writeInstream(writer io.Writer, content []byte) error {
+	if _, err := writer.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+
+	size := make([]byte, 4)
+	for len(content) > 0 {
+		chunk := content[:min(len(content), clamAVChunkSize)]
+		content = content[len(chunk):]
+
+		binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+		if _, err := writer.Write(size); err != nil {
+			return err
+		}
+		if _, err := writer.Write(chunk); err != nil {
+			return err
+		}
+	}
+
+	binary.BigEndian.PutUint32(size, 0)
+	_, err := writer.Write(size)
+
+	return err
+}
+
+// parseReply returns the threat named by the reply, like "stream: Eicar-Test-Signature FOUND".
+// Should raise panic if clamd reports an error, like the content exceeding its size limit.
+func parseReply(reply string) string {
+	result := strings.TrimPrefix(reply, "stream: ")
+
+	switch {
+	case result == "OK":
+		return ""
+	case strings.HasSuffix(result, " FOUND"):
+		return strings.TrimSuffix(result, " FOUND")
+	default:
+		panic(fmt.Errorf("clamav_file_scanner_err: scan: %s", reply))
+	}
+}
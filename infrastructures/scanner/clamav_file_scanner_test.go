@@ -0,0 +1,114 @@
+package scanner_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wisle25/task-pixie/infrastructures/scanner"
+)
+
+// startFakeClamd accepts one INSTREAM command, sends the streamed content to received,
+// then replies with what reply returns for it.
+func startFakeClamd(t *testing.T, reply func(content []byte) string) (string, <-chan []byte) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		command, err := reader.ReadString(0)
+		if err != nil || command != "zINSTREAM\x00" {
+			conn.Write([]byte("UNKNOWN COMMAND\x00"))
+			return
+		}
+
+		var content bytes.Buffer
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(reader, size); err != nil {
+				return
+			}
+			length := binary.BigEndian.Uint32(size)
+			if length == 0 {
+				break
+			}
+			if _, err := io.CopyN(&content, reader, int64(length)); err != nil {
+				return
+			}
+		}
+
+		received <- content.Bytes()
+		conn.Write([]byte(reply(content.Bytes()) + "\x00"))
+	}()
+
+	return listener.Addr().String(), received
+}
+
+func TestClamAVFileScanner(t *testing.T) {
+	t.Run("Should stream the whole content and find it clean", func(t *testing.T) {
+		// Arrange
+		address, received := startFakeClamd(t, func(_ []byte) string { return "stream: OK" })
+		clamAVScanner := scanner.NewClamAVFileScanner(address, 5*time.Second)
+		content := []byte(strings.Repeat("pixie", 50_000)) // Spans several chunks
+
+		// Action
+		threat := clamAVScanner.Scan(content)
+
+		// Assert
+		assert.Empty(t, threat)
+		assert.Equal(t, content, <-received)
+	})
+
+	t.Run("Should return the threat found", func(t *testing.T) {
+		// Arrange
+		address, _ := startFakeClamd(t, func(_ []byte) string { return "stream: Eicar-Test-Signature FOUND" })
+		clamAVScanner := scanner.NewClamAVFileScanner(address, 5*time.Second)
+
+		// Action
+		threat := clamAVScanner.Scan([]byte("X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR"))
+
+		// Assert
+		assert.Equal(t, "Eicar-Test-Signature", threat)
+	})
+
+	t.Run("Should raise panic when clamd reports an error", func(t *testing.T) {
+		// Arrange
+		address, _ := startFakeClamd(t, func(_ []byte) string { return "INSTREAM size limit exceeded. ERROR" })
+		clamAVScanner := scanner.NewClamAVFileScanner(address, 5*time.Second)
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			clamAVScanner.Scan([]byte("pixie"))
+		})
+	})
+
+	t.Run("Should raise panic when clamd can't be reached", func(t *testing.T) {
+		// Arrange
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		address := listener.Addr().String()
+		listener.Close()
+
+		clamAVScanner := scanner.NewClamAVFileScanner(address, time.Second)
+
+		// Action & Assert
+		assert.Panics(t, func() {
+			clamAVScanner.Scan([]byte("pixie"))
+		})
+	})
+}
@@ -0,0 +1,57 @@
+package scanner
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/generator"
+	"github.com/wisle25/task-pixie/applications/scanner"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DirectoryFileQuarantine implements FileQuarantine by writing every file into a directory, out of the storage.
+// The content is kept as a .bin file, so it's never opened by accident, next to a .json file describing it.
+type DirectoryFileQuarantine struct /* implements FileQuarantine */ {
+	directory   string
+	idGenerator generator.IdGenerator
+}
+
+func NewDirectoryFileQuarantine(directory string, idGenerator generator.IdGenerator) scanner.FileQuarantine {
+	return &DirectoryFileQuarantine{
+		directory:   directory,
+		idGenerator: idGenerator,
+	}
+}
+
+// quarantineRecord is what's written into the .json file of a quarantined file.
+type quarantineRecord struct {
+	*entity.QuarantinedFile
+	QuarantinedAt string `json:"quarantinedAt"`
+}
+
+func (q *DirectoryFileQuarantine) Quarantine(file *entity.QuarantinedFile) {
+	if err := os.MkdirAll(q.directory, 0o700); err != nil {
+		panic(fmt.Errorf("directory_file_quarantine_err: create directory: %v", err))
+	}
+
+	now := time.Now().UTC()
+	baseName := filepath.Join(q.directory, fmt.Sprintf("%s-%s", now.Format("20060102T150405"), q.idGenerator.Generate()))
+
+	if err := os.WriteFile(baseName+".bin", file.Content, 0o600); err != nil {
+		panic(fmt.Errorf("directory_file_quarantine_err: write file: %v", err))
+	}
+
+	record, err := json.MarshalIndent(&quarantineRecord{
+		QuarantinedFile: file,
+		QuarantinedAt:   now.Format(time.RFC3339),
+	}, "", "  ")
+	if err != nil {
+		panic(fmt.Errorf("directory_file_quarantine_err: marshal record: %v", err))
+	}
+
+	if err := os.WriteFile(baseName+".json", record, 0o600); err != nil {
+		panic(fmt.Errorf("directory_file_quarantine_err: write record: %v", err))
+	}
+}
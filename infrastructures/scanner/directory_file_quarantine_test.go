@@ -0,0 +1,54 @@
+package scanner_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/generator"
+	"github.com/wisle25/task-pixie/infrastructures/scanner"
+)
+
+func TestDirectoryFileQuarantine(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	quarantine := scanner.NewDirectoryFileQuarantine(directory, generator.NewUUIDGenerator())
+
+	file := &entity.QuarantinedFile{
+		Name:        "avatar.png",
+		ContentType: "image/png",
+		Size:        5,
+		Threat:      "Eicar-Test-Signature",
+		UploadedBy:  "user-123",
+		Content:     []byte("pixie"),
+	}
+
+	// Action
+	assert.NotPanics(t, func() {
+		quarantine.Quarantine(file)
+	})
+
+	// Assert
+	files, _ := filepath.Glob(filepath.Join(directory, "*.bin"))
+	require.Len(t, files, 1)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, []byte("pixie"), content)
+
+	recordJSON, err := os.ReadFile(strings.TrimSuffix(files[0], ".bin") + ".json")
+	require.NoError(t, err)
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(recordJSON, &record))
+	assert.Equal(t, "avatar.png", record["name"])
+	assert.Equal(t, "Eicar-Test-Signature", record["threat"])
+	assert.Equal(t, "user-123", record["uploadedBy"])
+	assert.NotEmpty(t, record["quarantinedAt"])
+	assert.NotContains(t, record, "content")
+}
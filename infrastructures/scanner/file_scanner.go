@@ -0,0 +1,15 @@
+package scanner
+
+import (
+	"github.com/wisle25/task-pixie/applications/scanner"
+	"github.com/wisle25/task-pixie/commons"
+)
+
+// NewFileScanner picks the FileScanner implementation based on UPLOAD_SCANNER ("none" or "clamav").
+func NewFileScanner(config *commons.Config) scanner.FileScanner {
+	if config.UploadScanner == "clamav" {
+		return NewClamAVFileScanner(config.ClamAVAddress, config.ClamAVTimeout)
+	}
+
+	return NewNoopFileScanner()
+}
@@ -0,0 +1,17 @@
+package scanner
+
+import "github.com/wisle25/task-pixie/applications/scanner"
+
+// NoopFileScanner implements FileScanner by finding every file clean.
+// It's meant for local development and tests, where no scanner is running.
+type NoopFileScanner struct /* implements FileScanner */ {
+
+}
+
+func NewNoopFileScanner() scanner.FileScanner {
+	return &NoopFileScanner{}
+}
+
+func (s *NoopFileScanner) Scan(_ []byte) string {
+	return ""
+}
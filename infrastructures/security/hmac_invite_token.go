@@ -0,0 +1,73 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/security"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HmacInviteToken signs invitation tokens with HMAC-SHA256.
+// A token looks like `base64url(invitationId.expiresAtUnix).base64url(signature)`.
+type HmacInviteToken struct /* implements InviteToken */ {
+	secret []byte
+}
+
+func NewHmacInviteToken(secret string) security.InviteToken {
+	if secret == "" {
+		panic(fmt.Errorf("invite_token_err: secret is empty"))
+	}
+
+	return &HmacInviteToken{
+		secret: []byte(secret),
+	}
+}
+
+func (t *HmacInviteToken) CreateInviteToken(invitationId string, expiresAt time.Time) string {
+	payload := invitationId + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." +
+		base64.RawURLEncoding.EncodeToString(t.sign(payload))
+}
+
+func (t *HmacInviteToken) ValidateInviteToken(token string) string {
+	invalid := fiber.NewError(fiber.StatusBadRequest, "Invitation link is invalid!")
+
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		panic(invalid)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		panic(invalid)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, t.sign(string(payload))) {
+		panic(invalid)
+	}
+
+	invitationId, expiresAt, found := strings.Cut(string(payload), ".")
+	unix, err := strconv.ParseInt(expiresAt, 10, 64)
+	if !found || err != nil {
+		panic(invalid)
+	}
+
+	if time.Now().Unix() >= unix {
+		panic(fiber.NewError(fiber.StatusGone, "Invitation link has expired!"))
+	}
+
+	return invitationId
+}
+
+func (t *HmacInviteToken) sign(payload string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(payload))
+
+	return mac.Sum(nil)
+}
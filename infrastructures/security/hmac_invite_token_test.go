@@ -0,0 +1,64 @@
+package security_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/infrastructures/security"
+)
+
+func TestHmacInviteToken(t *testing.T) {
+	inviteToken := security.NewHmacInviteToken("invite_secret")
+
+	t.Run("Should return invitation ID of valid token", func(t *testing.T) {
+		// Arrange
+		token := inviteToken.CreateInviteToken("invitation-id", time.Now().Add(time.Hour))
+
+		// Action and Assert
+		assert.Equal(t, "invitation-id", inviteToken.ValidateInviteToken(token))
+	})
+
+	t.Run("Should raise panic when token is expired", func(t *testing.T) {
+		// Arrange
+		token := inviteToken.CreateInviteToken("invitation-id", time.Now().Add(-time.Second))
+
+		// Action and Assert
+		assert.PanicsWithError(t, "Invitation link has expired!", func() {
+			inviteToken.ValidateInviteToken(token)
+		})
+	})
+
+	t.Run("Should raise panic when token is tampered", func(t *testing.T) {
+		// Arrange
+		token := inviteToken.CreateInviteToken("invitation-id", time.Now().Add(time.Hour))
+		otherToken := inviteToken.CreateInviteToken("other-id", time.Now().Add(time.Hour))
+		payload, _, _ := strings.Cut(otherToken, ".")
+		_, signature, _ := strings.Cut(token, ".")
+
+		// Action and Assert
+		assert.PanicsWithError(t, "Invitation link is invalid!", func() {
+			inviteToken.ValidateInviteToken(payload + "." + signature)
+		})
+	})
+
+	t.Run("Should raise panic when token is signed with another secret", func(t *testing.T) {
+		// Arrange
+		token := security.NewHmacInviteToken("other_secret").CreateInviteToken("invitation-id", time.Now().Add(time.Hour))
+
+		// Action and Assert
+		assert.PanicsWithError(t, "Invitation link is invalid!", func() {
+			inviteToken.ValidateInviteToken(token)
+		})
+	})
+
+	t.Run("Should raise panic when token is malformed", func(t *testing.T) {
+		// Action and Assert
+		for _, token := range []string{"", "no-dot", "!!!.!!!", "aW52aXRhdGlvbg.c2ln"} {
+			assert.PanicsWithError(t, "Invitation link is invalid!", func() {
+				inviteToken.ValidateInviteToken(token)
+			}, token)
+		}
+	})
+}
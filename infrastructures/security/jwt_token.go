@@ -34,6 +34,7 @@ func (jt *JwtToken) CreateToken(userToken *entity.User, ttl time.Duration, priva
 		TokenId:   jt.idGenerator.Generate(),
 		UserToken: userToken,
 		ExpiresIn: now.Add(ttl).Unix(),
+		IssuedAt:  now.Unix(),
 		MaxAge:    int(ttl.Seconds()),
 	}
 
@@ -57,7 +58,7 @@ func (jt *JwtToken) CreateToken(userToken *entity.User, ttl time.Duration, priva
 		"avatar_link": userToken.AvatarLink,
 		"token_id":    td.TokenId,
 		"exp":         td.ExpiresIn,
-		"iat":         now.Unix(),
+		"iat":         td.IssuedAt,
 		"nbf":         now.Unix(),
 	}
 
@@ -111,6 +112,9 @@ func (jt *JwtToken) ValidateToken(token string, publicKey string) *entity.TokenD
 		panic(fiber.NewError(fiber.StatusInternalServerError, "Invalid token!"))
 	}
 
+	// Tokens are signed with a numeric iat, see CreateToken
+	issuedAt, _ := claims["iat"].(float64)
+
 	// Return the token details
 	userToken := &entity.User{
 		Id:         claims["sub"].(string),
@@ -121,6 +125,7 @@ func (jt *JwtToken) ValidateToken(token string, publicKey string) *entity.TokenD
 
 	return &entity.TokenDetail{
 		TokenId:   fmt.Sprintf("%s", claims["token_id"]),
+		IssuedAt:  int64(issuedAt),
 		UserToken: userToken,
 	}
 }
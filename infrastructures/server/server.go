@@ -1,6 +1,7 @@
 ﻿package server
 
 import (
+	"context"
 	"errors"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -11,11 +12,31 @@ import (
 	"github.com/wisle25/task-pixie/infrastructures/container"
 	"github.com/wisle25/task-pixie/infrastructures/file_statics"
 	"github.com/wisle25/task-pixie/infrastructures/generator"
+	"github.com/wisle25/task-pixie/infrastructures/importer"
+	"github.com/wisle25/task-pixie/infrastructures/mailer"
+	"github.com/wisle25/task-pixie/infrastructures/markdown"
+	"github.com/wisle25/task-pixie/infrastructures/scanner"
 	"github.com/wisle25/task-pixie/infrastructures/services"
+	"github.com/wisle25/task-pixie/infrastructures/worker"
+	"github.com/wisle25/task-pixie/interfaces/http/accounts"
+	"github.com/wisle25/task-pixie/interfaces/http/admin"
+	"github.com/wisle25/task-pixie/interfaces/http/calendar_feeds"
+	"github.com/wisle25/task-pixie/interfaces/http/digests"
+	"github.com/wisle25/task-pixie/interfaces/http/imports"
+	"github.com/wisle25/task-pixie/interfaces/http/invitations"
 	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+	"github.com/wisle25/task-pixie/interfaces/http/milestones"
+	"github.com/wisle25/task-pixie/interfaces/http/organizations"
 	"github.com/wisle25/task-pixie/interfaces/http/projects"
+	"github.com/wisle25/task-pixie/interfaces/http/search"
 	"github.com/wisle25/task-pixie/interfaces/http/tasks"
+	"github.com/wisle25/task-pixie/interfaces/http/teams"
+	"github.com/wisle25/task-pixie/interfaces/http/time_entries"
+	"github.com/wisle25/task-pixie/interfaces/http/transfers"
+	"github.com/wisle25/task-pixie/interfaces/http/trash"
 	"github.com/wisle25/task-pixie/interfaces/http/users"
+	"github.com/wisle25/task-pixie/interfaces/http/views"
+	"github.com/wisle25/task-pixie/interfaces/http/webhooks"
 )
 
 func errorHandling(c *fiber.Ctx, err error) error {
@@ -54,9 +75,10 @@ func CreateServer(config *commons.Config) *fiber.App {
 	app.Use(recover.New())
 	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowHeaders: "Origin, Content-Type, Accept",
-		AllowMethods: "POST,GET,PUT,DELETE",
+		AllowOrigins:  "*",
+		AllowHeaders:  "Origin, Content-Type, Accept, If-Match, If-None-Match",
+		AllowMethods:  "POST,GET,PUT,PATCH,DELETE",
+		ExposeHeaders: "ETag",
 	}))
 
 	// Global Dependencies
@@ -65,6 +87,12 @@ func CreateServer(config *commons.Config) *fiber.App {
 	validation := services.NewValidation()
 	minioFileUpload := file_statics.NewMinioFileUpload(minio, uuidGenerator, bucketName)
 	vipsFileProcessing := file_statics.NewVipsFileProcessing()
+	configuredMailer := mailer.NewMailer(config, uuidGenerator)
+	templateMailRenderer := mailer.NewTemplateMailRenderer()
+	importAdapters := importer.NewImportAdapters()
+	sanitizedMarkdownRenderer := markdown.NewSanitizedMarkdownRenderer()
+	configuredFileScanner := scanner.NewFileScanner(config)
+	directoryFileQuarantine := scanner.NewDirectoryFileQuarantine(config.UploadQuarantineDir, uuidGenerator)
 
 	// Use Cases
 	userUseCase := container.NewUserContainer(
@@ -74,18 +102,107 @@ func CreateServer(config *commons.Config) *fiber.App {
 		uuidGenerator,
 		vipsFileProcessing,
 		minioFileUpload,
+		configuredFileScanner,
+		directoryFileQuarantine,
+		configuredMailer,
+		templateMailRenderer,
 		validation,
 	)
-	projectUseCase := container.NewProjectContainer(uuidGenerator, db, validation)
-	tasksUseCase := container.NewTaskContainer(uuidGenerator, db, validation)
+	webhookUseCase := container.NewWebhookContainer(config, uuidGenerator, db, validation)
+	projectUseCase := container.NewProjectContainer(
+		uuidGenerator,
+		db,
+		redisCache,
+		validation,
+		webhookUseCase,
+		sanitizedMarkdownRenderer,
+	)
+	tasksUseCase := container.NewTaskContainer(
+		uuidGenerator,
+		db,
+		redisCache,
+		validation,
+		webhookUseCase,
+		sanitizedMarkdownRenderer,
+	)
+	digestUseCase := container.NewDigestContainer(config, db, configuredMailer, templateMailRenderer, validation)
+	searchUseCase := container.NewSearchContainer(db, validation)
+	taskViewUseCase := container.NewTaskViewContainer(uuidGenerator, db, validation)
+	trashUseCase := container.NewTrashContainer(config, uuidGenerator, db, redisCache)
+	invitationUseCase := container.NewInvitationContainer(
+		config,
+		uuidGenerator,
+		db,
+		configuredMailer,
+		templateMailRenderer,
+		validation,
+	)
+	organizationUseCase := container.NewOrganizationContainer(uuidGenerator, db, validation)
+	teamUseCase := container.NewTeamContainer(uuidGenerator, db, validation)
+	timeEntryUseCase := container.NewTimeEntryContainer(uuidGenerator, db, validation)
+	milestoneUseCase := container.NewMilestoneContainer(uuidGenerator, db, redisCache, validation)
+	calendarFeedUseCase := container.NewCalendarFeedContainer(config, uuidGenerator, db, validation)
+	taskTransferUseCase := container.NewTaskTransferContainer(
+		uuidGenerator,
+		db,
+		redisCache,
+		validation,
+		configuredFileScanner,
+		directoryFileQuarantine,
+		webhookUseCase,
+	)
+	boardImportUseCase := container.NewBoardImportContainer(
+		uuidGenerator,
+		db,
+		redisCache,
+		validation,
+		importAdapters,
+		configuredFileScanner,
+		directoryFileQuarantine,
+		webhookUseCase,
+	)
+	accountUseCase := container.NewAccountContainer(config, uuidGenerator, db, minioFileUpload, validation)
+	adminUseCase := container.NewAdminContainer(
+		config,
+		uuidGenerator,
+		db,
+		redisCache,
+		minioFileUpload,
+		configuredMailer,
+		templateMailRenderer,
+		validation,
+	)
+
+	// Workers
+	worker.StartWebhookWorker(context.Background(), webhookUseCase, config.WebhookWorkerInterval)
+	worker.StartDigestWorker(context.Background(), digestUseCase, config.DigestWorkerInterval)
+	worker.StartPurgeWorker(context.Background(), trashUseCase, config.PurgeWorkerInterval)
+	worker.StartAccountWorker(context.Background(), accountUseCase, config.AccountWorkerInterval)
 
 	// Custom Middleware
 	jwtMiddleware := middlewares.NewJwtMiddleware(userUseCase)
+	timeZoneMiddleware := middlewares.NewTimeZoneMiddleware(userUseCase)
+	app.Use(timeZoneMiddleware.RenderTimeZone)
 
 	// Router
 	users.NewUserRouter(app, jwtMiddleware, userUseCase)
 	projects.NewProjectRouter(app, jwtMiddleware, projectUseCase)
 	tasks.NewTaskRouter(app, jwtMiddleware, tasksUseCase)
+	webhooks.NewWebhookRouter(app, jwtMiddleware, webhookUseCase)
+	digests.NewDigestRouter(app, jwtMiddleware, digestUseCase)
+	search.NewSearchRouter(app, jwtMiddleware, searchUseCase)
+	views.NewTaskViewRouter(app, jwtMiddleware, taskViewUseCase)
+	trash.NewTrashRouter(app, jwtMiddleware, trashUseCase)
+	invitations.NewInvitationRouter(app, jwtMiddleware, invitationUseCase)
+	organizations.NewOrganizationRouter(app, jwtMiddleware, organizationUseCase)
+	teams.NewTeamRouter(app, jwtMiddleware, teamUseCase)
+	time_entries.NewTimeEntryRouter(app, jwtMiddleware, timeEntryUseCase)
+	milestones.NewMilestoneRouter(app, jwtMiddleware, milestoneUseCase)
+	calendar_feeds.NewCalendarFeedRouter(app, jwtMiddleware, calendarFeedUseCase)
+	transfers.NewTaskTransferRouter(app, jwtMiddleware, taskTransferUseCase)
+	imports.NewBoardImportRouter(app, jwtMiddleware, boardImportUseCase)
+	accounts.NewAccountRouter(app, jwtMiddleware, accountUseCase)
+	admin.NewAdminRouter(app, jwtMiddleware, adminUseCase)
 
 	return app
 }
@@ -22,7 +22,7 @@ func ConnectDB(config *commons.Config) *sql.DB {
 
 	// Format the data source name (DSN) string for connecting to the cache.
 	dsn := fmt.Sprintf(
-		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
+		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
 		config.DBHost,
 		config.DBUserName,
 		config.DBUserPassword,
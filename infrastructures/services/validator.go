@@ -92,3 +92,15 @@ func Validate(s interface{}, schema map[string]string, v *Validation) {
 		))
 	}
 }
+
+// ValidateFields validates only the given fields of the payload, fields without rule are ignored.
+func ValidateFields(s interface{}, schema map[string]string, fields []string, v *Validation) {
+	partial := make(map[string]string)
+	for _, field := range fields {
+		if rule, ok := schema[field]; ok {
+			partial[field] = rule
+		}
+	}
+
+	Validate(s, partial, v)
+}
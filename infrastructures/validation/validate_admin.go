@@ -0,0 +1,45 @@
+package validation
+
+import (
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateAdmin struct /* implements ValidateAdmin */ {
+	validation *services.Validation
+}
+
+func NewValidateAdmin(validation *services.Validation) validation.ValidateAdmin {
+	return &GoValidateAdmin{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateAdmin) ValidateUserFilter(filter *entity.AdminUserFilter) {
+	schema := map[string]string{
+		"Query": "omitempty,max=200",
+		"Status": fmt.Sprintf(
+			"omitempty,oneof=%s %s %s",
+			entity.AccountStatusActive, entity.AccountStatusDisabled, entity.AccountStatusDeleted,
+		),
+		"Limit":  "min=1,max=100",
+		"Offset": "min=0",
+	}
+
+	services.Validate(filter, schema, v.validation)
+}
+
+func (v *GoValidateAdmin) ValidateAuditLogFilter(filter *entity.AuditLogFilter) {
+	schema := map[string]string{
+		"ActorId":    "omitempty,uuid",
+		"Action":     "omitempty,max=50",
+		"TargetType": fmt.Sprintf("omitempty,oneof=%s %s", entity.AuditTargetUser, entity.AuditTargetFile),
+		"TargetId":   "omitempty,max=255",
+		"Limit":      "min=1,max=100",
+		"Offset":     "min=0",
+	}
+
+	services.Validate(filter, schema, v.validation)
+}
@@ -0,0 +1,33 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateCalendarFeed struct {
+	validation *services.Validation
+}
+
+func NewValidateCalendarFeed(validation *services.Validation) validation.ValidateCalendarFeed {
+	return &GoValidateCalendarFeed{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateCalendarFeed) ValidatePayload(payload *entity.CalendarFeedPayload) {
+	schema := map[string]string{
+		"ProjectId": "omitempty,uuid",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateCalendarFeed) ValidateFilter(filter *entity.CalendarFeedFilter) {
+	schema := map[string]string{
+		"Kind": "omitempty,oneof=events todos",
+	}
+
+	services.Validate(filter, schema, v.validation)
+}
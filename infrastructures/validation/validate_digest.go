@@ -0,0 +1,27 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateDigest struct /* implements ValidateDigest */ {
+	validation *services.Validation
+}
+
+func NewValidateDigest(validation *services.Validation) validation.ValidateDigest {
+	return &GoValidateDigest{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateDigest) ValidateSubscriptionPayload(payload *entity.DigestSubscriptionPayload) {
+	schema := map[string]string{
+		"Frequency": "required,oneof=daily weekly",
+		"TimeZone":  "required,timezone",
+		"SendHour":  "min=0,max=23",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateInvitation struct /* implements ValidateInvitation */ {
+	validation *services.Validation
+}
+
+func NewValidateInvitation(validation *services.Validation) validation.ValidateInvitation {
+	return &GoValidateInvitation{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateInvitation) ValidatePayload(payload *entity.InvitationPayload) {
+	schema := map[string]string{
+		"Invitee": "required,max=255,email|alphanum",
+		"Role":    "required,oneof=admin member",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateMilestone struct {
+	validation *services.Validation
+}
+
+func NewValidateMilestone(validation *services.Validation) validation.ValidateMilestone {
+	return &GoValidateMilestone{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateMilestone) ValidatePayload(payload *entity.MilestonePayload) {
+	schema := map[string]string{
+		"Name":      "required,min=1,max=100",
+		"Goal":      "max=1000",
+		"StartDate": "required,datetime=2006-01-02",
+		"EndDate":   "required,datetime=2006-01-02",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateMilestone) ValidateClosePayload(payload *entity.CloseMilestonePayload) {
+	schema := map[string]string{
+		"NextMilestoneId": "omitempty,uuid",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
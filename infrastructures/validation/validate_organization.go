@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateOrganization struct /* implements ValidateOrganization */ {
+	validation *services.Validation
+}
+
+func NewValidateOrganization(validation *services.Validation) validation.ValidateOrganization {
+	return &GoValidateOrganization{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateOrganization) ValidatePayload(payload *entity.OrganizationPayload) {
+	schema := map[string]string{
+		"Name": "required,min=3,max=100",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateOrganization) ValidateMemberPayload(payload *entity.OrganizationMemberPayload) {
+	schema := map[string]string{
+		"UserId": "required,uuid",
+		"Role":   "required,oneof=admin member",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
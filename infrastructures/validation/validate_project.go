@@ -16,14 +16,62 @@ func NewValidateProject(validation *services.Validation) validation.ValidateProj
 	}
 }
 
+var projectSchema = map[string]string{
+	"Title":          "required,min=3,max=100",
+	"Detail":         "required,min=3,max=1000",
+	"Priority":       "required,oneof=Low High Urgent",
+	"Status":         "required,oneof='To Do' 'In Progress' Completed Canceled",
+	"OrganizationId": "omitempty,uuid",
+}
+
 func (v *GoValidateProject) ValidatePayload(payload *entity.ProjectPayload) {
+	services.Validate(payload, projectSchema, v.validation)
+}
+
+func (v *GoValidateProject) ValidatePatch(payload *entity.ProjectPayload, fields []string) {
+	services.ValidateFields(payload, projectSchema, fields, v.validation)
+}
+
+func (v *GoValidateProject) ValidateMemberPayload(payload *entity.MemberPayload) {
 	schema := map[string]string{
-		"Title":     "required,min=3,max=100",
-		"Detail":    "required,min=3,max=1000",
-		"Priority":  "required,oneof=Low High Urgent",
-		"Status":    "required,oneof='To Do' 'In Progress' Completed Canceled",
-		"MembersId": "required,dive,uuid",
+		"UserId": "required,uuid",
+		"Role":   "required,oneof=admin member",
 	}
 
 	services.Validate(payload, schema, v.validation)
 }
+
+func (v *GoValidateProject) ValidateTransferPayload(payload *entity.TransferOwnershipPayload) {
+	schema := map[string]string{
+		"UserId": "required,uuid",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateProject) ValidateClonePayload(payload *entity.CloneProjectPayload) {
+	schema := map[string]string{
+		"Title":          "required,min=3,max=100",
+		"OrganizationId": "omitempty,uuid",
+		"StartDate":      "omitempty,datetime=2006-01-02",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateProject) ValidateTemplatePayload(payload *entity.ProjectTemplatePayload) {
+	schema := map[string]string{
+		"Title": "required,min=3,max=100",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateProject) ValidateStatsFilter(filter *entity.ProjectStatsFilter) {
+	schema := map[string]string{
+		"From": "omitempty,datetime=2006-01-02",
+		"To":   "omitempty,datetime=2006-01-02",
+	}
+
+	services.Validate(filter, schema, v.validation)
+}
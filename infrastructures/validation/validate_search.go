@@ -0,0 +1,28 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateSearch struct /* implements ValidateSearch */ {
+	validation *services.Validation
+}
+
+func NewValidateSearch(validation *services.Validation) validation.ValidateSearch {
+	return &GoValidateSearch{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateSearch) ValidatePayload(payload *entity.SearchPayload) {
+	schema := map[string]string{
+		"Query":  "required,min=2,max=200",
+		"Type":   "omitempty,oneof=task project",
+		"Limit":  "min=1,max=100",
+		"Offset": "min=0",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
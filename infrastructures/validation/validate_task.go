@@ -16,16 +16,69 @@ func NewValidateTask(validation *services.Validation) validation.ValidateTask {
 	}
 }
 
+var taskSchema = map[string]string{
+	"Title":        "required,min=3,max=100",
+	"Description":  "required,min=3,max=1000",
+	"Detail":       "omitempty,min=3,max=1000",
+	"Priority":     "required,oneof=Low High Urgent",
+	"Status":       "required,oneof='To Do' 'In Progress' Completed Canceled",
+	"ProjectId":    "omitempty,uuid",
+	"DueDate":      "required",
+	"AssignedToId": "omitempty,dive,uuid",
+	"Estimate":     "min=0,max=100000",
+	"MilestoneId":  "omitempty,uuid",
+}
+
 func (v *GoValidateTask) ValidatePayload(payload *entity.TaskPayload) {
+	services.Validate(payload, taskSchema, v.validation)
+}
+
+func (v *GoValidateTask) ValidatePatch(payload *entity.TaskPayload, fields []string) {
+	services.ValidateFields(payload, taskSchema, fields, v.validation)
+}
+
+func (v *GoValidateTask) ValidateBulkPayload(payload *entity.TaskBulkPayload) {
+	schema := map[string]string{
+		"TaskIds":   "required,min=1,max=100,dive,uuid",
+		"Operation": "required,oneof=set-status set-priority add-assignee remove-assignee move-to-project delete",
+	}
+
+	// The value depends on the operation
+	switch payload.Operation {
+	case entity.TaskBulkSetStatus:
+		schema["Value"] = "required,oneof='To Do' 'In Progress' Completed Canceled"
+	case entity.TaskBulkSetPriority:
+		schema["Value"] = "required,oneof=Low High Urgent"
+	case entity.TaskBulkAddAssignee, entity.TaskBulkRemoveAssignee, entity.TaskBulkMoveToProject:
+		schema["Value"] = "required,uuid"
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateTask) ValidateImportPayload(payload *entity.TaskImportPayload) {
+	schema := map[string]string{
+		"File":    "required",
+		"Format":  "omitempty,oneof=csv json",
+		"Mapping": "omitempty,json",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateTask) ValidateExportFilter(filter *entity.TaskExportFilter) {
+	schema := map[string]string{
+		"Format": "omitempty,oneof=csv json",
+	}
+
+	services.Validate(filter, schema, v.validation)
+}
+
+func (v *GoValidateTask) ValidateBoardImportPayload(payload *entity.BoardImportPayload) {
 	schema := map[string]string{
-		"Title":        "required,min=3,max=100",
-		"Description":  "required,min=3,max=1000",
-		"Detail":       "omitempty,min=3,max=1000",
-		"Priority":     "required,oneof=Low High Urgent",
-		"Status":       "required,oneof='To Do' 'In Progress' Completed Canceled",
-		"ProjectId":    "omitempty,uuid",
-		"DueDate":      "required",
-		"AssignedToId": "omitempty,dive,uuid",
+		"File":    "required",
+		"Source":  "required,alpha",
+		"Mapping": "omitempty,json",
 	}
 
 	services.Validate(payload, schema, v.validation)
@@ -0,0 +1,27 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateTaskView struct /* implements ValidateTaskView */ {
+	validation *services.Validation
+}
+
+func NewValidateTaskView(validation *services.Validation) validation.ValidateTaskView {
+	return &GoValidateTaskView{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateTaskView) ValidatePayload(payload *entity.TaskViewPayload) {
+	schema := map[string]string{
+		"Name":      "required,min=1,max=100",
+		"Query":     "required,max=1000",
+		"ProjectId": "omitempty,uuid",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
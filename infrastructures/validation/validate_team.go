@@ -0,0 +1,42 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateTeam struct /* implements ValidateTeam */ {
+	validation *services.Validation
+}
+
+func NewValidateTeam(validation *services.Validation) validation.ValidateTeam {
+	return &GoValidateTeam{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateTeam) ValidatePayload(payload *entity.TeamPayload) {
+	schema := map[string]string{
+		"Name": "required,min=1,max=100",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateTeam) ValidateMemberPayload(payload *entity.TeamMemberPayload) {
+	schema := map[string]string{
+		"UserId": "required,uuid",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateTeam) ValidateProjectTeamPayload(payload *entity.ProjectTeamPayload) {
+	schema := map[string]string{
+		"TeamId": "required,uuid",
+		"Role":   "required,oneof=admin member",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateTimeEntry struct {
+	validation *services.Validation
+}
+
+func NewValidateTimeEntry(validation *services.Validation) validation.ValidateTimeEntry {
+	return &GoValidateTimeEntry{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateTimeEntry) ValidateTimerPayload(payload *entity.TimerPayload) {
+	schema := map[string]string{
+		"Note": "max=1000",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateTimeEntry) ValidateEntryPayload(payload *entity.TimeEntryPayload) {
+	schema := map[string]string{
+		"StartedAt": "required,datetime=2006-01-02T15:04:05Z07:00",
+		"EndedAt":   "required,datetime=2006-01-02T15:04:05Z07:00",
+		"Note":      "max=1000",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateTimeEntry) ValidateReportFilter(filter *entity.TimeReportFilter) {
+	schema := map[string]string{
+		"ProjectId": "omitempty,uuid",
+		"UserId":    "omitempty,uuid",
+		"From":      "omitempty,datetime=2006-01-02",
+		"To":        "omitempty,datetime=2006-01-02",
+		"Format":    "omitempty,oneof=json csv",
+	}
+
+	services.Validate(filter, schema, v.validation)
+}
@@ -43,6 +43,32 @@ func (v *GoValidateUser) ValidateUpdatePayload(payload *entity.UpdateUserPayload
 		"Email":           "required,email",
 		"Password":        "omitempty,min=8",
 		"ConfirmPassword": "omitempty,min=8," + fmt.Sprintf("eq=%s", services.FieldValue(payload, "Password")),
+		"DisplayName":     "omitempty,max=100",
+		"Bio":             "omitempty,max=500",
+		"TimeZone":        "omitempty,timezone",
+		"Locale":          "omitempty,max=35,bcp47_language_tag",
+		"DateFormat": fmt.Sprintf(
+			"omitempty,oneof=%s %s %s %s",
+			entity.DateFormatISO, entity.DateFormatDayFirst, entity.DateFormatUS, entity.DateFormatDotted,
+		),
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateUser) ValidatePasswordResetPayload(payload *entity.PasswordResetPayload) {
+	schema := map[string]string{
+		"Token":           "required,hexadecimal,len=64",
+		"Password":        "required,min=8",
+		"ConfirmPassword": "required,min=8," + fmt.Sprintf("eq=%s", services.FieldValue(payload, "Password")),
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
+
+func (v *GoValidateUser) ValidateDeletePayload(payload *entity.DeleteUserPayload) {
+	schema := map[string]string{
+		"Password": "required",
 	}
 
 	services.Validate(payload, schema, v.validation)
@@ -4,6 +4,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/wisle25/task-pixie/domains/entity"
 	"github.com/wisle25/task-pixie/infrastructures/services"
+	"strings"
 	"testing"
 
 	"github.com/wisle25/task-pixie/infrastructures/validation"
@@ -380,4 +381,106 @@ func TestValidateUser(t *testing.T) {
 			})
 		})
 	})
+
+	t.Run("Profile Validation", func(t *testing.T) {
+		newPayload := func() *entity.UpdateUserPayload {
+			return &entity.UpdateUserPayload{
+				Username:    "validuser",
+				Email:       "valid@gmail.com",
+				DisplayName: "Valid User",
+				TimeZone:    "Asia/Jakarta",
+				Locale:      "id-ID",
+				DateFormat:  entity.DateFormatDayFirst,
+			}
+		}
+
+		t.Run("Should return error when time zone is unknown", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+			payload.TimeZone = "Mars/Olympus_Mons"
+
+			// Action and Assert
+			assert.Panics(t, func() {
+				validateUser.ValidateUpdatePayload(payload)
+			})
+		})
+
+		t.Run("Should return error when locale is not a language tag", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+			payload.Locale = "not a locale"
+
+			// Action and Assert
+			assert.Panics(t, func() {
+				validateUser.ValidateUpdatePayload(payload)
+			})
+		})
+
+		t.Run("Should return error when date format is not supported", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+			payload.DateFormat = "YYYY/DD/MM"
+
+			// Action and Assert
+			assert.Panics(t, func() {
+				validateUser.ValidateUpdatePayload(payload)
+			})
+		})
+
+		t.Run("Shouldn't raise error when profile is valid or left empty", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+			emptyProfile := &entity.UpdateUserPayload{Username: "validuser", Email: "valid@gmail.com"}
+
+			// Action and Assert
+			assert.NotPanics(t, func() {
+				validateUser.ValidateUpdatePayload(payload)
+			})
+			assert.NotPanics(t, func() {
+				validateUser.ValidateUpdatePayload(emptyProfile)
+			})
+		})
+	})
+
+	t.Run("Password Reset Validation", func(t *testing.T) {
+		newPayload := func() *entity.PasswordResetPayload {
+			return &entity.PasswordResetPayload{
+				Token:           strings.Repeat("ab", 32),
+				Password:        "newpassword",
+				ConfirmPassword: "newpassword",
+			}
+		}
+
+		t.Run("Should return error when token is malformed", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+			payload.Token = "not-a-token"
+
+			// Action and Assert
+			assert.Panics(t, func() {
+				validateUser.ValidatePasswordResetPayload(payload)
+			})
+		})
+
+		t.Run("Should return error when passwords don't match", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+			payload.ConfirmPassword = "otherpassword"
+
+			// Action and Assert
+			assert.Panics(t, func() {
+				validateUser.ValidatePasswordResetPayload(payload)
+			})
+		})
+
+		t.Run("Shouldn't raise error when payload is valid", func(t *testing.T) {
+			// Arrange
+			payload := newPayload()
+
+			// Action and Assert
+			assert.NotPanics(t, func() {
+				validateUser.ValidatePasswordResetPayload(payload)
+			})
+		})
+	})
 }
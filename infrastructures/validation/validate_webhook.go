@@ -0,0 +1,26 @@
+package validation
+
+import (
+	"github.com/wisle25/task-pixie/applications/validation"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/services"
+)
+
+type GoValidateWebhook struct /* implements ValidateWebhook */ {
+	validation *services.Validation
+}
+
+func NewValidateWebhook(validation *services.Validation) validation.ValidateWebhook {
+	return &GoValidateWebhook{
+		validation: validation,
+	}
+}
+
+func (v *GoValidateWebhook) ValidatePayload(payload *entity.WebhookPayload) {
+	schema := map[string]string{
+		"Url":    "required,http_url,max=2048",
+		"Events": "required,min=1,dive,oneof=task.created task.updated task.deleted project.updated",
+	}
+
+	services.Validate(payload, schema, v.validation)
+}
@@ -0,0 +1,106 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/wisle25/task-pixie/applications/webhook"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+const (
+	// SignatureHeader holds the HMAC-SHA256 of the request body, formatted as "sha256=<hex>".
+	SignatureHeader = "X-TaskPixie-Signature"
+	EventHeader     = "X-TaskPixie-Event"
+	DeliveryHeader  = "X-TaskPixie-Delivery"
+)
+
+// HttpWebhookSender implements WebhookSender over plain HTTP.
+// Receivers are only reached on public addresses, checked once the name is resolved, and redirects aren't followed,
+// so a webhook can't be used to reach the server itself or its network.
+type HttpWebhookSender struct /* implements WebhookSender */ {
+	client *http.Client
+}
+
+// NewHttpWebhookSender creates the sender, allowPrivateNetworks lets deliveries reach internal addresses
+// and is only meant for local development and tests.
+func NewHttpWebhookSender(timeout time.Duration, allowPrivateNetworks bool) webhook.WebhookSender {
+	dialer := &net.Dialer{Timeout: timeout}
+	if !allowPrivateNetworks {
+		dialer.Control = refuseInternalAddress
+	}
+
+	return &HttpWebhookSender{
+		client: &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				// No proxy, the address dialed must be the receiver's for the check to hold
+				Proxy:                 nil,
+				DialContext:           dialer.DialContext,
+				ForceAttemptHTTP2:     true,
+				MaxIdleConns:          100,
+				IdleConnTimeout:       90 * time.Second,
+				TLSHandshakeTimeout:   timeout,
+				ExpectContinueTimeout: time.Second,
+			},
+			CheckRedirect: func(_ *http.Request, _ []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// refuseInternalAddress is called with the resolved address before connecting, so names resolving to
+// internal addresses are refused as well.
+func refuseInternalAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !webhook.IsPublicAddress(ip) {
+		return fmt.Errorf("receiver address %s is not public", host)
+	}
+
+	return nil
+}
+
+// Sign computes the signature of the body, receivers should compare it with SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *HttpWebhookSender) Send(delivery *entity.WebhookDelivery) *entity.WebhookResponse {
+	body := []byte(delivery.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return &entity.WebhookResponse{Error: err.Error()}
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "TaskPixie-Webhook")
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, body))
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, delivery.Id)
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return &entity.WebhookResponse{Error: err.Error()}
+	}
+	defer res.Body.Close()
+
+	// Only the status is kept, the body is the receiver's business
+	return &entity.WebhookResponse{
+		StatusCode: res.StatusCode,
+	}
+}
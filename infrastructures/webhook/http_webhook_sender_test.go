@@ -0,0 +1,139 @@
+package webhook_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/infrastructures/webhook"
+)
+
+func TestHttpWebhookSender(t *testing.T) {
+	sender := webhook.NewHttpWebhookSender(time.Second, true)
+	payload := `{"event":"task.created","projectId":"project-1","data":{}}`
+
+	t.Run("Should send signed payload to the receiver", func(t *testing.T) {
+		// Arrange
+		var receivedBody []byte
+		var receivedHeader http.Header
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			receivedBody, _ = io.ReadAll(r.Body)
+			receivedHeader = r.Header.Clone()
+
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte("ok"))
+		}))
+		defer receiver.Close()
+
+		delivery := &entity.WebhookDelivery{
+			Id:      "delivery-1",
+			Event:   entity.WebhookEventTaskCreated,
+			Payload: payload,
+			Url:     receiver.URL,
+			Secret:  "secret",
+		}
+
+		// Action
+		response := sender.Send(delivery)
+
+		// Assert
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.Empty(t, response.Error)
+		assert.Equal(t, payload, string(receivedBody))
+		assert.Equal(t, webhook.Sign("secret", receivedBody), receivedHeader.Get(webhook.SignatureHeader))
+		assert.Equal(t, entity.WebhookEventTaskCreated, receivedHeader.Get(webhook.EventHeader))
+		assert.Equal(t, "delivery-1", receivedHeader.Get(webhook.DeliveryHeader))
+		assert.Equal(t, "application/json", receivedHeader.Get("Content-Type"))
+	})
+
+	t.Run("Should record the response when receiver fails", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("boom"))
+		}))
+		defer receiver.Close()
+
+		delivery := &entity.WebhookDelivery{Payload: payload, Url: receiver.URL, Secret: "secret"}
+
+		// Action
+		response := sender.Send(delivery)
+
+		// Assert
+		assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+		assert.Empty(t, response.Error)
+	})
+
+	t.Run("Should return error instead of panicking when receiver is unreachable", func(t *testing.T) {
+		// Arrange
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := receiver.URL
+		receiver.Close()
+
+		delivery := &entity.WebhookDelivery{Payload: payload, Url: url, Secret: "secret"}
+
+		// Action and Assert
+		var response *entity.WebhookResponse
+		assert.NotPanics(t, func() {
+			response = sender.Send(delivery)
+		})
+		assert.Zero(t, response.StatusCode)
+		assert.NotEmpty(t, response.Error)
+	})
+
+	t.Run("Should not follow redirects", func(t *testing.T) {
+		// Arrange
+		followed := false
+		target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			followed = true
+		}))
+		defer target.Close()
+
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+		}))
+		defer receiver.Close()
+
+		delivery := &entity.WebhookDelivery{Payload: payload, Url: receiver.URL, Secret: "secret"}
+
+		// Action
+		response := sender.Send(delivery)
+
+		// Assert
+		assert.Equal(t, http.StatusTemporaryRedirect, response.StatusCode)
+		assert.False(t, followed)
+	})
+
+	t.Run("Should refuse internal addresses unless private networks are allowed", func(t *testing.T) {
+		// Arrange
+		reached := false
+		receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			reached = true
+		}))
+		defer receiver.Close()
+
+		publicSender := webhook.NewHttpWebhookSender(time.Second, false)
+		delivery := &entity.WebhookDelivery{Payload: payload, Url: receiver.URL, Secret: "secret"}
+
+		// Action
+		response := publicSender.Send(delivery)
+
+		// Assert
+		assert.Zero(t, response.StatusCode)
+		assert.Contains(t, response.Error, "not public")
+		assert.False(t, reached)
+	})
+
+	t.Run("Signature should depend on the secret", func(t *testing.T) {
+		body := []byte(payload)
+
+		assert.Equal(t, webhook.Sign("secret", body), webhook.Sign("secret", body))
+		assert.NotEqual(t, webhook.Sign("secret", body), webhook.Sign("other", body))
+		assert.Contains(t, webhook.Sign("secret", body), "sha256=")
+	})
+}
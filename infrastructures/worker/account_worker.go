@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"context"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"log"
+	"time"
+)
+
+// StartAccountWorker builds the pending exports, removes the expired ones and deletes the accounts
+// whose grace period is over, on every interval until ctx is done.
+func StartAccountWorker(ctx context.Context, useCase *use_case.AccountUseCase, interval time.Duration) {
+	go run(ctx, "account", interval, func() {
+		// Keep building while there are still pending exports
+		for {
+			if useCase.ExecuteBuildPendingExports() == 0 {
+				break
+			}
+		}
+
+		if removed := useCase.ExecuteRemoveExpiredExports(); removed > 0 {
+			log.Printf("account_worker: removed %d expired exports", removed)
+		}
+		if deleted := useCase.ExecuteDeleteDueAccounts(); deleted > 0 {
+			log.Printf("account_worker: deleted %d accounts", deleted)
+		}
+	})
+}
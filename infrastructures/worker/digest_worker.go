@@ -0,0 +1,14 @@
+package worker
+
+import (
+	"context"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"time"
+)
+
+// StartDigestWorker sends the due email digests on every interval until ctx is done.
+func StartDigestWorker(ctx context.Context, useCase *use_case.DigestUseCase, interval time.Duration) {
+	go run(ctx, "digest", interval, func() {
+		useCase.ExecuteSendDueDigests(time.Now())
+	})
+}
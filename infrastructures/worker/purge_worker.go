@@ -0,0 +1,18 @@
+package worker
+
+import (
+	"context"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"log"
+	"time"
+)
+
+// StartPurgeWorker permanently deletes the expired trash on every interval until ctx is done.
+func StartPurgeWorker(ctx context.Context, useCase *use_case.TrashUseCase, interval time.Duration) {
+	go run(ctx, "purge", interval, func() {
+		projects, tasks := useCase.ExecutePurge()
+		if projects+tasks > 0 {
+			log.Printf("purge_worker: purged %d projects and %d tasks", projects, tasks)
+		}
+	})
+}
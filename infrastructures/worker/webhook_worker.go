@@ -0,0 +1,43 @@
+package worker
+
+import (
+	"context"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"log"
+	"time"
+)
+
+// StartWebhookWorker sends the due webhook deliveries on every interval until ctx is done.
+func StartWebhookWorker(ctx context.Context, useCase *use_case.WebhookUseCase, interval time.Duration) {
+	go run(ctx, "webhook", interval, func() {
+		// Keep draining while there are still due deliveries
+		for {
+			if useCase.ExecuteProcessDeliveries() == 0 {
+				return
+			}
+		}
+	})
+}
+
+// run calls job on every interval, a panicking job is logged and doesn't stop the worker.
+func run(ctx context.Context, name string, interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						log.Printf("%s_worker_err: %v", name, r)
+					}
+				}()
+
+				job()
+			}()
+		}
+	}
+}
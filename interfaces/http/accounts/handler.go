@@ -0,0 +1,105 @@
+package accounts
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type AccountHandler struct {
+	useCase *use_case.AccountUseCase
+}
+
+func NewAccountHandler(useCase *use_case.AccountUseCase) *AccountHandler {
+	return &AccountHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *AccountHandler) RequestExport(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+
+	export, queued := h.useCase.ExecuteRequestExport(id)
+
+	if export.Status == entity.AccountExportReady {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "success",
+			"data":    export,
+			"message": fmt.Sprintf("Your data is ready, download it from /users/%s/export/download!", id),
+		})
+	}
+
+	message := "Your data is still being exported!"
+	if queued {
+		message = "Exporting your data, it'll be ready to download soon!"
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status":  "success",
+		"data":    export,
+		"message": message,
+	})
+}
+
+func (h *AccountHandler) DownloadExport(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+
+	archive := h.useCase.ExecuteDownloadExport(id)
+
+	c.Attachment(fmt.Sprintf("taskpixie-%s.zip", id))
+	c.Set(fiber.HeaderContentType, "application/zip")
+
+	return c.Status(fiber.StatusOK).Send(archive)
+}
+
+func (h *AccountHandler) DeleteAccount(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+	var payload entity.DeleteUserPayload
+	_ = c.BodyParser(&payload)
+
+	deletion := h.useCase.ExecuteScheduleDeletion(id, &payload)
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status":  "success",
+		"data":    deletion,
+		"message": "Your account will be deleted, you can still restore it until then!",
+	})
+}
+
+func (h *AccountHandler) RestoreAccount(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+
+	h.useCase.ExecuteCancelDeletion(id)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully restored your account!",
+	})
+}
+
+func selfUserId(c *fiber.Ctx) (string, error) {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	if loggedUserId != id {
+		return "", fiber.NewError(
+			fiber.StatusForbidden,
+			"You are not able to manage other user's account!",
+		)
+	}
+
+	return id, nil
+}
@@ -0,0 +1,20 @@
+package accounts
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewAccountRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.AccountUseCase,
+) {
+	accountHandler := NewAccountHandler(useCase)
+
+	app.Get("/users/:id/export", jwtMiddleware.GuardJWT, accountHandler.RequestExport)
+	app.Get("/users/:id/export/download", jwtMiddleware.GuardJWT, accountHandler.DownloadExport)
+	app.Delete("/users/:id", jwtMiddleware.GuardJWT, accountHandler.DeleteAccount)
+	app.Post("/users/:id/restore", jwtMiddleware.GuardJWT, accountHandler.RestoreAccount)
+}
@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type AdminHandler struct {
+	useCase *use_case.AdminUseCase
+}
+
+func NewAdminHandler(useCase *use_case.AdminUseCase) *AdminHandler {
+	return &AdminHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *AdminHandler) GetUsers(c *fiber.Ctx) error {
+	var filter entity.AdminUserFilter
+	_ = c.QueryParser(&filter)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	users := h.useCase.ExecuteGetUsers(&filter, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   users,
+	})
+}
+
+func (h *AdminHandler) DisableUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDisableUser(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully disabling the user, they've been signed out everywhere!",
+	})
+}
+
+func (h *AdminHandler) EnableUser(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteEnableUser(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully enabling the user!",
+	})
+}
+
+func (h *AdminHandler) ForcePasswordReset(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteForcePasswordReset(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully forcing a password reset, the link has been emailed to the user!",
+	})
+}
+
+func (h *AdminHandler) GetStats(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	stats := h.useCase.ExecuteGetStats(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   stats,
+	})
+}
+
+func (h *AdminHandler) GetOrphanedFiles(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	orphaned := h.useCase.ExecuteGetOrphanedFiles(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   orphaned,
+	})
+}
+
+func (h *AdminHandler) PurgeOrphanedFiles(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	purged := h.useCase.ExecutePurgeOrphanedFiles(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"data":    purged,
+		"message": fmt.Sprintf("Successfully removing %d orphaned files!", len(purged.Files)),
+	})
+}
+
+func (h *AdminHandler) GetAuditLogs(c *fiber.Ctx) error {
+	var filter entity.AuditLogFilter
+	_ = c.QueryParser(&filter)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	logs := h.useCase.ExecuteGetAuditLogs(&filter, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   logs,
+	})
+}
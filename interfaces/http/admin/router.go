@@ -0,0 +1,24 @@
+package admin
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewAdminRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.AdminUseCase,
+) {
+	adminHandler := NewAdminHandler(useCase)
+
+	app.Get("/admin/users", jwtMiddleware.GuardJWT, adminHandler.GetUsers)
+	app.Post("/admin/users/:id/disable", jwtMiddleware.GuardJWT, adminHandler.DisableUser)
+	app.Post("/admin/users/:id/enable", jwtMiddleware.GuardJWT, adminHandler.EnableUser)
+	app.Post("/admin/users/:id/password-reset", jwtMiddleware.GuardJWT, adminHandler.ForcePasswordReset)
+	app.Get("/admin/stats", jwtMiddleware.GuardJWT, adminHandler.GetStats)
+	app.Get("/admin/files/orphaned", jwtMiddleware.GuardJWT, adminHandler.GetOrphanedFiles)
+	app.Delete("/admin/files/orphaned", jwtMiddleware.GuardJWT, adminHandler.PurgeOrphanedFiles)
+	app.Get("/admin/audit-logs", jwtMiddleware.GuardJWT, adminHandler.GetAuditLogs)
+}
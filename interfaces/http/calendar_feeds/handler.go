@@ -0,0 +1,78 @@
+package calendar_feeds
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/ical"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/interfaces/http/etag"
+)
+
+type CalendarFeedHandler struct {
+	useCase *use_case.CalendarFeedUseCase
+}
+
+func NewCalendarFeedHandler(useCase *use_case.CalendarFeedUseCase) *CalendarFeedHandler {
+	return &CalendarFeedHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *CalendarFeedHandler) AddCalendarFeed(c *fiber.Ctx) error {
+	var payload entity.CalendarFeedPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	feedId, token := h.useCase.ExecuteAddCalendarFeed(&payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data": fiber.Map{
+			"id":    feedId,
+			"token": token,
+			"url":   c.BaseURL() + "/calendars/" + token + ".ics",
+		},
+		"message": "Calendar feed created, keep its URL secret!",
+	})
+}
+
+func (h *CalendarFeedHandler) GetCalendarFeeds(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	feeds := h.useCase.ExecuteGetCalendarFeeds(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   feeds,
+	})
+}
+
+func (h *CalendarFeedHandler) DeleteCalendarFeed(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteCalendarFeed(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Calendar feed revoked successfully!",
+	})
+}
+
+func (h *CalendarFeedHandler) GetCalendar(c *fiber.Ctx) error {
+	token := c.Params("token")
+	var filter entity.CalendarFeedFilter
+	_ = c.QueryParser(&filter)
+
+	calendar := h.useCase.ExecuteGetCalendar(token, &filter)
+
+	// Calendar apps poll the feed, they should check with us before using their copy
+	c.Set(fiber.HeaderCacheControl, "private, no-cache")
+	if etag.ContentNotModified(c, calendar) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, ical.ContentType)
+	return c.Status(fiber.StatusOK).Send(calendar)
+}
@@ -0,0 +1,23 @@
+package calendar_feeds
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewCalendarFeedRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.CalendarFeedUseCase,
+) {
+	calendarFeedHandler := NewCalendarFeedHandler(useCase)
+
+	// Feeds of the user
+	app.Post("/calendar-feeds", jwtMiddleware.GuardJWT, calendarFeedHandler.AddCalendarFeed)
+	app.Get("/calendar-feeds", jwtMiddleware.GuardJWT, calendarFeedHandler.GetCalendarFeeds)
+	app.Delete("/calendar-feeds/:id", jwtMiddleware.GuardJWT, calendarFeedHandler.DeleteCalendarFeed)
+
+	// Subscriptions of calendar apps, the secret token authenticates them
+	app.Get("/calendars/:token.ics", calendarFeedHandler.GetCalendar)
+}
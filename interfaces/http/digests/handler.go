@@ -0,0 +1,77 @@
+package digests
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type DigestHandler struct {
+	useCase *use_case.DigestUseCase
+}
+
+func NewDigestHandler(useCase *use_case.DigestUseCase) *DigestHandler {
+	return &DigestHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *DigestHandler) GetSubscription(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+
+	subscription := h.useCase.ExecuteGetSubscription(id)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   subscription,
+	})
+}
+
+func (h *DigestHandler) Subscribe(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+
+	var payload entity.DigestSubscriptionPayload
+	_ = c.BodyParser(&payload)
+
+	h.useCase.ExecuteSubscribe(id, &payload)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully subscribed to the digest!",
+	})
+}
+
+func (h *DigestHandler) Unsubscribe(c *fiber.Ctx) error {
+	id, err := selfUserId(c)
+	if err != nil {
+		return err
+	}
+
+	h.useCase.ExecuteUnsubscribe(id)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully unsubscribed from the digest!",
+	})
+}
+
+// selfUserId makes sure users only manage their own subscription.
+func selfUserId(c *fiber.Ctx) (string, error) {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	if loggedUserId != id {
+		return "", fiber.NewError(
+			fiber.StatusForbidden,
+			"You are not able to manage other user's digest!",
+		)
+	}
+
+	return id, nil
+}
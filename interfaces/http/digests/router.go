@@ -0,0 +1,19 @@
+package digests
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewDigestRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.DigestUseCase,
+) {
+	digestHandler := NewDigestHandler(useCase)
+
+	app.Get("/users/:id/digest", jwtMiddleware.GuardJWT, digestHandler.GetSubscription)
+	app.Put("/users/:id/digest", jwtMiddleware.GuardJWT, digestHandler.Subscribe)
+	app.Delete("/users/:id/digest", jwtMiddleware.GuardJWT, digestHandler.Unsubscribe)
+}
@@ -0,0 +1,120 @@
+// Package etag handles the ETag of versioned resources and the conditional requests made with them.
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+	"strings"
+)
+
+// Format returns the ETag of the version of a resource.
+func Format(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// Set sets the ETag header of the response to the version.
+func Set(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, Format(version))
+}
+
+// IfMatch returns the versions the request is conditioned on, none when it isn't (no If-Match or "*").
+// Weak or invalid ETags never match, so they're returned as an impossible version.
+func IfMatch(c *fiber.Ctx) []int {
+	return ParseIfMatch(c.Get(fiber.HeaderIfMatch))
+}
+
+// ParseIfMatch parses an If-Match header, see IfMatch.
+func ParseIfMatch(header string) []int {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return nil
+	}
+
+	var versions []int
+	for _, tag := range strings.Split(header, ",") {
+		// If-Match uses the strong comparison
+		tag = strings.TrimSpace(tag)
+		version, ok := parse(tag)
+		if !ok || strings.HasPrefix(tag, "W/") {
+			version = -1
+		}
+		versions = append(versions, version)
+	}
+
+	return versions
+}
+
+// FormatRepresentation returns the ETag of a version of a resource whose representation holds more than the resource,
+// like the names of related resources or totals. It changes with either of them, and still tells the version to If-Match.
+func FormatRepresentation(version int, content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return fmt.Sprintf(`"%d-%s"`, version, hex.EncodeToString(sum[:16]))
+}
+
+// RepresentationNotModified sets the ETag of the response from the version and the representation sent,
+// and tells whether the client's copy (If-None-Match) is still the same.
+func RepresentationNotModified(c *fiber.Ctx, version int, representation interface{}) bool {
+	content, err := json.Marshal(representation)
+	if err != nil {
+		panic(fmt.Errorf("etag_err: marshal representation: %v", err))
+	}
+
+	tag := FormatRepresentation(version, content)
+	c.Set(fiber.HeaderETag, tag)
+
+	return MatchTagIfNoneMatch(c.Get(fiber.HeaderIfNoneMatch), tag)
+}
+
+// FormatContent returns the ETag of a resource that isn't versioned, derived from its representation.
+func FormatContent(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ContentNotModified sets the ETag of the response from its content,
+// and tells whether the client's copy (If-None-Match) is still the same.
+func ContentNotModified(c *fiber.Ctx, content []byte) bool {
+	tag := FormatContent(content)
+	c.Set(fiber.HeaderETag, tag)
+
+	return MatchTagIfNoneMatch(c.Get(fiber.HeaderIfNoneMatch), tag)
+}
+
+// MatchTagIfNoneMatch tells whether an If-None-Match header matches the ETag, see ContentNotModified.
+func MatchTagIfNoneMatch(header string, tag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		// If-None-Match uses the weak comparison
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parse returns the version of an ETag, weak ones and those of a representation included.
+func parse(tag string) (int, bool) {
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+		return 0, false
+	}
+
+	value, _, _ := strings.Cut(tag[1:len(tag)-1], "-")
+	version, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, false
+	}
+
+	return version, true
+}
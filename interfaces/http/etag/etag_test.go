@@ -0,0 +1,58 @@
+package etag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/interfaces/http/etag"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"7"`, etag.Format(7))
+}
+
+func TestParseIfMatch(t *testing.T) {
+	t.Run("Should not condition the request without ETags", func(t *testing.T) {
+		assert.Nil(t, etag.ParseIfMatch(""))
+		assert.Nil(t, etag.ParseIfMatch(" * "))
+	})
+
+	t.Run("Should return the versions of the ETags", func(t *testing.T) {
+		assert.Equal(t, []int{3}, etag.ParseIfMatch(`"3"`))
+		assert.Equal(t, []int{3, 4}, etag.ParseIfMatch(`"3", "4"`))
+		assert.Equal(t, []int{5}, etag.ParseIfMatch(etag.FormatRepresentation(5, []byte(`{"timeSpent":30}`))))
+	})
+
+	t.Run("Should never match weak or invalid ETags", func(t *testing.T) {
+		assert.Equal(t, []int{-1}, etag.ParseIfMatch(`W/"3"`))
+		assert.Equal(t, []int{-1, 2}, etag.ParseIfMatch(`3, "2"`))
+		assert.Equal(t, []int{-1}, etag.ParseIfMatch(`"abc"`))
+		assert.Equal(t, []int{-1}, etag.ParseIfMatch(`"-3"`))
+	})
+}
+
+func TestFormatContent(t *testing.T) {
+	first := etag.FormatContent([]byte("BEGIN:VCALENDAR"))
+
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, first)
+	assert.Equal(t, first, etag.FormatContent([]byte("BEGIN:VCALENDAR")))
+	assert.NotEqual(t, first, etag.FormatContent([]byte("BEGIN:VCALENDAR\r\n")))
+}
+
+func TestFormatRepresentation(t *testing.T) {
+	first := etag.FormatRepresentation(4, []byte(`{"timeSpent":30}`))
+
+	assert.Regexp(t, `^"4-[0-9a-f]{32}"$`, first)
+	assert.Equal(t, first, etag.FormatRepresentation(4, []byte(`{"timeSpent":30}`)))
+	assert.NotEqual(t, first, etag.FormatRepresentation(4, []byte(`{"timeSpent":31}`)))
+	assert.NotEqual(t, first, etag.FormatRepresentation(5, []byte(`{"timeSpent":30}`)))
+}
+
+func TestMatchTagIfNoneMatch(t *testing.T) {
+	assert.True(t, etag.MatchTagIfNoneMatch(`"abc"`, `"abc"`))
+	assert.True(t, etag.MatchTagIfNoneMatch(`W/"abc"`, `"abc"`))
+	assert.True(t, etag.MatchTagIfNoneMatch(`"x", "abc"`, `"abc"`))
+	assert.True(t, etag.MatchTagIfNoneMatch(`*`, `"abc"`))
+	assert.False(t, etag.MatchTagIfNoneMatch(`"abd"`, `"abc"`))
+	assert.False(t, etag.MatchTagIfNoneMatch(``, `"abc"`))
+}
@@ -0,0 +1,73 @@
+package imports
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"strings"
+)
+
+type BoardImportHandler struct {
+	useCase *use_case.BoardImportUseCase
+}
+
+func NewBoardImportHandler(useCase *use_case.BoardImportUseCase) *BoardImportHandler {
+	return &BoardImportHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *BoardImportHandler) PreviewBoardImport(c *fiber.Ctx) error {
+	id := c.Params("id")
+	payload := parseBoardImportPayload(c)
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	report := h.useCase.ExecutePreviewBoardImport(id, payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"data":    report,
+		"message": "Board read successfully, nothing was imported yet!",
+	})
+}
+
+func (h *BoardImportHandler) CommitBoardImport(c *fiber.Ctx) error {
+	id := c.Params("id")
+	payload := parseBoardImportPayload(c)
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	report := h.useCase.ExecuteCommitBoardImport(id, payload, loggedUserId)
+
+	if report.Failed > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  "fail",
+			"data":    report,
+			"message": "Some cards are invalid, nothing was imported!",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    report,
+		"message": "Board imported successfully!",
+	})
+}
+
+// parseBoardImportPayload reads the multipart form, a missing file is left to the validation.
+func parseBoardImportPayload(c *fiber.Ctx) *entity.BoardImportPayload {
+	var err error
+	var payload entity.BoardImportPayload
+	_ = c.BodyParser(&payload)
+
+	payload.File, err = c.FormFile("file")
+	if err != nil {
+		if !strings.Contains(err.Error(), "there is no uploaded") && !strings.Contains(err.Error(), "multipart") {
+			panic(fmt.Errorf("upload board import: %v", err))
+		}
+
+		payload.File = nil
+	}
+
+	return &payload
+}
@@ -0,0 +1,18 @@
+package imports
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewBoardImportRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.BoardImportUseCase,
+) {
+	boardImportHandler := NewBoardImportHandler(useCase)
+
+	app.Post("/projects/:id/imports/preview", jwtMiddleware.GuardJWT, boardImportHandler.PreviewBoardImport)
+	app.Post("/projects/:id/imports/commit", jwtMiddleware.GuardJWT, boardImportHandler.CommitBoardImport)
+}
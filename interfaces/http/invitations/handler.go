@@ -0,0 +1,101 @@
+package invitations
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type InvitationHandler struct {
+	useCase *use_case.InvitationUseCase
+}
+
+func NewInvitationHandler(useCase *use_case.InvitationUseCase) *InvitationHandler {
+	return &InvitationHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *InvitationHandler) Invite(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	var payload entity.InvitationPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	invitationId := h.useCase.ExecuteInvite(projectId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    invitationId,
+		"message": "Invitation sent successfully!",
+	})
+}
+
+func (h *InvitationHandler) GetProjectInvitations(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	invitations := h.useCase.ExecuteGetProjectInvitations(projectId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   invitations,
+	})
+}
+
+func (h *InvitationHandler) RevokeInvitation(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRevokeInvitation(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Invitation revoked successfully!",
+	})
+}
+
+func (h *InvitationHandler) GetMyInvitations(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	invitations := h.useCase.ExecuteGetMyInvitations(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   invitations,
+	})
+}
+
+func (h *InvitationHandler) AcceptInvitation(c *fiber.Ctx) error {
+	return h.respond(c, true)
+}
+
+func (h *InvitationHandler) DeclineInvitation(c *fiber.Ctx) error {
+	return h.respond(c, false)
+}
+
+// respond answers the invitation by ID, or by the emailed token when there is no ID.
+func (h *InvitationHandler) respond(c *fiber.Ctx, accept bool) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	if id != "" {
+		h.useCase.ExecuteRespondInvitation(id, loggedUserId, accept)
+	} else {
+		var payload entity.InvitationTokenPayload
+		_ = c.BodyParser(&payload)
+
+		h.useCase.ExecuteRespondInvitationByToken(payload.Token, loggedUserId, accept)
+	}
+
+	message := "Invitation declined!"
+	if accept {
+		message = "Invitation accepted, welcome to the project!"
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": message,
+	})
+}
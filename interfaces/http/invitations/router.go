@@ -0,0 +1,27 @@
+package invitations
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewInvitationRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.InvitationUseCase,
+) {
+	invitationHandler := NewInvitationHandler(useCase)
+
+	// Inviter side
+	app.Post("/projects/:projectId/invitations", jwtMiddleware.GuardJWT, invitationHandler.Invite)
+	app.Get("/projects/:projectId/invitations", jwtMiddleware.GuardJWT, invitationHandler.GetProjectInvitations)
+	app.Delete("/invitations/:id", jwtMiddleware.GuardJWT, invitationHandler.RevokeInvitation)
+
+	// Invitee side, answering with the emailed token or from the list
+	app.Get("/invitations", jwtMiddleware.GuardJWT, invitationHandler.GetMyInvitations)
+	app.Post("/invitations/accept", jwtMiddleware.GuardJWT, invitationHandler.AcceptInvitation)
+	app.Post("/invitations/decline", jwtMiddleware.GuardJWT, invitationHandler.DeclineInvitation)
+	app.Post("/invitations/:id/accept", jwtMiddleware.GuardJWT, invitationHandler.AcceptInvitation)
+	app.Post("/invitations/:id/decline", jwtMiddleware.GuardJWT, invitationHandler.DeclineInvitation)
+}
@@ -0,0 +1,76 @@
+package middlewares
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/interfaces/http/timezone"
+	"strings"
+	"time"
+)
+
+// TimeZoneMiddleware renders the times of JSON responses in the time zone of the timeZone query.
+// Rendered responses carry no ETag, the versions of the resources tell nothing about the zone they're rendered in.
+// Using UserUseCase to read the time zone of the logged user's profile, asked with timeZone=local.
+type TimeZoneMiddleware struct {
+	userUseCase *use_case.UserUseCase
+}
+
+func NewTimeZoneMiddleware(userUseCase *use_case.UserUseCase) *TimeZoneMiddleware {
+	return &TimeZoneMiddleware{userUseCase}
+}
+
+func (m *TimeZoneMiddleware) RenderTimeZone(c *fiber.Ctx) error {
+	name := c.Query(timezone.Query)
+	if name == "" {
+		return c.Next()
+	}
+
+	// Named zones are checked before anything is done
+	var location *time.Location
+	if name != timezone.Local {
+		var err error
+		if location, err = time.LoadLocation(name); err != nil || name == "Local" {
+			return fiber.NewError(fiber.StatusBadRequest, "Unknown time zone!")
+		}
+	}
+
+	// The handler would answer 304 to a copy rendered in another zone
+	c.Request().Header.Del(fiber.HeaderIfNoneMatch)
+
+	if err := c.Next(); err != nil {
+		return err
+	}
+
+	// The logged user is only known once the route guarded it, guests stay in UTC
+	if location == nil {
+		userInfo, ok := c.Locals("userInfo").(entity.User)
+		if !ok {
+			return nil
+		}
+
+		// The same URL is rendered differently for every user
+		c.Vary(fiber.HeaderAuthorization)
+
+		zone := m.userUseCase.ExecuteGetUserById(userInfo.Id).TimeZone
+		location, _ = time.LoadLocation(zone)
+		if location == nil {
+			location = time.UTC
+		}
+	}
+
+	contentType := string(c.Response().Header.ContentType())
+	if !strings.HasPrefix(contentType, fiber.MIMEApplicationJSON) || len(c.Response().Body()) == 0 {
+		return nil
+	}
+
+	body, err := timezone.Render(c.Response().Body(), location)
+	if err != nil {
+		return fmt.Errorf("render time zone: %v", err)
+	}
+	c.Response().SetBodyRaw(body)
+	c.Response().Header.Del(fiber.HeaderETag)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package milestones
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type MilestoneHandler struct {
+	useCase *use_case.MilestoneUseCase
+}
+
+func NewMilestoneHandler(useCase *use_case.MilestoneUseCase) *MilestoneHandler {
+	return &MilestoneHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *MilestoneHandler) AddMilestone(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	var payload entity.MilestonePayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	milestoneId := h.useCase.ExecuteAddMilestone(projectId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    milestoneId,
+		"message": "Milestone created successfully!",
+	})
+}
+
+func (h *MilestoneHandler) GetMilestones(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	milestones := h.useCase.ExecuteGetMilestonesByProject(projectId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   milestones,
+	})
+}
+
+func (h *MilestoneHandler) GetMilestoneById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	milestone := h.useCase.ExecuteGetMilestoneById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   milestone,
+	})
+}
+
+func (h *MilestoneHandler) UpdateMilestoneById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.MilestonePayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateMilestoneById(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Milestone updated successfully!",
+	})
+}
+
+func (h *MilestoneHandler) DeleteMilestoneById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteMilestoneById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Milestone deleted successfully!",
+	})
+}
+
+func (h *MilestoneHandler) StartMilestone(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteStartMilestone(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Milestone started!",
+	})
+}
+
+func (h *MilestoneHandler) CloseMilestone(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.CloseMilestonePayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	nextMilestoneId, carried := h.useCase.ExecuteCloseMilestone(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data": fiber.Map{
+			"nextMilestoneId": nextMilestoneId,
+			"carriedTasks":    carried,
+		},
+		"message": "Milestone closed!",
+	})
+}
+
+func (h *MilestoneHandler) GetSprintReport(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	report := h.useCase.ExecuteGetSprintReport(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   report,
+	})
+}
@@ -0,0 +1,27 @@
+package milestones
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewMilestoneRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.MilestoneUseCase,
+) {
+	milestoneHandler := NewMilestoneHandler(useCase)
+
+	// Milestones of a project
+	app.Post("/projects/:projectId/milestones", jwtMiddleware.GuardJWT, milestoneHandler.AddMilestone)
+	app.Get("/projects/:projectId/milestones", jwtMiddleware.GuardJWT, milestoneHandler.GetMilestones)
+
+	// Milestone
+	app.Get("/milestones/:id", jwtMiddleware.GuardJWT, milestoneHandler.GetMilestoneById)
+	app.Put("/milestones/:id", jwtMiddleware.GuardJWT, milestoneHandler.UpdateMilestoneById)
+	app.Delete("/milestones/:id", jwtMiddleware.GuardJWT, milestoneHandler.DeleteMilestoneById)
+	app.Post("/milestones/:id/start", jwtMiddleware.GuardJWT, milestoneHandler.StartMilestone)
+	app.Post("/milestones/:id/close", jwtMiddleware.GuardJWT, milestoneHandler.CloseMilestone)
+	app.Get("/milestones/:id/report", jwtMiddleware.GuardJWT, milestoneHandler.GetSprintReport)
+}
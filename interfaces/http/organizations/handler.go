@@ -0,0 +1,162 @@
+package organizations
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type OrganizationHandler struct {
+	useCase *use_case.OrganizationUseCase
+}
+
+func NewOrganizationHandler(useCase *use_case.OrganizationUseCase) *OrganizationHandler {
+	return &OrganizationHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *OrganizationHandler) AddOrganization(c *fiber.Ctx) error {
+	var payload entity.OrganizationPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	organizationId := h.useCase.ExecuteAddOrganization(&payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    organizationId,
+		"message": "Organization created successfully!",
+	})
+}
+
+func (h *OrganizationHandler) GetOrganizations(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	organizations := h.useCase.ExecuteGetOrganizations(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   organizations,
+	})
+}
+
+func (h *OrganizationHandler) GetOrganizationById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	organization := h.useCase.ExecuteGetOrganizationById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   organization,
+	})
+}
+
+func (h *OrganizationHandler) UpdateOrganizationById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.OrganizationPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateOrganizationById(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Organization updated successfully!",
+	})
+}
+
+func (h *OrganizationHandler) DeleteOrganizationById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteOrganizationById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Organization deleted successfully!",
+	})
+}
+
+func (h *OrganizationHandler) GetOrganizationProjects(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	projects := h.useCase.ExecuteGetOrganizationProjects(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   projects,
+	})
+}
+
+func (h *OrganizationHandler) GetMembers(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	members := h.useCase.ExecuteGetOrganizationMembers(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   members,
+	})
+}
+
+func (h *OrganizationHandler) AddMember(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.OrganizationMemberPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteAddOrganizationMember(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member added successfully!",
+	})
+}
+
+func (h *OrganizationHandler) UpdateMemberRole(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.OrganizationMemberPayload
+	_ = c.BodyParser(&payload)
+	payload.UserId = c.Params("userId")
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateOrganizationMemberRole(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member role updated successfully!",
+	})
+}
+
+func (h *OrganizationHandler) RemoveMember(c *fiber.Ctx) error {
+	id := c.Params("id")
+	memberId := c.Params("userId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRemoveOrganizationMember(id, memberId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member removed successfully!",
+	})
+}
+
+func (h *OrganizationHandler) LeaveOrganization(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteLeaveOrganization(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "You left the organization!",
+	})
+}
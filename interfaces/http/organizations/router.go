@@ -0,0 +1,29 @@
+package organizations
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewOrganizationRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.OrganizationUseCase,
+) {
+	organizationHandler := NewOrganizationHandler(useCase)
+
+	app.Post("/organizations", jwtMiddleware.GuardJWT, organizationHandler.AddOrganization)
+	app.Get("/organizations", jwtMiddleware.GuardJWT, organizationHandler.GetOrganizations)
+	app.Get("/organizations/:id", jwtMiddleware.GuardJWT, organizationHandler.GetOrganizationById)
+	app.Put("/organizations/:id", jwtMiddleware.GuardJWT, organizationHandler.UpdateOrganizationById)
+	app.Delete("/organizations/:id", jwtMiddleware.GuardJWT, organizationHandler.DeleteOrganizationById)
+	app.Get("/organizations/:id/projects", jwtMiddleware.GuardJWT, organizationHandler.GetOrganizationProjects)
+
+	// Member directory
+	app.Get("/organizations/:id/members", jwtMiddleware.GuardJWT, organizationHandler.GetMembers)
+	app.Post("/organizations/:id/members", jwtMiddleware.GuardJWT, organizationHandler.AddMember)
+	app.Patch("/organizations/:id/members/:userId", jwtMiddleware.GuardJWT, organizationHandler.UpdateMemberRole)
+	app.Delete("/organizations/:id/members/:userId", jwtMiddleware.GuardJWT, organizationHandler.RemoveMember)
+	app.Post("/organizations/:id/leave", jwtMiddleware.GuardJWT, organizationHandler.LeaveOrganization)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/wisle25/task-pixie/applications/use_case"
 	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/interfaces/http/etag"
 )
 
 type ProjectHandler struct {
@@ -33,8 +34,12 @@ func (h *ProjectHandler) AddProject(c *fiber.Ctx) error {
 
 func (h *ProjectHandler) GetProjectById(c *fiber.Ctx) error {
 	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
 
-	project := h.useCase.ExecuteGetProjectById(id)
+	project := h.useCase.ExecuteGetProjectById(id, loggedUserId)
+	if etag.RepresentationNotModified(c, project.Version, project) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status": "success",
@@ -47,7 +52,23 @@ func (h *ProjectHandler) UpdateProjectById(c *fiber.Ctx) error {
 	var payload entity.ProjectPayload
 	_ = c.BodyParser(&payload)
 
-	h.useCase.ExecuteUpdateProjectById(id, &payload)
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	version := h.useCase.ExecuteUpdateProjectById(id, &payload, loggedUserId, etag.IfMatch(c))
+	etag.Set(c, version)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Project updated successfully!",
+	})
+}
+
+func (h *ProjectHandler) PatchProjectById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	version := h.useCase.ExecutePatchProjectById(id, c.Body(), loggedUserId, etag.IfMatch(c))
+	etag.Set(c, version)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
@@ -57,8 +78,9 @@ func (h *ProjectHandler) UpdateProjectById(c *fiber.Ctx) error {
 
 func (h *ProjectHandler) DeleteProjectById(c *fiber.Ctx) error {
 	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
 
-	h.useCase.ExecuteDeleteProjectById(id)
+	h.useCase.ExecuteDeleteProjectById(id, loggedUserId, etag.IfMatch(c))
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
@@ -66,6 +88,30 @@ func (h *ProjectHandler) DeleteProjectById(c *fiber.Ctx) error {
 	})
 }
 
+func (h *ProjectHandler) ArchiveProjectById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteArchiveProjectById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Project archived successfully!",
+	})
+}
+
+func (h *ProjectHandler) UnarchiveProjectById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUnarchiveProjectById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Project unarchived successfully!",
+	})
+}
+
 func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
 	loggedUserId := c.Locals("userInfo").(entity.User).Id
 
@@ -77,13 +123,184 @@ func (h *ProjectHandler) GetProjects(c *fiber.Ctx) error {
 	})
 }
 
+func (h *ProjectHandler) GetProjectStats(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var filter entity.ProjectStatsFilter
+	_ = c.QueryParser(&filter)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	stats := h.useCase.ExecuteGetProjectStats(id, &filter, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   stats,
+	})
+}
+
 func (h *ProjectHandler) GetMembersProject(c *fiber.Ctx) error {
 	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
 
-	members := h.useCase.ExecuteGetProjectMembers(id)
+	members := h.useCase.ExecuteGetProjectMembers(id, loggedUserId)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status": "success",
 		"data":   members,
 	})
 }
+
+func (h *ProjectHandler) AddMember(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.MemberPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteAddMember(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member added successfully!",
+	})
+}
+
+func (h *ProjectHandler) UpdateMemberRole(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.MemberPayload
+	_ = c.BodyParser(&payload)
+	payload.UserId = c.Params("userId")
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateMemberRole(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member role updated successfully!",
+	})
+}
+
+func (h *ProjectHandler) RemoveMember(c *fiber.Ctx) error {
+	id := c.Params("id")
+	memberId := c.Params("userId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRemoveMember(id, memberId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member removed successfully!",
+	})
+}
+
+func (h *ProjectHandler) LeaveProject(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteLeaveProject(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "You left the project!",
+	})
+}
+
+func (h *ProjectHandler) TransferOwnership(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.TransferOwnershipPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteTransferOwnership(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Ownership transferred successfully!",
+	})
+}
+
+func (h *ProjectHandler) CloneProject(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.CloneProjectPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	projectId := h.useCase.ExecuteCloneProject(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    projectId,
+		"message": "Project cloned successfully!",
+	})
+}
+
+func (h *ProjectHandler) SaveAsTemplate(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.ProjectTemplatePayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	templateId := h.useCase.ExecuteSaveAsTemplate(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    templateId,
+		"message": "Template saved successfully!",
+	})
+}
+
+func (h *ProjectHandler) GetTemplates(c *fiber.Ctx) error {
+	organizationId := c.Params("organizationId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	templates := h.useCase.ExecuteGetTemplates(organizationId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   templates,
+	})
+}
+
+func (h *ProjectHandler) GetTemplateById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	template := h.useCase.ExecuteGetTemplateById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   template,
+	})
+}
+
+func (h *ProjectHandler) InstantiateTemplate(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.CloneProjectPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	projectId := h.useCase.ExecuteInstantiateTemplate(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    projectId,
+		"message": "Project created successfully!",
+	})
+}
+
+func (h *ProjectHandler) DeleteTemplateById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteTemplateById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Template deleted successfully!",
+	})
+}
@@ -16,7 +16,27 @@ func NewProjectRouter(
 	app.Post("/projects", jwtMiddleware.GuardJWT, projectHandler.AddProject)
 	app.Get("/projects/:id", jwtMiddleware.GuardJWT, projectHandler.GetProjectById)
 	app.Put("/projects/:id", jwtMiddleware.GuardJWT, projectHandler.UpdateProjectById)
+	app.Patch("/projects/:id", jwtMiddleware.GuardJWT, projectHandler.PatchProjectById)
 	app.Delete("/projects/:id", jwtMiddleware.GuardJWT, projectHandler.DeleteProjectById)
+	app.Post("/projects/:id/archive", jwtMiddleware.GuardJWT, projectHandler.ArchiveProjectById)
+	app.Post("/projects/:id/unarchive", jwtMiddleware.GuardJWT, projectHandler.UnarchiveProjectById)
 	app.Get("/projects", jwtMiddleware.GuardJWT, projectHandler.GetProjects)
-	app.Get("/projects-member/:id", projectHandler.GetMembersProject)
+	app.Get("/projects-member/:id", jwtMiddleware.GuardJWT, projectHandler.GetMembersProject)
+	app.Get("/projects/:id/stats", jwtMiddleware.GuardJWT, projectHandler.GetProjectStats)
+
+	// Membership
+	app.Get("/projects/:id/members", jwtMiddleware.GuardJWT, projectHandler.GetMembersProject)
+	app.Post("/projects/:id/members", jwtMiddleware.GuardJWT, projectHandler.AddMember)
+	app.Patch("/projects/:id/members/:userId", jwtMiddleware.GuardJWT, projectHandler.UpdateMemberRole)
+	app.Delete("/projects/:id/members/:userId", jwtMiddleware.GuardJWT, projectHandler.RemoveMember)
+	app.Post("/projects/:id/leave", jwtMiddleware.GuardJWT, projectHandler.LeaveProject)
+	app.Post("/projects/:id/transfer", jwtMiddleware.GuardJWT, projectHandler.TransferOwnership)
+
+	// Cloning and templates
+	app.Post("/projects/:id/clone", jwtMiddleware.GuardJWT, projectHandler.CloneProject)
+	app.Post("/projects/:id/template", jwtMiddleware.GuardJWT, projectHandler.SaveAsTemplate)
+	app.Get("/organizations/:organizationId/templates", jwtMiddleware.GuardJWT, projectHandler.GetTemplates)
+	app.Get("/templates/:id", jwtMiddleware.GuardJWT, projectHandler.GetTemplateById)
+	app.Post("/templates/:id/instantiate", jwtMiddleware.GuardJWT, projectHandler.InstantiateTemplate)
+	app.Delete("/templates/:id", jwtMiddleware.GuardJWT, projectHandler.DeleteTemplateById)
 }
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type SearchHandler struct {
+	useCase *use_case.SearchUseCase
+}
+
+func NewSearchHandler(useCase *use_case.SearchUseCase) *SearchHandler {
+	return &SearchHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	var payload entity.SearchPayload
+	_ = c.QueryParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	results := h.useCase.ExecuteSearch(loggedUserId, &payload)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   results,
+	})
+}
@@ -0,0 +1,17 @@
+package search
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewSearchRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.SearchUseCase,
+) {
+	searchHandler := NewSearchHandler(useCase)
+
+	app.Get("/search", jwtMiddleware.GuardJWT, searchHandler.Search)
+}
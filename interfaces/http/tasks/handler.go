@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/wisle25/task-pixie/applications/use_case"
 	"github.com/wisle25/task-pixie/domains/entity"
+	"github.com/wisle25/task-pixie/interfaces/http/etag"
 )
 
 type TaskHandler struct {
@@ -31,7 +32,13 @@ func (h *TaskHandler) AddTask(c *fiber.Ctx) error {
 
 func (h *TaskHandler) GetTaskById(c *fiber.Ctx) error {
 	id := c.Params("id")
-	task := h.useCase.ExecuteGetTaskById(id)
+	userId := c.Locals("userInfo").(entity.User).Id
+
+	task := h.useCase.ExecuteGetTaskById(id, userId)
+	if etag.RepresentationNotModified(c, task.Version, task) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status": "success",
 		"data":   task,
@@ -51,8 +58,9 @@ func (h *TaskHandler) GetTasks(c *fiber.Ctx) error {
 
 func (h *TaskHandler) GetTasksByProject(c *fiber.Ctx) error {
 	projectId := c.Params("projectId")
+	userId := c.Locals("userInfo").(entity.User).Id
 
-	tasks := h.useCase.ExecuteGetTasksByProjects(projectId)
+	tasks := h.useCase.ExecuteGetTasksByProjects(projectId, userId)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status": "success",
@@ -66,7 +74,23 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 	var payload entity.TaskPayload
 	_ = c.BodyParser(&payload)
 
-	h.useCase.ExecuteUpdateTaskById(id, &payload)
+	userId := c.Locals("userInfo").(entity.User).Id
+
+	version := h.useCase.ExecuteUpdateTaskById(id, &payload, userId, etag.IfMatch(c))
+	etag.Set(c, version)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Task updated successfully",
+	})
+}
+
+func (h *TaskHandler) PatchTask(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userId := c.Locals("userInfo").(entity.User).Id
+
+	version := h.useCase.ExecutePatchTaskById(id, c.Body(), userId, etag.IfMatch(c))
+	etag.Set(c, version)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
@@ -76,10 +100,35 @@ func (h *TaskHandler) UpdateTask(c *fiber.Ctx) error {
 
 func (h *TaskHandler) DeleteTask(c *fiber.Ctx) error {
 	id := c.Params("id")
-	h.useCase.ExecuteDeleteTaskById(id)
+	userId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteTaskById(id, userId, etag.IfMatch(c))
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status":  "success",
 		"message": "Task deleted successfully",
 	})
 }
+
+func (h *TaskHandler) BulkTasks(c *fiber.Ctx) error {
+	var payload entity.TaskBulkPayload
+	_ = c.BodyParser(&payload)
+
+	userId := c.Locals("userInfo").(entity.User).Id
+
+	report := h.useCase.ExecuteBulkTasks(&payload, userId)
+
+	if !report.Applied {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  "fail",
+			"data":    report,
+			"message": "Some tasks can't be changed, nothing was applied!",
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"data":    report,
+		"message": "Tasks updated successfully",
+	})
+}
@@ -14,9 +14,11 @@ func NewTaskRouter(
 	taskHandler := NewTaskHandler(useCase)
 
 	app.Post("/tasks", jwtMiddleware.GuardJWT, taskHandler.AddTask)
+	app.Post("/tasks/bulk", jwtMiddleware.GuardJWT, taskHandler.BulkTasks)
 	app.Get("/tasks/:id", jwtMiddleware.GuardJWT, taskHandler.GetTaskById)
 	app.Get("/tasks", jwtMiddleware.GuardJWT, taskHandler.GetTasks)
 	app.Get("/tasks/project/:projectId", jwtMiddleware.GuardJWT, taskHandler.GetTasksByProject)
 	app.Put("/tasks/:id", jwtMiddleware.GuardJWT, taskHandler.UpdateTask)
+	app.Patch("/tasks/:id", jwtMiddleware.GuardJWT, taskHandler.PatchTask)
 	app.Delete("/tasks/:id", jwtMiddleware.GuardJWT, taskHandler.DeleteTask)
 }
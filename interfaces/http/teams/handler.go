@@ -0,0 +1,180 @@
+package teams
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type TeamHandler struct {
+	useCase *use_case.TeamUseCase
+}
+
+func NewTeamHandler(useCase *use_case.TeamUseCase) *TeamHandler {
+	return &TeamHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *TeamHandler) AddTeam(c *fiber.Ctx) error {
+	organizationId := c.Params("organizationId")
+	var payload entity.TeamPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	teamId := h.useCase.ExecuteAddTeam(organizationId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    teamId,
+		"message": "Team created successfully!",
+	})
+}
+
+func (h *TeamHandler) GetTeams(c *fiber.Ctx) error {
+	organizationId := c.Params("organizationId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	teams := h.useCase.ExecuteGetTeams(organizationId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   teams,
+	})
+}
+
+func (h *TeamHandler) GetTeamById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	team := h.useCase.ExecuteGetTeamById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   team,
+	})
+}
+
+func (h *TeamHandler) UpdateTeamById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.TeamPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateTeamById(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Team updated successfully!",
+	})
+}
+
+func (h *TeamHandler) DeleteTeamById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteTeamById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Team deleted successfully!",
+	})
+}
+
+func (h *TeamHandler) GetTeamMembers(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	members := h.useCase.ExecuteGetTeamMembers(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   members,
+	})
+}
+
+func (h *TeamHandler) AddTeamMember(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.TeamMemberPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteAddTeamMember(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member added successfully!",
+	})
+}
+
+func (h *TeamHandler) RemoveTeamMember(c *fiber.Ctx) error {
+	id := c.Params("id")
+	memberId := c.Params("userId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRemoveTeamMember(id, memberId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Member removed successfully!",
+	})
+}
+
+func (h *TeamHandler) GetProjectTeams(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	teams := h.useCase.ExecuteGetProjectTeams(projectId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   teams,
+	})
+}
+
+func (h *TeamHandler) AddProjectTeam(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	var payload entity.ProjectTeamPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteAddProjectTeam(projectId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Team granted successfully!",
+	})
+}
+
+func (h *TeamHandler) UpdateProjectTeamRole(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	var payload entity.ProjectTeamPayload
+	_ = c.BodyParser(&payload)
+	payload.TeamId = c.Params("teamId")
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateProjectTeamRole(projectId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Team role updated successfully!",
+	})
+}
+
+func (h *TeamHandler) RemoveProjectTeam(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	teamId := c.Params("teamId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRemoveProjectTeam(projectId, teamId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Team access removed successfully!",
+	})
+}
@@ -0,0 +1,32 @@
+package teams
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewTeamRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.TeamUseCase,
+) {
+	teamHandler := NewTeamHandler(useCase)
+
+	app.Post("/organizations/:organizationId/teams", jwtMiddleware.GuardJWT, teamHandler.AddTeam)
+	app.Get("/organizations/:organizationId/teams", jwtMiddleware.GuardJWT, teamHandler.GetTeams)
+	app.Get("/teams/:id", jwtMiddleware.GuardJWT, teamHandler.GetTeamById)
+	app.Put("/teams/:id", jwtMiddleware.GuardJWT, teamHandler.UpdateTeamById)
+	app.Delete("/teams/:id", jwtMiddleware.GuardJWT, teamHandler.DeleteTeamById)
+
+	// Team membership
+	app.Get("/teams/:id/members", jwtMiddleware.GuardJWT, teamHandler.GetTeamMembers)
+	app.Post("/teams/:id/members", jwtMiddleware.GuardJWT, teamHandler.AddTeamMember)
+	app.Delete("/teams/:id/members/:userId", jwtMiddleware.GuardJWT, teamHandler.RemoveTeamMember)
+
+	// Project access
+	app.Get("/projects/:projectId/teams", jwtMiddleware.GuardJWT, teamHandler.GetProjectTeams)
+	app.Post("/projects/:projectId/teams", jwtMiddleware.GuardJWT, teamHandler.AddProjectTeam)
+	app.Patch("/projects/:projectId/teams/:teamId", jwtMiddleware.GuardJWT, teamHandler.UpdateProjectTeamRole)
+	app.Delete("/projects/:projectId/teams/:teamId", jwtMiddleware.GuardJWT, teamHandler.RemoveProjectTeam)
+}
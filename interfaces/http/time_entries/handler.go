@@ -0,0 +1,119 @@
+package time_entries
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type TimeEntryHandler struct {
+	useCase *use_case.TimeEntryUseCase
+}
+
+func NewTimeEntryHandler(useCase *use_case.TimeEntryUseCase) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *TimeEntryHandler) StartTimer(c *fiber.Ctx) error {
+	taskId := c.Params("id")
+	var payload entity.TimerPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	entryId := h.useCase.ExecuteStartTimer(taskId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    entryId,
+		"message": "Timer started!",
+	})
+}
+
+func (h *TimeEntryHandler) StopTimer(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	entry := h.useCase.ExecuteStopTimer(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"data":    entry,
+		"message": "Timer stopped!",
+	})
+}
+
+func (h *TimeEntryHandler) GetRunningTimer(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	entry := h.useCase.ExecuteGetRunningTimer(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   entry,
+	})
+}
+
+func (h *TimeEntryHandler) AddTimeEntry(c *fiber.Ctx) error {
+	taskId := c.Params("id")
+	var payload entity.TimeEntryPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	entryId := h.useCase.ExecuteAddTimeEntry(taskId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    entryId,
+		"message": "Time entry added successfully!",
+	})
+}
+
+func (h *TimeEntryHandler) GetTimeEntries(c *fiber.Ctx) error {
+	taskId := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	entries := h.useCase.ExecuteGetTimeEntries(taskId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   entries,
+	})
+}
+
+func (h *TimeEntryHandler) DeleteTimeEntry(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteTimeEntryById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Time entry deleted successfully!",
+	})
+}
+
+func (h *TimeEntryHandler) GetTimeReport(c *fiber.Ctx) error {
+	var filter entity.TimeReportFilter
+	_ = c.QueryParser(&filter)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	if filter.Format == entity.TimeReportFormatCSV {
+		report := h.useCase.ExecuteExportTimeReport(&filter, loggedUserId)
+
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Attachment("time-report.csv")
+
+		return c.Status(fiber.StatusOK).Send(report)
+	}
+
+	report := h.useCase.ExecuteGetTimeReport(&filter, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   report,
+	})
+}
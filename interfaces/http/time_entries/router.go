@@ -0,0 +1,26 @@
+package time_entries
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewTimeEntryRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.TimeEntryUseCase,
+) {
+	timeEntryHandler := NewTimeEntryHandler(useCase)
+
+	// Timer
+	app.Post("/tasks/:id/timer", jwtMiddleware.GuardJWT, timeEntryHandler.StartTimer)
+	app.Get("/timer", jwtMiddleware.GuardJWT, timeEntryHandler.GetRunningTimer)
+	app.Post("/timer/stop", jwtMiddleware.GuardJWT, timeEntryHandler.StopTimer)
+
+	// Time entries
+	app.Get("/tasks/:id/time-entries", jwtMiddleware.GuardJWT, timeEntryHandler.GetTimeEntries)
+	app.Post("/tasks/:id/time-entries", jwtMiddleware.GuardJWT, timeEntryHandler.AddTimeEntry)
+	app.Get("/time-entries/report", jwtMiddleware.GuardJWT, timeEntryHandler.GetTimeReport)
+	app.Delete("/time-entries/:id", jwtMiddleware.GuardJWT, timeEntryHandler.DeleteTimeEntry)
+}
@@ -0,0 +1,131 @@
+// Package timezone renders the times of JSON responses, always RFC 3339 in UTC, in the time zone asked by the client.
+package timezone
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"time"
+)
+
+// Query is the query parameter naming the time zone, an IANA name or local for the one of the user's profile.
+const Query = "timeZone"
+
+// Local asks for the time zone of the logged user's profile.
+const Local = "local"
+
+// Fields holding the times of the API, only their values are rendered.
+// Anything else, like titles, descriptions or payloads typed by users, is left as is even when it looks like a time.
+var timestampFields = map[string]bool{
+	"activatedAt":         true,
+	"archivedAt":          true,
+	"changedAt":           true,
+	"closedAt":            true,
+	"completedAt":         true,
+	"createdAt":           true,
+	"deletedAt":           true,
+	"deletionScheduledAt": true,
+	"deliveredAt":         true,
+	"disabledAt":          true,
+	"endedAt":             true,
+	"expiresAt":           true,
+	"joinedAt":            true,
+	"lastModified":        true,
+	"lastUsedAt":          true,
+	"nextAttemptAt":       true,
+	"occurredAt":          true,
+	"scheduledAt":         true,
+	"startedAt":           true,
+	"updatedAt":           true,
+}
+
+// Only exact UTC times are rendered, dates are left as is
+var utcTimePattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(?:\.\d+)?Z$`)
+
+// Render rewrites the values of the timestamp fields of the JSON document in the location, keeping everything else as is.
+func Render(document []byte, location *time.Location) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+
+	var out bytes.Buffer
+	if err := renderValue(decoder, &out, location, ""); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON document")
+	}
+
+	return out.Bytes(), nil
+}
+
+// renderValue copies the next value of the decoder, objects and arrays keeping their order.
+// The field is the key the value belongs to, empty for array items and the document itself.
+func renderValue(decoder *json.Decoder, out *bytes.Buffer, location *time.Location, field string) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	switch value := token.(type) {
+	case json.Delim:
+		closing := json.Delim('}')
+		if value == '[' {
+			closing = ']'
+		}
+
+		out.WriteRune(rune(value))
+		for first := true; decoder.More(); first = false {
+			if !first {
+				out.WriteByte(',')
+			}
+
+			// Keys are copied as they are
+			key := ""
+			if value == '{' {
+				token, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				key, _ = token.(string)
+				if err := writeJSON(out, key); err != nil {
+					return err
+				}
+				out.WriteByte(':')
+			}
+
+			if err := renderValue(decoder, out, location, key); err != nil {
+				return err
+			}
+		}
+
+		if _, err := decoder.Token(); err != nil {
+			return err
+		}
+		out.WriteRune(rune(closing))
+
+		return nil
+	case string:
+		if timestampFields[field] && utcTimePattern.MatchString(value) {
+			if parsed, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				return writeJSON(out, parsed.In(location).Format(time.RFC3339Nano))
+			}
+		}
+
+		return writeJSON(out, value)
+	default:
+		return writeJSON(out, value)
+	}
+}
+
+func writeJSON(out *bytes.Buffer, value interface{}) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	out.Write(encoded)
+
+	return nil
+}
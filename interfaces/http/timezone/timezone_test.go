@@ -0,0 +1,65 @@
+package timezone_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/wisle25/task-pixie/interfaces/http/timezone"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	t.Run("Should render the timestamp fields in the location", func(t *testing.T) {
+		// Arrange
+		document := `{"status":"success","data":[{"createdAt":"2024-05-01T20:30:00Z","endedAt":"2024-05-01T20:30:00.5Z"}]}`
+
+		// Action
+		rendered, err := timezone.Render([]byte(document), jakarta)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, `{"status":"success","data":[{"createdAt":"2024-05-02T03:30:00+07:00","endedAt":"2024-05-02T03:30:00.5+07:00"}]}`, string(rendered))
+	})
+
+	t.Run("Should keep texts that look like times as they are", func(t *testing.T) {
+		// Arrange
+		document := `{"title":"2024-05-01T20:30:00Z","payload":"{\"createdAt\":\"2024-05-01T20:30:00Z\"}","tags":["2024-05-01T20:30:00Z"]}`
+
+		// Action
+		rendered, err := timezone.Render([]byte(document), jakarta)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, document, string(rendered))
+	})
+
+	t.Run("Should keep the order, dates, numbers and texts as they are", func(t *testing.T) {
+		// Arrange
+		document := `{"z":1.50,"dueDate":"2024-05-01","createdAt":"2024-05-01","detail":"due at 2024-05-01T20:30:00Z","a":[true,null,{}],"empty":[]}`
+
+		// Action
+		rendered, err := timezone.Render([]byte(document), jakarta)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, document, string(rendered))
+	})
+
+	t.Run("Should keep UTC times in UTC", func(t *testing.T) {
+		// Action
+		rendered, err := timezone.Render([]byte(`{"createdAt":"2024-05-01T20:30:00Z"}`), time.UTC)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, `{"createdAt":"2024-05-01T20:30:00Z"}`, string(rendered))
+	})
+
+	t.Run("Should return error when the document is invalid", func(t *testing.T) {
+		// Action
+		_, err := timezone.Render([]byte(`{"createdAt":`), jakarta)
+
+		// Assert
+		assert.Error(t, err)
+	})
+}
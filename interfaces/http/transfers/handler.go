@@ -0,0 +1,92 @@
+package transfers
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+	"log"
+	"strings"
+)
+
+type TaskTransferHandler struct {
+	useCase *use_case.TaskTransferUseCase
+}
+
+func NewTaskTransferHandler(useCase *use_case.TaskTransferUseCase) *TaskTransferHandler {
+	return &TaskTransferHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *TaskTransferHandler) ExportTasks(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var filter entity.TaskExportFilter
+	_ = c.QueryParser(&filter)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	export := h.useCase.ExecuteExportTasks(id, &filter, loggedUserId)
+
+	extension := entity.TransferFormatCSV
+	if filter.Format == entity.TransferFormatJSON {
+		extension = entity.TransferFormatJSON
+	}
+	c.Attachment(fmt.Sprintf("project-%s-tasks.%s", id, extension))
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The response has already started, a failure can only cut it short
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("export tasks of project %s: %v", id, r)
+			}
+		}()
+
+		export(w)
+	})
+
+	return nil
+}
+
+func (h *TaskTransferHandler) ImportTasks(c *fiber.Ctx) error {
+	var err error
+	id := c.Params("id")
+	var payload entity.TaskImportPayload
+	_ = c.BodyParser(&payload)
+
+	payload.File, err = c.FormFile("file")
+	if err != nil {
+		if !strings.Contains(err.Error(), "there is no uploaded") && !strings.Contains(err.Error(), "multipart") {
+			return fmt.Errorf("upload import: %v", err)
+		}
+
+		payload.File = nil
+	}
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	report := h.useCase.ExecuteImportTasks(id, &payload, loggedUserId)
+
+	if report.Failed > 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"status":  "fail",
+			"data":    report,
+			"message": "Some rows are invalid, nothing was imported!",
+		})
+	}
+
+	if report.DryRun {
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{
+			"status":  "success",
+			"data":    report,
+			"message": "Every row is valid, nothing was imported (dry run)!",
+		})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    report,
+		"message": "Tasks imported successfully!",
+	})
+}
@@ -0,0 +1,18 @@
+package transfers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewTaskTransferRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.TaskTransferUseCase,
+) {
+	taskTransferHandler := NewTaskTransferHandler(useCase)
+
+	app.Get("/projects/:id/export", jwtMiddleware.GuardJWT, taskTransferHandler.ExportTasks)
+	app.Post("/projects/:id/import", jwtMiddleware.GuardJWT, taskTransferHandler.ImportTasks)
+}
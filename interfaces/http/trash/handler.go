@@ -0,0 +1,52 @@
+package trash
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type TrashHandler struct {
+	useCase *use_case.TrashUseCase
+}
+
+func NewTrashHandler(useCase *use_case.TrashUseCase) *TrashHandler {
+	return &TrashHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *TrashHandler) GetTrash(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	trash := h.useCase.ExecuteGetTrash(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   trash,
+	})
+}
+
+func (h *TrashHandler) RestoreProjectById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRestoreProjectById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Project restored successfully!",
+	})
+}
+
+func (h *TrashHandler) RestoreTaskById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteRestoreTaskById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Task restored successfully!",
+	})
+}
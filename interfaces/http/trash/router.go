@@ -0,0 +1,19 @@
+package trash
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewTrashRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.TrashUseCase,
+) {
+	trashHandler := NewTrashHandler(useCase)
+
+	app.Get("/trash", jwtMiddleware.GuardJWT, trashHandler.GetTrash)
+	app.Post("/trash/projects/:id/restore", jwtMiddleware.GuardJWT, trashHandler.RestoreProjectById)
+	app.Post("/trash/tasks/:id/restore", jwtMiddleware.GuardJWT, trashHandler.RestoreTaskById)
+}
@@ -53,6 +53,21 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 	})
 }
 
+func (h *UserHandler) ResetPassword(c *fiber.Ctx) error {
+	// Payload
+	var payload entity.PasswordResetPayload
+	_ = c.BodyParser(&payload)
+
+	// Use Case
+	h.useCase.ExecuteResetPassword(&payload)
+
+	// Response
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Successfully resetting your password! You can log in now!",
+	})
+}
+
 func (h *UserHandler) RefreshToken(c *fiber.Ctx) error {
 	// Payload
 	refreshToken := c.Cookies("refresh_token")
@@ -89,7 +104,7 @@ func (h *UserHandler) GetUserById(c *fiber.Ctx) error {
 	id := c.Params("id")
 
 	// Use Case
-	user := h.useCase.ExecuteGetUserById(id)
+	user := h.useCase.ExecuteGetPublicUserById(id)
 
 	// Response
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -98,10 +113,30 @@ func (h *UserHandler) GetUserById(c *fiber.Ctx) error {
 	})
 }
 
+func (h *UserHandler) GetAvatar(c *fiber.Ctx) error {
+	id := c.Params("id")
+	size := c.QueryInt("size")
+
+	picture, contentType := h.useCase.ExecuteGetAvatar(id, size)
+
+	// Avatars keep their URL when they're replaced
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return c.Status(fiber.StatusOK).Send(picture)
+}
+
 func (h *UserHandler) GetLoggedUser(c *fiber.Ctx) error {
+	// The session only holds the user as they logged in, the profile may have changed since
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	// Use Case
+	user := h.useCase.ExecuteGetUserById(loggedUserId)
+
+	// Response
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"status": "success",
-		"data":   c.Locals("userInfo").(entity.User),
+		"data":   user,
 	})
 }
 
@@ -18,7 +18,9 @@ func NewUserRouter(
 	app.Get("/auths", jwtMiddleware.GuardJWT, userHandler.GetLoggedUser)
 	app.Put("/auths", userHandler.RefreshToken)
 	app.Delete("/auths", jwtMiddleware.GuardJWT, userHandler.Logout)
+	app.Post("/auths/password-reset", userHandler.ResetPassword)
 	app.Get("/users/:id", userHandler.GetUserById)
+	app.Get("/users/:id/avatar", userHandler.GetAvatar)
 	app.Put("/users/:id", jwtMiddleware.GuardJWT, userHandler.UpdateUserById)
 	app.Get("/usersSearch", userHandler.SearchUsersByUsername)
 }
@@ -0,0 +1,106 @@
+package views
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type TaskViewHandler struct {
+	useCase *use_case.TaskViewUseCase
+}
+
+func NewTaskViewHandler(useCase *use_case.TaskViewUseCase) *TaskViewHandler {
+	return &TaskViewHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *TaskViewHandler) AddView(c *fiber.Ctx) error {
+	var payload entity.TaskViewPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	viewId := h.useCase.ExecuteAddView(&payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status":  "success",
+		"data":    viewId,
+		"message": "View saved successfully!",
+	})
+}
+
+func (h *TaskViewHandler) GetViews(c *fiber.Ctx) error {
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	views := h.useCase.ExecuteGetViews(loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   views,
+	})
+}
+
+func (h *TaskViewHandler) GetViewById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	view := h.useCase.ExecuteGetViewById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   view,
+	})
+}
+
+func (h *TaskViewHandler) UpdateViewById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.TaskViewPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateViewById(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "View updated successfully!",
+	})
+}
+
+func (h *TaskViewHandler) DeleteViewById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteViewById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "View deleted successfully!",
+	})
+}
+
+func (h *TaskViewHandler) RunView(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	tasks := h.useCase.ExecuteRunView(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   tasks,
+	})
+}
+
+func (h *TaskViewHandler) FilterTasks(c *fiber.Ctx) error {
+	query := c.Query("q")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	tasks := h.useCase.ExecuteFilterTasks(query, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   tasks,
+	})
+}
@@ -0,0 +1,25 @@
+package views
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewTaskViewRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.TaskViewUseCase,
+) {
+	taskViewHandler := NewTaskViewHandler(useCase)
+
+	// Must be registered before /views/:id
+	app.Get("/views/preview", jwtMiddleware.GuardJWT, taskViewHandler.FilterTasks)
+
+	app.Post("/views", jwtMiddleware.GuardJWT, taskViewHandler.AddView)
+	app.Get("/views", jwtMiddleware.GuardJWT, taskViewHandler.GetViews)
+	app.Get("/views/:id", jwtMiddleware.GuardJWT, taskViewHandler.GetViewById)
+	app.Put("/views/:id", jwtMiddleware.GuardJWT, taskViewHandler.UpdateViewById)
+	app.Delete("/views/:id", jwtMiddleware.GuardJWT, taskViewHandler.DeleteViewById)
+	app.Get("/views/:id/tasks", jwtMiddleware.GuardJWT, taskViewHandler.RunView)
+}
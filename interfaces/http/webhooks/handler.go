@@ -0,0 +1,101 @@
+package webhooks
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/domains/entity"
+)
+
+type WebhookHandler struct {
+	useCase *use_case.WebhookUseCase
+}
+
+func NewWebhookHandler(useCase *use_case.WebhookUseCase) *WebhookHandler {
+	return &WebhookHandler{
+		useCase: useCase,
+	}
+}
+
+func (h *WebhookHandler) AddWebhook(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	var payload entity.WebhookPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	webhookId, secret := h.useCase.ExecuteAddWebhook(projectId, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"status": "success",
+		"data": fiber.Map{
+			"id":     webhookId,
+			"secret": secret,
+		},
+		"message": "Webhook registered successfully! Keep the secret, it won't be shown again.",
+	})
+}
+
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	projectId := c.Params("projectId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	webhooks := h.useCase.ExecuteGetWebhooks(projectId, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   webhooks,
+	})
+}
+
+func (h *WebhookHandler) UpdateWebhookById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	var payload entity.WebhookPayload
+	_ = c.BodyParser(&payload)
+
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteUpdateWebhookById(id, &payload, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Webhook updated successfully!",
+	})
+}
+
+func (h *WebhookHandler) DeleteWebhookById(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	h.useCase.ExecuteDeleteWebhookById(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Webhook deleted successfully!",
+	})
+}
+
+func (h *WebhookHandler) GetDeliveries(c *fiber.Ctx) error {
+	id := c.Params("id")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	deliveries := h.useCase.ExecuteGetDeliveries(id, loggedUserId)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"status": "success",
+		"data":   deliveries,
+	})
+}
+
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	id := c.Params("id")
+	deliveryId := c.Params("deliveryId")
+	loggedUserId := c.Locals("userInfo").(entity.User).Id
+
+	newDeliveryId := h.useCase.ExecuteRedeliver(id, deliveryId, loggedUserId)
+
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status":  "success",
+		"data":    newDeliveryId,
+		"message": "Delivery is queued again!",
+	})
+}
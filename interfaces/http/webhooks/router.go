@@ -0,0 +1,22 @@
+package webhooks
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/wisle25/task-pixie/applications/use_case"
+	"github.com/wisle25/task-pixie/interfaces/http/middlewares"
+)
+
+func NewWebhookRouter(
+	app *fiber.App,
+	jwtMiddleware *middlewares.JwtMiddleware,
+	useCase *use_case.WebhookUseCase,
+) {
+	webhookHandler := NewWebhookHandler(useCase)
+
+	app.Post("/projects/:projectId/webhooks", jwtMiddleware.GuardJWT, webhookHandler.AddWebhook)
+	app.Get("/projects/:projectId/webhooks", jwtMiddleware.GuardJWT, webhookHandler.GetWebhooks)
+	app.Put("/webhooks/:id", jwtMiddleware.GuardJWT, webhookHandler.UpdateWebhookById)
+	app.Delete("/webhooks/:id", jwtMiddleware.GuardJWT, webhookHandler.DeleteWebhookById)
+	app.Get("/webhooks/:id/deliveries", jwtMiddleware.GuardJWT, webhookHandler.GetDeliveries)
+	app.Post("/webhooks/:id/deliveries/:deliveryId/redeliver", jwtMiddleware.GuardJWT, webhookHandler.Redeliver)
+}
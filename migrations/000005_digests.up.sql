@@ -0,0 +1,17 @@
+-- Create the digest_subscriptions table, one subscription per user
+CREATE TABLE digest_subscriptions (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    frequency VARCHAR(10) NOT NULL,
+    time_zone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    send_hour INT NOT NULL DEFAULT 8,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Create the digest_deliveries table, a period can only be sent once per user
+CREATE TABLE digest_deliveries (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_key VARCHAR(30) NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, period_key)
+);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS tasks_search_vector_trigger ON tasks;
+DROP TRIGGER IF EXISTS projects_search_vector_trigger ON projects;
+DROP FUNCTION IF EXISTS tasks_search_vector_update();
+DROP FUNCTION IF EXISTS projects_search_vector_update();
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE projects DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,37 @@
+-- Search vectors are maintained by triggers, title weighs more than the other fields
+ALTER TABLE tasks ADD COLUMN search_vector tsvector;
+ALTER TABLE projects ADD COLUMN search_vector tsvector;
+
+CREATE FUNCTION tasks_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.description, '')), 'B') ||
+        setweight(to_tsvector('english', COALESCE(NEW.detail, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION projects_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('english', COALESCE(NEW.title, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(NEW.detail, '')), 'C');
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, description, detail ON tasks
+    FOR EACH ROW EXECUTE FUNCTION tasks_search_vector_update();
+
+CREATE TRIGGER projects_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF title, detail ON projects
+    FOR EACH ROW EXECUTE FUNCTION projects_search_vector_update();
+
+-- Fill the existing rows through the triggers
+UPDATE tasks SET title = title;
+UPDATE projects SET title = title;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN(search_vector);
+CREATE INDEX idx_projects_search_vector ON projects USING GIN(search_vector);
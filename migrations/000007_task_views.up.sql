@@ -0,0 +1,13 @@
+-- Create the task_views table, a saved filter owned by a user, optionally shared with a project
+CREATE TABLE task_views (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    query TEXT NOT NULL,
+    owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_views_owner_id ON task_views(owner_id);
+CREATE INDEX idx_task_views_project_id ON task_views(project_id);
@@ -0,0 +1,8 @@
+-- Archived projects are read-only, deleted projects and tasks stay in the trash until purged
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE projects ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMP;
+
+-- Only the trash and the purge job look for deleted rows
+CREATE INDEX idx_projects_deleted_at ON projects(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
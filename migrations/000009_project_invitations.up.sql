@@ -0,0 +1,18 @@
+-- Create the project_invitations table, invitees become members only after accepting
+CREATE TABLE project_invitations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL, -- Lower cased, the invitee might not be registered yet
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(15) NOT NULL DEFAULT 'member',
+    status VARCHAR(15) NOT NULL DEFAULT 'pending',
+    invited_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    expires_at TIMESTAMP NOT NULL,
+    responded_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A person can only have one pending invitation per project
+CREATE UNIQUE INDEX idx_project_invitations_pending ON project_invitations(project_id, email) WHERE status = 'pending';
+CREATE INDEX idx_project_invitations_email ON project_invitations(email);
+CREATE INDEX idx_project_invitations_user_id ON project_invitations(user_id);
@@ -0,0 +1,7 @@
+DROP FUNCTION IF EXISTS accessible_project_ids(UUID);
+
+DROP INDEX IF EXISTS idx_projects_organization_id;
+ALTER TABLE projects DROP COLUMN IF EXISTS organization_id;
+
+DROP TABLE IF EXISTS organization_members;
+DROP TABLE IF EXISTS organizations;
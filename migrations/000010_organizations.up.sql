@@ -0,0 +1,62 @@
+-- Create the organizations table, the tenant owning projects
+CREATE TABLE organizations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    is_personal BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Every user has a single personal workspace
+CREATE UNIQUE INDEX idx_organizations_personal ON organizations(created_by) WHERE is_personal;
+
+-- Create the organization_members table, the member directory of an organization
+CREATE TABLE organization_members (
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(15) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+-- Put each existing user's projects into a personal workspace
+INSERT INTO organizations(name, is_personal, created_by)
+SELECT username || '''s workspace', TRUE, id FROM users;
+
+INSERT INTO organization_members(organization_id, user_id, role)
+SELECT id, created_by, 'owner' FROM organizations WHERE is_personal;
+
+ALTER TABLE projects ADD COLUMN organization_id UUID REFERENCES organizations(id) ON DELETE CASCADE;
+
+UPDATE projects p
+SET organization_id = o.id
+FROM organizations o
+WHERE o.is_personal AND o.created_by = p.owner_id;
+
+-- Existing collaborators keep their access as members of the owner's workspace
+INSERT INTO organization_members(organization_id, user_id, role)
+SELECT DISTINCT p.organization_id, pm.user_id, 'member'
+FROM project_members pm
+INNER JOIN projects p ON p.id = pm.project_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE projects ALTER COLUMN organization_id SET NOT NULL;
+CREATE INDEX idx_projects_organization_id ON projects(organization_id);
+
+-- Projects the user can access: the user must belong to the project's organization,
+-- then be the project owner, a project member, or an organization owner/admin.
+-- Every query listing projects or their tasks for a user goes through this, so data never leaks across organizations.
+CREATE FUNCTION accessible_project_ids(p_user_id UUID) RETURNS TABLE (project_id UUID) AS $$
+    SELECT p.id
+    FROM projects p
+    INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = p_user_id
+    WHERE p.deleted_at IS NULL
+      AND (
+        p.owner_id = p_user_id
+        OR om.role IN ('owner', 'admin')
+        OR EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = p_user_id)
+      )
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION accessible_project_ids(p_user_id UUID) RETURNS TABLE (project_id UUID) AS $$
+    SELECT p.id
+    FROM projects p
+    INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = p_user_id
+    WHERE p.deleted_at IS NULL
+      AND (
+        p.owner_id = p_user_id
+        OR om.role IN ('owner', 'admin')
+        OR EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = p_user_id)
+      )
+$$ LANGUAGE sql STABLE;
+
+DROP TABLE IF EXISTS project_teams;
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
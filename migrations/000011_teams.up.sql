@@ -0,0 +1,48 @@
+-- Create the teams table, named groups of organization members
+CREATE TABLE teams (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organization_id UUID NOT NULL REFERENCES organizations(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (organization_id, name)
+);
+
+CREATE TABLE team_members (
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX idx_team_members_user_id ON team_members(user_id);
+
+-- Every member of the team gets the role on the project
+CREATE TABLE project_teams (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    role VARCHAR(15) NOT NULL DEFAULT 'member',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (project_id, team_id)
+);
+
+CREATE INDEX idx_project_teams_team_id ON project_teams(team_id);
+
+-- Team grants give access too
+CREATE OR REPLACE FUNCTION accessible_project_ids(p_user_id UUID) RETURNS TABLE (project_id UUID) AS $$
+    SELECT p.id
+    FROM projects p
+    INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = p_user_id
+    WHERE p.deleted_at IS NULL
+      AND (
+        p.owner_id = p_user_id
+        OR om.role IN ('owner', 'admin')
+        OR EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = p_user_id)
+        OR EXISTS (
+            SELECT 1
+            FROM project_teams pt
+            INNER JOIN team_members tm ON tm.team_id = pt.team_id
+            WHERE pt.project_id = p.id AND tm.user_id = p_user_id
+        )
+      )
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,23 @@
+-- Templates would show up as regular projects
+DELETE FROM projects WHERE is_template;
+
+CREATE OR REPLACE FUNCTION accessible_project_ids(p_user_id UUID) RETURNS TABLE (project_id UUID) AS $$
+    SELECT p.id
+    FROM projects p
+    INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = p_user_id
+    WHERE p.deleted_at IS NULL
+      AND (
+        p.owner_id = p_user_id
+        OR om.role IN ('owner', 'admin')
+        OR EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = p_user_id)
+        OR EXISTS (
+            SELECT 1
+            FROM project_teams pt
+            INNER JOIN team_members tm ON tm.team_id = pt.team_id
+            WHERE pt.project_id = p.id AND tm.user_id = p_user_id
+        )
+      )
+$$ LANGUAGE sql STABLE;
+
+DROP INDEX IF EXISTS idx_projects_templates;
+ALTER TABLE projects DROP COLUMN IF EXISTS is_template;
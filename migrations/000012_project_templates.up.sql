@@ -0,0 +1,24 @@
+-- Templates are hidden projects of an organization, instantiated by cloning them
+ALTER TABLE projects ADD COLUMN is_template BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX idx_projects_templates ON projects(organization_id) WHERE is_template;
+
+-- Templates are never listed along with projects
+CREATE OR REPLACE FUNCTION accessible_project_ids(p_user_id UUID) RETURNS TABLE (project_id UUID) AS $$
+    SELECT p.id
+    FROM projects p
+    INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = p_user_id
+    WHERE p.deleted_at IS NULL
+      AND NOT p.is_template
+      AND (
+        p.owner_id = p_user_id
+        OR om.role IN ('owner', 'admin')
+        OR EXISTS (SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = p_user_id)
+        OR EXISTS (
+            SELECT 1
+            FROM project_teams pt
+            INNER JOIN team_members tm ON tm.team_id = pt.team_id
+            WHERE pt.project_id = p.id AND tm.user_id = p_user_id
+        )
+      )
+$$ LANGUAGE sql STABLE;
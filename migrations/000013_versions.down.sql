@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS project_members_version_trigger ON project_members;
+DROP TRIGGER IF EXISTS task_assignments_version_trigger ON task_assignments;
+DROP TRIGGER IF EXISTS projects_version_trigger ON projects;
+DROP TRIGGER IF EXISTS tasks_version_trigger ON tasks;
+
+DROP FUNCTION IF EXISTS project_members_bump_project();
+DROP FUNCTION IF EXISTS task_assignments_bump_task();
+DROP FUNCTION IF EXISTS bump_version();
+
+ALTER TABLE projects DROP COLUMN IF EXISTS version;
+ALTER TABLE tasks DROP COLUMN IF EXISTS version;
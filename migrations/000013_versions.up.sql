@@ -0,0 +1,41 @@
+-- Versions are bumped by triggers on every change, they back the ETags of tasks and projects
+ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE projects ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+CREATE FUNCTION bump_version() RETURNS trigger AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_version_trigger
+    BEFORE UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+CREATE TRIGGER projects_version_trigger
+    BEFORE UPDATE ON projects
+    FOR EACH ROW EXECUTE FUNCTION bump_version();
+
+-- Assignees and members are part of the task and the project, changing them changes the version too
+CREATE FUNCTION task_assignments_bump_task() RETURNS trigger AS $$
+BEGIN
+    UPDATE tasks SET version = version WHERE id = COALESCE(NEW.task_id, OLD.task_id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION project_members_bump_project() RETURNS trigger AS $$
+BEGIN
+    UPDATE projects SET version = version WHERE id = COALESCE(NEW.project_id, OLD.project_id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER task_assignments_version_trigger
+    AFTER INSERT OR DELETE ON task_assignments
+    FOR EACH ROW EXECUTE FUNCTION task_assignments_bump_task();
+
+CREATE TRIGGER project_members_version_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON project_members
+    FOR EACH ROW EXECUTE FUNCTION project_members_bump_project();
@@ -0,0 +1,21 @@
+-- Estimated time of a task, in minutes
+ALTER TABLE tasks ADD COLUMN estimate INTEGER CHECK (estimate > 0);
+
+-- Time spent by a user on a task, entries without end are running timers
+CREATE TABLE time_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT NOT NULL DEFAULT '',
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (ended_at IS NULL OR ended_at > started_at)
+);
+
+-- A user has at most one running timer
+CREATE UNIQUE INDEX idx_time_entries_running ON time_entries(user_id) WHERE ended_at IS NULL;
+
+CREATE INDEX idx_time_entries_task ON time_entries(task_id);
+CREATE INDEX idx_time_entries_user_started ON time_entries(user_id, started_at);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS tasks_activity_trigger ON tasks;
+DROP FUNCTION IF EXISTS record_task_activity();
+
+DROP TABLE IF EXISTS task_activities;
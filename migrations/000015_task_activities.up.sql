@@ -0,0 +1,37 @@
+-- History of the states of tasks, the cumulative flow and burndown of projects are computed from it.
+-- A row is recorded by trigger whenever a task is created, changes status or project, or goes in or out of the trash.
+CREATE TABLE task_activities (
+    id BIGSERIAL PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    project_id UUID, -- Not a foreign key, the history outlives the project the task was in
+    status VARCHAR(50) NOT NULL,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_task_activities_project ON task_activities(project_id, task_id);
+CREATE INDEX idx_task_activities_task_changed ON task_activities(task_id, changed_at DESC, id DESC);
+
+CREATE FUNCTION record_task_activity() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND NEW.status IS NOT DISTINCT FROM OLD.status
+       AND NEW.project_id IS NOT DISTINCT FROM OLD.project_id
+       AND (NEW.deleted_at IS NULL) = (OLD.deleted_at IS NULL) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_activities(task_id, project_id, status, deleted)
+    VALUES (NEW.id, NEW.project_id, NEW.status, NEW.deleted_at IS NOT NULL);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tasks_activity_trigger
+    AFTER INSERT OR UPDATE ON tasks
+    FOR EACH ROW EXECUTE FUNCTION record_task_activity();
+
+-- The past of existing tasks is unknown, they're taken as being in their current state since their creation
+INSERT INTO task_activities(task_id, project_id, status, deleted, changed_at)
+SELECT id, project_id, status, deleted_at IS NOT NULL, COALESCE(created_at, NOW())
+FROM tasks;
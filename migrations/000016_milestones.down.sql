@@ -0,0 +1,19 @@
+CREATE OR REPLACE FUNCTION record_task_activity() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND NEW.status IS NOT DISTINCT FROM OLD.status
+       AND NEW.project_id IS NOT DISTINCT FROM OLD.project_id
+       AND (NEW.deleted_at IS NULL) = (OLD.deleted_at IS NULL) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_activities(task_id, project_id, status, deleted)
+    VALUES (NEW.id, NEW.project_id, NEW.status, NEW.deleted_at IS NOT NULL);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE task_activities DROP COLUMN IF EXISTS milestone_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS milestone_id;
+
+DROP TABLE IF EXISTS milestones;
@@ -0,0 +1,42 @@
+-- Milestones (sprints) of a project, at most one is active at a time
+CREATE TABLE milestones (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    goal TEXT NOT NULL DEFAULT '',
+    start_date DATE NOT NULL,
+    end_date DATE NOT NULL,
+    state VARCHAR(10) NOT NULL DEFAULT 'planned' CHECK (state IN ('planned', 'active', 'closed')),
+    activated_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CHECK (end_date >= start_date)
+);
+
+CREATE UNIQUE INDEX idx_milestones_active ON milestones(project_id) WHERE state = 'active';
+CREATE INDEX idx_milestones_project ON milestones(project_id, start_date);
+
+ALTER TABLE tasks ADD COLUMN milestone_id UUID REFERENCES milestones(id) ON DELETE SET NULL;
+CREATE INDEX idx_tasks_milestone ON tasks(milestone_id);
+
+-- The history of the tasks records their milestone too, sprint reports replay the scope from it
+ALTER TABLE task_activities ADD COLUMN milestone_id UUID;
+CREATE INDEX idx_task_activities_milestone ON task_activities(milestone_id, task_id);
+
+CREATE OR REPLACE FUNCTION record_task_activity() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+       AND NEW.status IS NOT DISTINCT FROM OLD.status
+       AND NEW.project_id IS NOT DISTINCT FROM OLD.project_id
+       AND NEW.milestone_id IS NOT DISTINCT FROM OLD.milestone_id
+       AND (NEW.deleted_at IS NULL) = (OLD.deleted_at IS NULL) THEN
+        RETURN NULL;
+    END IF;
+
+    INSERT INTO task_activities(task_id, project_id, milestone_id, status, deleted)
+    VALUES (NEW.id, NEW.project_id, NEW.milestone_id, NEW.status, NEW.deleted_at IS NOT NULL);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,13 @@
+-- Create the calendar_feeds table, secret URLs calendar apps subscribe to
+CREATE TABLE calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID REFERENCES projects(id) ON DELETE CASCADE, -- NULL for the feed of the user's own tasks
+    token_hash CHAR(64) NOT NULL UNIQUE, -- SHA-256 of the token, the token itself is only shown once
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A user has one feed of their own tasks, and one per project
+CREATE UNIQUE INDEX idx_calendar_feeds_user ON calendar_feeds(user_id) WHERE project_id IS NULL;
+CREATE UNIQUE INDEX idx_calendar_feeds_user_project ON calendar_feeds(user_id, project_id) WHERE project_id IS NOT NULL;
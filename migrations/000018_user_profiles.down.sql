@@ -0,0 +1,52 @@
+-- Times go back to the wall time of Asia/Shanghai
+ALTER TABLE projects
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN archived_at TYPE TIMESTAMP USING archived_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE tasks
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN deleted_at TYPE TIMESTAMP USING deleted_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE task_assignments
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE webhooks
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE webhook_deliveries
+    ALTER COLUMN next_attempt_at TYPE TIMESTAMP USING next_attempt_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN delivered_at TYPE TIMESTAMP USING delivered_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE digest_subscriptions
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE digest_deliveries
+    ALTER COLUMN sent_at TYPE TIMESTAMP USING sent_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE task_views
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE project_invitations
+    ALTER COLUMN expires_at TYPE TIMESTAMP USING expires_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN responded_at TYPE TIMESTAMP USING responded_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE organizations
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE organization_members
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE teams
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai',
+    ALTER COLUMN updated_at TYPE TIMESTAMP USING updated_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE team_members
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai';
+ALTER TABLE project_teams
+    ALTER COLUMN created_at TYPE TIMESTAMP USING created_at AT TIME ZONE 'Asia/Shanghai';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_digests,
+    DROP COLUMN IF EXISTS email_invitations,
+    DROP COLUMN IF EXISTS date_format,
+    DROP COLUMN IF EXISTS locale,
+    DROP COLUMN IF EXISTS time_zone,
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS display_name;
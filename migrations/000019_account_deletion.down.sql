@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS user_exports;
+
+ALTER TABLE projects DROP CONSTRAINT projects_owner_id_fkey;
+ALTER TABLE projects ADD CONSTRAINT projects_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE tasks DROP CONSTRAINT tasks_owner_id_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS deletion_scheduled_at;
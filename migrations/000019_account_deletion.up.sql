@@ -0,0 +1,28 @@
+-- Deleted accounts are anonymized but kept, the tasks and projects they leave behind still belong to the team
+ALTER TABLE users
+    ADD COLUMN deletion_scheduled_at TIMESTAMPTZ, -- The account is deleted once it's passed, NULL when not requested
+    ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- Deleting the row of a user must never take the team's tasks and projects with it
+ALTER TABLE tasks DROP CONSTRAINT tasks_owner_id_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE RESTRICT;
+ALTER TABLE projects DROP CONSTRAINT projects_owner_id_fkey;
+ALTER TABLE projects ADD CONSTRAINT projects_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE RESTRICT;
+
+-- Create the user_exports table, the archives of the data of users built in the background
+CREATE TABLE user_exports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    status VARCHAR(10) NOT NULL DEFAULT 'pending', -- pending, ready or failed
+    file_name TEXT NOT NULL DEFAULT '', -- Object of the archive once ready
+    error TEXT NOT NULL DEFAULT '',
+    leased_until TIMESTAMPTZ, -- Set while a worker builds it, a crashed worker doesn't lose it
+    completed_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ, -- The archive is removed once it's passed
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_exports_user_created ON user_exports(user_id, created_at DESC);
+CREATE INDEX idx_user_exports_pending ON user_exports(created_at) WHERE status = 'pending';
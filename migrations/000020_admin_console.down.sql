@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS audit_logs;
+
+DROP INDEX IF EXISTS idx_users_password_reset_token;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_reset_expires_at,
+    DROP COLUMN IF EXISTS password_reset_token_hash,
+    DROP COLUMN IF EXISTS disabled_at,
+    DROP COLUMN IF EXISTS is_admin;
@@ -0,0 +1,22 @@
+-- System administrators, and accounts disabled by them
+ALTER TABLE users
+    ADD COLUMN is_admin BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN disabled_at TIMESTAMPTZ,
+    ADD COLUMN password_reset_token_hash CHAR(64), -- SHA-256 of the emailed token, set while a reset is forced
+    ADD COLUMN password_reset_expires_at TIMESTAMPTZ;
+
+CREATE UNIQUE INDEX idx_users_password_reset_token ON users(password_reset_token_hash) WHERE password_reset_token_hash IS NOT NULL;
+
+-- Create the audit_logs table, every action of the administrators
+CREATE TABLE audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(20) NOT NULL DEFAULT '', -- user or file, empty when the action has no target
+    target_id TEXT NOT NULL DEFAULT '',
+    detail JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_logs_created ON audit_logs(created_at DESC);
+CREATE INDEX idx_audit_logs_target ON audit_logs(target_type, target_id);
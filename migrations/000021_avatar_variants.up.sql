@@ -0,0 +1,7 @@
+-- Square variants of the uploaded avatars, users.avatar_link keeps naming the largest one
+CREATE TABLE user_avatars (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    size SMALLINT NOT NULL,
+    file_name TEXT NOT NULL,
+    PRIMARY KEY (user_id, size)
+);
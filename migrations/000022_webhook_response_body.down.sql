@@ -0,0 +1 @@
+ALTER TABLE webhook_deliveries ADD COLUMN response_body TEXT;
@@ -0,0 +1,2 @@
+-- Receiver responses aren't kept anymore, they could hold what an internal service answered
+ALTER TABLE webhook_deliveries DROP COLUMN response_body;
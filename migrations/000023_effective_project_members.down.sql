@@ -0,0 +1 @@
+DROP FUNCTION IF EXISTS effective_project_members(UUID);
@@ -0,0 +1,33 @@
+-- Everyone having a role on a project and where it comes from, the single place roles are computed.
+-- Nobody outside the organization has a role, the owner is taken from the project itself,
+-- owners and admins of the organization are admins, otherwise the highest of direct and team roles wins
+CREATE FUNCTION effective_project_members(p_project_id UUID) RETURNS TABLE (user_id UUID, role VARCHAR, teams TEXT[]) AS $$
+    WITH access AS (
+        SELECT p.owner_id AS user_id, 'owner' AS role, NULL AS team FROM projects p WHERE p.id = p_project_id
+        UNION ALL
+        SELECT pm.user_id, pm.role, NULL FROM project_members pm WHERE pm.project_id = p_project_id
+        UNION ALL
+        SELECT tm.user_id, pt.role, t.name
+        FROM project_teams pt
+        INNER JOIN teams t ON t.id = pt.team_id
+        INNER JOIN team_members tm ON tm.team_id = pt.team_id
+        WHERE pt.project_id = p_project_id
+        UNION ALL
+        SELECT om.user_id, 'admin', NULL
+        FROM projects p
+        INNER JOIN organization_members om ON om.organization_id = p.organization_id
+        WHERE p.id = p_project_id AND om.role IN ('owner', 'admin')
+    )
+    SELECT
+        a.user_id,
+        CASE
+            WHEN bool_or(a.role = 'owner') THEN 'owner'
+            WHEN bool_or(a.role = 'admin') THEN 'admin'
+            ELSE 'member'
+        END::VARCHAR,
+        COALESCE(array_agg(a.team ORDER BY a.team) FILTER (WHERE a.team IS NOT NULL), '{}')::TEXT[]
+    FROM access a
+    INNER JOIN projects p ON p.id = p_project_id
+    INNER JOIN organization_members om ON om.organization_id = p.organization_id AND om.user_id = a.user_id
+    GROUP BY a.user_id
+$$ LANGUAGE sql STABLE;
@@ -0,0 +1,218 @@
+package db_helper
+
+import (
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ProjectHelperDB inserts and reads the rows around projects: users, organizations, teams, tasks and milestones.
+type ProjectHelperDB struct {
+	DB *sql.DB
+}
+
+func (h *ProjectHelperDB) exec(query string, args ...interface{}) {
+	if _, err := h.DB.Exec(query, args...); err != nil {
+		panic(err)
+	}
+}
+
+func (h *ProjectHelperDB) insert(query string, args ...interface{}) string {
+	id, _ := uuid.NewV7()
+	h.exec(query, append([]interface{}{id}, args...)...)
+
+	return id.String()
+}
+
+func (h *ProjectHelperDB) AddUserDB(username string) string {
+	return h.insert(
+		"INSERT INTO users(id, username, password, email) VALUES ($1, $2, 'password', $3)",
+		username,
+		username+"@gmail.com",
+	)
+}
+
+func (h *ProjectHelperDB) AddOrganizationDB(name string) string {
+	return h.insert("INSERT INTO organizations(id, name) VALUES ($1, $2)", name)
+}
+
+func (h *ProjectHelperDB) AddOrganizationMemberDB(organizationId string, userId string, role string) {
+	h.exec("INSERT INTO organization_members(organization_id, user_id, role) VALUES ($1, $2, $3)", organizationId, userId, role)
+}
+
+func (h *ProjectHelperDB) AddProjectDB(organizationId string, ownerId string, title string) string {
+	return h.insert(
+		"INSERT INTO projects(id, title, priority, status, owner_id, organization_id) VALUES ($1, $2, 'High', 'To Do', $3, $4)",
+		title,
+		ownerId,
+		organizationId,
+	)
+}
+
+func (h *ProjectHelperDB) AddProjectMemberDB(projectId string, userId string, role string) {
+	h.exec("INSERT INTO project_members(project_id, user_id, role) VALUES ($1, $2, $3)", projectId, userId, role)
+}
+
+func (h *ProjectHelperDB) AddTeamDB(organizationId string, name string, memberIds ...string) string {
+	id := h.insert("INSERT INTO teams(id, organization_id, name) VALUES ($1, $2, $3)", organizationId, name)
+	for _, memberId := range memberIds {
+		h.exec("INSERT INTO team_members(team_id, user_id) VALUES ($1, $2)", id, memberId)
+	}
+
+	return id
+}
+
+func (h *ProjectHelperDB) AddProjectTeamDB(projectId string, teamId string, role string) {
+	h.exec("INSERT INTO project_teams(project_id, team_id, role) VALUES ($1, $2, $3)", projectId, teamId, role)
+}
+
+func (h *ProjectHelperDB) SetProjectDB(id string, column string, value interface{}) {
+	h.exec("UPDATE projects SET "+column+" = $2 WHERE id = $1", id, value)
+}
+
+func (h *ProjectHelperDB) AddMilestoneDB(projectId string, name string, state string) string {
+	return h.insert(
+		"INSERT INTO milestones(id, project_id, name, start_date, end_date, state) VALUES ($1, $2, $3, '2024-03-01', '2024-03-14', $4)",
+		projectId,
+		name,
+		state,
+	)
+}
+
+// AddTaskDB adds a task to the project, or a personal task with an empty projectId.
+func (h *ProjectHelperDB) AddTaskDB(projectId string, ownerId string, title string, status string, dueDate string) string {
+	return h.insert(
+		`INSERT INTO tasks(id, title, description, priority, status, project_id, owner_id, due_date)
+		 VALUES ($1, $2, '', 'Low', $3, NULLIF($4, '')::UUID, $5, NULLIF($6, '')::DATE)`,
+		title,
+		status,
+		projectId,
+		ownerId,
+		dueDate,
+	)
+}
+
+func (h *ProjectHelperDB) SetTaskDB(id string, column string, value interface{}) {
+	h.exec("UPDATE tasks SET "+column+" = $2 WHERE id = $1", id, value)
+}
+
+func (h *ProjectHelperDB) AssignTaskDB(taskId string, userId string) {
+	h.exec("INSERT INTO task_assignments(task_id, user_id) VALUES ($1, $2)", taskId, userId)
+}
+
+// ProjectDB is a project as it's stored.
+type ProjectDB struct {
+	Title          string
+	OwnerId        string
+	OrganizationId string
+	IsTemplate     bool
+}
+
+func (h *ProjectHelperDB) GetProjectDB(id string) ProjectDB {
+	var project ProjectDB
+	err := h.DB.QueryRow(
+		"SELECT title, owner_id, organization_id, is_template FROM projects WHERE id = $1",
+		id,
+	).Scan(&project.Title, &project.OwnerId, &project.OrganizationId, &project.IsTemplate)
+	if err != nil {
+		panic(err)
+	}
+
+	return project
+}
+
+// GetProjectMembersDB returns the role of every direct member of the project by user id.
+func (h *ProjectHelperDB) GetProjectMembersDB(projectId string) map[string]string {
+	rows, err := h.DB.Query("SELECT user_id, role FROM project_members WHERE project_id = $1", projectId)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	members := make(map[string]string)
+	for rows.Next() {
+		var userId, role string
+		if err := rows.Scan(&userId, &role); err != nil {
+			panic(err)
+		}
+		members[userId] = role
+	}
+
+	return members
+}
+
+// TaskDB is a task as it's stored, along with its assignees.
+type TaskDB struct {
+	Id          string
+	Title       string
+	Status      string
+	Priority    string
+	ProjectId   string
+	MilestoneId string
+	DueDate     string
+	Deleted     bool
+	AssigneeIds []string
+}
+
+// GetTasksDB returns the tasks of the project, deleted ones included, ordered by title.
+func (h *ProjectHelperDB) GetTasksDB(projectId string) []TaskDB {
+	return h.queryTasks("WHERE t.project_id = $1", projectId)
+}
+
+func (h *ProjectHelperDB) GetTaskDB(id string) TaskDB {
+	return h.queryTasks("WHERE t.id = $1", id)[0]
+}
+
+func (h *ProjectHelperDB) queryTasks(where string, arg string) []TaskDB {
+	rows, err := h.DB.Query(`
+		SELECT
+			t.id, t.title, t.status, t.priority,
+			COALESCE(t.project_id::TEXT, ''), COALESCE(t.milestone_id::TEXT, ''), COALESCE(to_char(t.due_date, 'YYYY-MM-DD'), ''),
+			t.deleted_at IS NOT NULL,
+			COALESCE(array_agg(ta.user_id::TEXT ORDER BY ta.user_id) FILTER (WHERE ta.user_id IS NOT NULL), '{}')
+		FROM tasks t
+		LEFT JOIN task_assignments ta ON ta.task_id = t.id
+		`+where+`
+		GROUP BY t.id
+		ORDER BY t.title`, arg)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	var tasks []TaskDB
+	for rows.Next() {
+		var task TaskDB
+		err := rows.Scan(
+			&task.Id,
+			&task.Title,
+			&task.Status,
+			&task.Priority,
+			&task.ProjectId,
+			&task.MilestoneId,
+			&task.DueDate,
+			&task.Deleted,
+			pq.Array(&task.AssigneeIds),
+		)
+		if err != nil {
+			panic(err)
+		}
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
+
+func (h *ProjectHelperDB) GetMilestoneStateDB(id string) string {
+	var state string
+	if err := h.DB.QueryRow("SELECT state FROM milestones WHERE id = $1", id).Scan(&state); err != nil {
+		panic(err)
+	}
+
+	return state
+}
+
+func (h *ProjectHelperDB) CleanProjectDB() {
+	// Everything around projects references users or organizations
+	_, _ = h.DB.Exec("TRUNCATE TABLE users, organizations CASCADE")
+}